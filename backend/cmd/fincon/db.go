@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/joaopsramos/fincon/internal/config"
+	"github.com/joaopsramos/fincon/internal/migrate"
+)
+
+func dbCommand(ctx context.Context, args []string) error {
+	return dispatch(ctx, "fincon db", []command{
+		{"create", "", "create the database", dbCreate},
+		{"migrate", "<up|down [n]|status|new <name>>", "manage the migrations", dbMigrate},
+	}, args)
+}
+
+func dbCreate(ctx context.Context, args []string) error {
+	if _, err := parseArgs(newFlagSet("fincon db create", ""), args, 0); err != nil {
+		return err
+	}
+
+	dbCfg := config.Get().Database
+	// Can't use config.PostgresDSN() because it contains the database name, which doesn't exist yet
+	db := config.NewPostgresConn(fmt.Sprintf(
+		"host=%s port=%s user=%s password=%s sslmode=disable",
+		dbCfg.Host,
+		dbCfg.Port,
+		dbCfg.User,
+		dbCfg.Pass,
+	))
+
+	slog.Info("Creating database...", "name", dbCfg.Name)
+	return db.WithContext(ctx).Exec("CREATE DATABASE " + dbCfg.Name).Error
+}
+
+func dbMigrate(ctx context.Context, args []string) error {
+	return dispatch(ctx, "fincon db migrate", []command{
+		{"up", "", "apply every pending migration", migrateUp},
+		{"down", "[n]", "roll back the last n migrations (default 1)", migrateDown},
+		{"status", "", "list migrations and when they were applied", migrateStatus},
+		{"new", "<name>", "create up and down files for a new migration", migrateNew},
+	}, args)
+}
+
+func newMigrator() *migrate.Migrator {
+	return migrate.New(newDB(), migrate.Migrations(), migrate.Opts{})
+}
+
+func migrateUp(ctx context.Context, args []string) error {
+	if _, err := parseArgs(newFlagSet("fincon db migrate up", ""), args, 0); err != nil {
+		return err
+	}
+
+	applied, err := newMigrator().Up(ctx)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Migrated", "applied", len(applied))
+	return nil
+}
+
+func migrateDown(ctx context.Context, args []string) error {
+	if len(args) > 1 {
+		return errors.New("usage: fincon db migrate down [n]")
+	}
+
+	steps := 1
+	if len(args) == 1 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid number of steps %q", args[0])
+		}
+		steps = n
+	}
+
+	rolledBack, err := newMigrator().Down(ctx, steps)
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Rolled back", "migrations", len(rolledBack))
+	return nil
+}
+
+func migrateStatus(ctx context.Context, args []string) error {
+	if _, err := parseArgs(newFlagSet("fincon db migrate status", ""), args, 0); err != nil {
+		return err
+	}
+
+	statuses, err := newMigrator().Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "MIGRATION\tAPPLIED AT")
+	for _, s := range statuses {
+		appliedAt := "pending"
+		if s.AppliedAt != nil {
+			appliedAt = s.AppliedAt.Local().Format("2006-01-02 15:04:05")
+		}
+
+		fmt.Fprintf(w, "%s\t%s\n", s.Migration, appliedAt)
+	}
+
+	return w.Flush()
+}
+
+func migrateNew(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: fincon db migrate new <name>")
+	}
+
+	up, down, err := migrate.Create(migrate.Dir, args[0])
+	if err != nil {
+		return err
+	}
+
+	slog.Info("Created migration", "up", up, "down", down)
+	return nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/joaopsramos/fincon/internal/repository"
+	"github.com/joaopsramos/fincon/internal/service"
+)
+
+func exportCommand(ctx context.Context, args []string) error {
+	fs := newFlagSet("fincon export", "[-format json|csv] [-o file] <email>")
+	format := fs.String("format", "json", "json, or csv for a zip with one file per kind of data")
+	output := fs.String("o", "", "file to write to, defaults to stdout")
+
+	positional, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	if *format != "json" && *format != "csv" {
+		return errors.New("format must be one of: json, csv")
+	}
+
+	db := newDB()
+
+	user, err := repository.NewPostgresUser(db).GetByEmail(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	exportService := service.NewExportService(
+		repository.NewPostgresUser(db),
+		repository.NewPostgresSalary(db),
+		repository.NewPostgresGoal(db),
+		repository.NewPostgresExpense(db),
+		repository.NewPostgresRecurringExpense(db),
+		repository.NewPostgresIncome(db),
+		repository.NewPostgresTag(db),
+		repository.NewPostgresSavingsTarget(db),
+		repository.NewPostgresTransactor(db),
+	)
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		w = f
+	}
+
+	if *format == "csv" {
+		return exportService.WriteCSVZip(ctx, user.ID, w)
+	}
+
+	return exportService.WriteJSON(ctx, user.ID, w)
+}
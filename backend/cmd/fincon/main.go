@@ -1,32 +1,125 @@
 package main
 
 import (
-	"log"
-	"log/slog"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
 	"os"
+	"strings"
 
-	"github.com/honeybadger-io/honeybadger-go"
-	"github.com/joaopsramos/fincon/internal/api"
 	"github.com/joaopsramos/fincon/internal/config"
-	"github.com/joaopsramos/fincon/internal/mail"
+	"gorm.io/gorm"
 )
 
+type command struct {
+	name    string
+	args    string
+	summary string
+	run     func(ctx context.Context, args []string) error
+}
+
+var errUsage = errors.New("invalid usage")
+
+func commands() []command {
+	return []command{
+		{"serve", "", "start the API server", serve},
+		{"db", "<create|migrate>", "create the database or manage its migrations", dbCommand},
+		{"user", "<create|reset-password|delete|list>", "manage users", userCommand},
+		{"export", "[-format json|csv] [-o file] <email>", "export all the data of a user", exportCommand},
+		{"seed", "[-seed n] [-users n] [-months n]", "fill the database with demo users", seedCommand},
+	}
+}
+
 func init() {
 	config.Load(".")
 }
 
 func main() {
-	cfg := config.Get()
+	if len(os.Args) < 2 {
+		printUsage(os.Stderr, "fincon", commands())
+		os.Exit(2)
+	}
+
+	if err := dispatch(context.Background(), "fincon", commands(), os.Args[1:]); err != nil {
+		if !errors.Is(err, errUsage) && !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "error:", err)
+		}
+
+		os.Exit(1)
+	}
+}
+
+// dispatch runs the command named by the first argument with the remaining ones
+func dispatch(ctx context.Context, prefix string, cmds []command, args []string) error {
+	if len(args) == 0 {
+		printUsage(os.Stderr, prefix, cmds)
+		return errUsage
+	}
+
+	for _, cmd := range cmds {
+		if cmd.name == args[0] {
+			return cmd.run(ctx, args[1:])
+		}
+	}
+
+	if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printUsage(os.Stdout, prefix, cmds)
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "unknown command %q\n\n", strings.Join(append([]string{prefix}, args[0]), " "))
+	printUsage(os.Stderr, prefix, cmds)
 
-	honeybadger.Configure(honeybadger.Configuration{APIKey: cfg.HoneybadgerAPIKey})
-	defer honeybadger.Monitor()
+	return errUsage
+}
+
+func printUsage(w io.Writer, prefix string, cmds []command) {
+	fmt.Fprintf(w, "Usage: %s <command>\n\nCommands:\n", prefix)
+
+	for _, cmd := range cmds {
+		fmt.Fprintf(w, "  %-52s %s\n", strings.TrimSpace(cmd.name+" "+cmd.args), cmd.summary)
+	}
+}
+
+// newFlagSet builds the flags of a command, printing its usage on parsing errors
+func newFlagSet(name string, args string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: %s %s\n", name, args)
+		fs.PrintDefaults()
+	}
+
+	return fs
+}
+
+// parseArgs parses the flags of fs, which may come before or after the positional
+// arguments, requiring exactly n positional ones
+func parseArgs(fs *flag.FlagSet, args []string, n int) ([]string, error) {
+	var positional []string
+
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
 
-	db := config.NewPostgresConn(cfg.PostgresDSN())
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
-	mailer := mail.NewMailer()
+		if fs.NArg() == 0 {
+			break
+		}
 
-	api := api.NewApp(db, logger, mailer)
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	if len(positional) != n {
+		fs.Usage()
+		return nil, errUsage
+	}
+
+	return positional, nil
+}
 
-	api.SetupAll()
-	log.Fatal(api.Listen())
+func newDB() *gorm.DB {
+	return config.NewPostgresConn(config.Get().PostgresDSN())
 }
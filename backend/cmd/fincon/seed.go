@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/joaopsramos/fincon/internal/config"
+	"github.com/joaopsramos/fincon/internal/seed"
+)
+
+func seedCommand(ctx context.Context, args []string) error {
+	fs := newFlagSet("fincon seed", "[-seed n] [-users n] [-months n]")
+	seedFlag := fs.Uint64("seed", seed.DefaultSeed, "seed of the generated data, the same seed always generates the same data")
+	users := fs.Int("users", seed.DefaultUsers, "number of users")
+	months := fs.Int("months", seed.DefaultMonths, "months of expenses, up to the current one")
+
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+
+	if config.Get().AppEnv == "prod" {
+		return errors.New("refusing to seed a production database")
+	}
+
+	result, err := seed.Run(ctx, newDB(), seed.Opts{Seed: *seedFlag, Users: *users, Months: *months})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Seeded %d users with %d expenses, their password is %q\n", len(result.Users), result.Expenses, seed.DemoPassword)
+	for _, u := range result.Users {
+		fmt.Printf("  %s\n", u.Email)
+	}
+
+	return nil
+}
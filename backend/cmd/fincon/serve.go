@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/honeybadger-io/honeybadger-go"
+	"github.com/joaopsramos/fincon/internal/api"
+	"github.com/joaopsramos/fincon/internal/config"
+	"github.com/joaopsramos/fincon/internal/mail"
+	"github.com/joaopsramos/fincon/internal/repository"
+	"github.com/joaopsramos/fincon/internal/service"
+	"gorm.io/gorm"
+)
+
+const (
+	digestInterval    = time.Hour
+	recurringInterval = time.Hour
+)
+
+func serve(ctx context.Context, args []string) error {
+	if _, err := parseArgs(newFlagSet("fincon serve", ""), args, 0); err != nil {
+		return err
+	}
+
+	// The server and the background jobs stop on SIGINT or SIGTERM, which is how the
+	// machine is stopped on deploys
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cfg := config.Get()
+
+	honeybadger.Configure(honeybadger.Configuration{APIKey: cfg.HoneybadgerAPIKey})
+	defer honeybadger.Monitor()
+
+	db := newDB()
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	outbox := repository.NewPostgresOutbox(db)
+	mailer := mail.NewQueuedMailer(outbox)
+
+	worker := mail.NewWorker(outbox, mail.NewMailer(), logger, mail.WorkerOpts{})
+	digests := newDigestService(db, mailer, logger)
+	recurring := service.NewRecurringExpenseService(repository.NewPostgresRecurringExpense(db), repository.NewPostgresGoal(db))
+
+	var wg sync.WaitGroup
+	jobs := []func(){
+		func() { worker.Run(ctx) },
+		func() { runDigests(ctx, digests, logger) },
+		func() { runRecurring(ctx, recurring, logger) },
+	}
+
+	for _, job := range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			job()
+		}()
+	}
+
+	api := api.NewApp(db, logger, mailer)
+
+	api.SetupAll()
+	err := api.Listen(ctx)
+
+	// Stops the jobs as well when the server fails to start
+	stop()
+	wg.Wait()
+	logger.Info("Background jobs stopped")
+
+	return err
+}
+
+func newExpenseService(db *gorm.DB, mailer mail.Mailer, logger *slog.Logger) service.ExpenseService {
+	return service.NewExpenseService(
+		repository.NewPostgresExpense(db),
+		repository.NewPostgresGoal(db),
+		repository.NewPostgresSalary(db),
+		repository.NewPostgresRecurringExpense(db),
+		repository.NewPostgresIncome(db),
+		repository.NewPostgresTag(db),
+		repository.NewPostgresTransactor(db),
+		service.NewAlertService(repository.NewPostgresNotification(db), repository.NewPostgresUser(db), mailer),
+		logger,
+	)
+}
+
+func newDigestService(db *gorm.DB, mailer mail.Mailer, logger *slog.Logger) service.DigestService {
+	return service.NewDigestService(
+		repository.NewPostgresDigest(db),
+		newExpenseService(db, mailer, logger),
+		repository.NewPostgresTransactor(db),
+		mailer,
+	)
+}
+
+// runDigests periodically sends the budget digests of the last period to the users who
+// opted in and didn't receive them yet
+func runDigests(ctx context.Context, digests service.DigestService, logger *slog.Logger) {
+	ticker := time.NewTicker(digestInterval)
+	defer ticker.Stop()
+
+	for {
+		sent, err := digests.SendDue(ctx, time.Now().UTC())
+		if err != nil {
+			logger.Error("Failed to send digests", "error", err)
+		}
+
+		if sent > 0 {
+			logger.Info("Digests sent", "count", sent)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runRecurring periodically creates the expenses of the recurring expense templates, so
+// each month gets them as soon as it starts
+func runRecurring(ctx context.Context, recurring service.RecurringExpenseService, logger *slog.Logger) {
+	ticker := time.NewTicker(recurringInterval)
+	defer ticker.Stop()
+
+	for {
+		created, err := recurring.MaterializeDue(ctx, time.Now().UTC())
+		if err != nil {
+			logger.Error("Failed to materialize recurring expenses", "error", err)
+		}
+
+		if created > 0 {
+			logger.Info("Recurring expenses materialized", "count", created)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	netmail "net/mail"
+	"os"
+	"text/tabwriter"
+
+	"github.com/joaopsramos/fincon/internal/mail"
+	"github.com/joaopsramos/fincon/internal/repository"
+	"github.com/joaopsramos/fincon/internal/service"
+)
+
+const (
+	minPasswordLength = 8
+	maxPasswordLength = 72
+)
+
+func userCommand(ctx context.Context, args []string) error {
+	return dispatch(ctx, "fincon user", []command{
+		{"create", "[-password p] [-salary n] [-verified] <email>", "create a user with the default goals", userCreate},
+		{"reset-password", "[-password p] <email>", "set a new password and log the user out", userResetPassword},
+		{"delete", "-yes <email>", "delete a user and all of their data", userDelete},
+		{"list", "", "list every user", userList},
+	}, args)
+}
+
+func newUserService() service.UserService {
+	db := newDB()
+	return service.NewUserService(
+		repository.NewPostgresUser(db),
+		repository.NewPostgresSession(db),
+		repository.NewPostgresTransactor(db),
+		mail.NewQueuedMailer(repository.NewPostgresOutbox(db)),
+	)
+}
+
+func userCreate(ctx context.Context, args []string) error {
+	fs := newFlagSet("fincon user create", "[-password p] [-salary n] [-verified] <email>")
+	password := fs.String("password", "", "password of the user, a random one is generated when empty")
+	salary := fs.Float64("salary", 5000, "monthly salary")
+	verified := fs.Bool("verified", false, "mark the email as verified")
+
+	positional, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	email, err := parseEmail(positional[0])
+	if err != nil {
+		return err
+	}
+
+	if *salary <= 0 {
+		return errors.New("salary must be greater than 0")
+	}
+
+	pass, generated, err := passwordOrRandom(*password)
+	if err != nil {
+		return err
+	}
+
+	userService := newUserService()
+
+	user, _, err := userService.Create(ctx, service.CreateUserDTO{
+		Email:           email,
+		Password:        pass,
+		CreateSalaryDTO: service.CreateSalaryDTO{Amount: *salary},
+	})
+	if err != nil {
+		return err
+	}
+
+	if *verified {
+		if err := userService.MarkAsVerified(ctx, user.ID); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Created user %s (%s)\n", user.Email, user.ID)
+	if generated {
+		fmt.Printf("Password: %s\n", pass)
+	}
+
+	return nil
+}
+
+func userResetPassword(ctx context.Context, args []string) error {
+	fs := newFlagSet("fincon user reset-password", "[-password p] <email>")
+	password := fs.String("password", "", "new password, a random one is generated when empty")
+
+	positional, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	pass, generated, err := passwordOrRandom(*password)
+	if err != nil {
+		return err
+	}
+
+	userService := newUserService()
+
+	user, err := userService.GetByEmail(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	if err := userService.SetPassword(ctx, user.ID, pass); err != nil {
+		return err
+	}
+
+	fmt.Printf("Password of %s updated, every session was revoked\n", user.Email)
+	if generated {
+		fmt.Printf("Password: %s\n", pass)
+	}
+
+	return nil
+}
+
+func userDelete(ctx context.Context, args []string) error {
+	fs := newFlagSet("fincon user delete", "-yes <email>")
+	yes := fs.Bool("yes", false, "confirm the deletion, it can't be undone")
+
+	positional, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	userService := newUserService()
+
+	user, err := userService.GetByEmail(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	if !*yes {
+		return fmt.Errorf("refusing to delete %s (%s) and all of their data without -yes", user.Email, user.ID)
+	}
+
+	if err := userService.ForceDelete(ctx, user.ID); err != nil {
+		return err
+	}
+
+	fmt.Printf("Deleted user %s (%s)\n", user.Email, user.ID)
+	return nil
+}
+
+func userList(ctx context.Context, args []string) error {
+	if _, err := parseArgs(newFlagSet("fincon user list", ""), args, 0); err != nil {
+		return err
+	}
+
+	userService := newUserService()
+
+	users, err := userService.All(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tEMAIL\tVERIFIED\t2FA\tCREATED AT")
+	for _, u := range users {
+		fmt.Fprintf(w, "%s\t%s\t%t\t%t\t%s\n", u.ID, u.Email, u.IsVerified(), u.TwoFactorEnabled(), u.CreatedAt.Local().Format("2006-01-02 15:04"))
+	}
+
+	return w.Flush()
+}
+
+func parseEmail(email string) (string, error) {
+	addr, err := netmail.ParseAddress(email)
+	if err != nil || addr.Address != email || len(email) > 160 {
+		return "", fmt.Errorf("invalid email %q", email)
+	}
+
+	return email, nil
+}
+
+// passwordOrRandom validates password like the API does, generating a random one when
+// it's empty. The returned bool tells if it was generated.
+func passwordOrRandom(password string) (string, bool, error) {
+	if password == "" {
+		b := make([]byte, 12)
+		if _, err := rand.Read(b); err != nil {
+			return "", false, err
+		}
+
+		return base64.RawURLEncoding.EncodeToString(b), true, nil
+	}
+
+	if len(password) < minPasswordLength || len(password) > maxPasswordLength {
+		return "", false, fmt.Errorf("password must have between %d and %d characters", minPasswordLength, maxPasswordLength)
+	}
+
+	return password, false, nil
+}
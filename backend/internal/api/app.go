@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"os"
@@ -17,6 +18,7 @@ import (
 	"github.com/honeybadger-io/honeybadger-go"
 	"github.com/joaopsramos/fincon/internal/auth"
 	"github.com/joaopsramos/fincon/internal/config"
+	"github.com/joaopsramos/fincon/internal/errs"
 	"github.com/joaopsramos/fincon/internal/mail"
 	"github.com/joaopsramos/fincon/internal/repository"
 	"github.com/joaopsramos/fincon/internal/service"
@@ -24,14 +26,33 @@ import (
 	"gorm.io/gorm"
 )
 
+// shutdownTimeout is how long Listen waits for the requests in flight when stopping
+const shutdownTimeout = 10 * time.Second
+
 type App struct {
 	Router *chi.Mux
 	logger *slog.Logger
 
-	userHandler    *UserHandler
-	salaryHandler  *SalaryHandler
-	goalHandler    *GoalHandler
-	expenseHandler *ExpenseHandler
+	userService        service.UserService
+	sessionService     service.SessionService
+	householdService   service.HouseholdService
+	verificationPolicy config.VerificationPolicy
+
+	userHandler             *UserHandler
+	salaryHandler           *SalaryHandler
+	goalHandler             *GoalHandler
+	expenseHandler          *ExpenseHandler
+	recurringExpenseHandler *RecurringExpenseHandler
+	exportHandler           *ExportHandler
+	incomeHandler           *IncomeHandler
+	savingsTargetHandler    *SavingsTargetHandler
+	sessionHandler          *SessionHandler
+	twoFactorHandler        *TwoFactorHandler
+	householdHandler        *HouseholdHandler
+	digestHandler           *DigestHandler
+	notificationHandler     *NotificationHandler
+	tagHandler              *TagHandler
+	reportHandler           *ReportHandler
 }
 
 func NewApp(db *gorm.DB, logger *slog.Logger, mailer mail.Mailer) *App {
@@ -39,22 +60,67 @@ func NewApp(db *gorm.DB, logger *slog.Logger, mailer mail.Mailer) *App {
 	salaryRepo := repository.NewPostgresSalary(db)
 	goalRepo := repository.NewPostgresGoal(db)
 	expenseRepo := repository.NewPostgresExpense(db)
+	recurringExpenseRepo := repository.NewPostgresRecurringExpense(db)
+	incomeRepo := repository.NewPostgresIncome(db)
+	tagRepo := repository.NewPostgresTag(db)
+	sessionRepo := repository.NewPostgresSession(db)
+	householdRepo := repository.NewPostgresHousehold(db)
+	digestRepo := repository.NewPostgresDigest(db)
+	notificationRepo := repository.NewPostgresNotification(db)
+	savingsTargetRepo := repository.NewPostgresSavingsTarget(db)
+	transactor := repository.NewPostgresTransactor(db)
 
 	baseHandler := NewBaseHandler(logger)
 
-	userService := service.NewUserService(userRepo, mailer)
+	userService := service.NewUserService(userRepo, sessionRepo, transactor, mailer)
+	sessionService := service.NewSessionService(sessionRepo)
+	twoFactorService := service.NewTwoFactorService(userRepo)
+	householdService := service.NewHouseholdService(householdRepo, userRepo, transactor, mailer)
 	salaryService := service.NewSalaryService(salaryRepo)
 	goalService := service.NewGoalService(goalRepo)
-	expenseService := service.NewExpenseService(expenseRepo, goalRepo, salaryRepo)
+	alertService := service.NewAlertService(notificationRepo, userRepo, mailer)
+	expenseService := service.NewExpenseService(expenseRepo, goalRepo, salaryRepo, recurringExpenseRepo, incomeRepo, tagRepo, transactor, alertService, logger)
+	recurringExpenseService := service.NewRecurringExpenseService(recurringExpenseRepo, goalRepo)
+	exportService := service.NewExportService(
+		userRepo,
+		salaryRepo,
+		goalRepo,
+		expenseRepo,
+		recurringExpenseRepo,
+		incomeRepo,
+		tagRepo,
+		savingsTargetRepo,
+		transactor,
+	)
+	incomeService := service.NewIncomeService(incomeRepo)
+	savingsTargetService := service.NewSavingsTargetService(savingsTargetRepo, goalRepo, expenseRepo)
+	tagService := service.NewTagService(tagRepo)
+	digestService := service.NewDigestService(digestRepo, expenseService, transactor, mailer)
 
 	return &App{
 		Router: chi.NewRouter(),
 		logger: logger,
 
-		userHandler:    NewUserHandler(baseHandler, userService),
-		salaryHandler:  NewSalaryHandler(baseHandler, salaryService),
-		goalHandler:    NewGoalHandler(baseHandler, goalService, expenseService),
-		expenseHandler: NewExpenseHandler(baseHandler, expenseService),
+		userService:        userService,
+		sessionService:     sessionService,
+		householdService:   householdService,
+		verificationPolicy: config.Get().EmailVerificationPolicy,
+
+		userHandler:             NewUserHandler(baseHandler, userService, sessionService),
+		sessionHandler:          NewSessionHandler(baseHandler, sessionService, twoFactorService),
+		twoFactorHandler:        NewTwoFactorHandler(baseHandler, twoFactorService),
+		householdHandler:        NewHouseholdHandler(baseHandler, householdService),
+		digestHandler:           NewDigestHandler(baseHandler, digestService),
+		notificationHandler:     NewNotificationHandler(baseHandler, alertService),
+		salaryHandler:           NewSalaryHandler(baseHandler, salaryService),
+		goalHandler:             NewGoalHandler(baseHandler, goalService, expenseService),
+		expenseHandler:          NewExpenseHandler(baseHandler, expenseService),
+		recurringExpenseHandler: NewRecurringExpenseHandler(baseHandler, recurringExpenseService),
+		exportHandler:           NewExportHandler(baseHandler, exportService),
+		incomeHandler:           NewIncomeHandler(baseHandler, incomeService),
+		savingsTargetHandler:    NewSavingsTargetHandler(baseHandler, savingsTargetService),
+		tagHandler:              NewTagHandler(baseHandler, tagService),
+		reportHandler:           NewReportHandler(baseHandler, tagService, expenseService),
 	}
 }
 
@@ -63,9 +129,29 @@ func (a *App) SetupAll() {
 	a.SetupRoutes()
 }
 
-func (a *App) Listen() error {
-	slog.Info("Listening on port 4000")
-	return http.ListenAndServe(":4000", honeybadger.Handler(a.Router))
+// Listen serves the API until ctx is cancelled, then stops accepting connections and
+// waits up to shutdownTimeout for the requests in flight
+func (a *App) Listen(ctx context.Context) error {
+	server := &http.Server{Addr: ":4000", Handler: honeybadger.Handler(a.Router)}
+
+	errCh := make(chan error, 1)
+	go func() {
+		slog.Info("Listening on port 4000")
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	slog.Info("Shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	return server.Shutdown(shutdownCtx)
 }
 
 func (a *App) SetupMiddlewares() {
@@ -88,7 +174,7 @@ func (a *App) corsMiddleware() func(http.Handler) http.Handler {
 	return cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"*"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "PATCH", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", HouseholdHeader},
 		ExposedHeaders:   []string{"Link"},
 		AllowCredentials: false,
 		MaxAge:           300,
@@ -101,20 +187,58 @@ func (a *App) SetupRoutes() {
 	a.Router.Route("/api", func(r chi.Router) {
 		// Public routes
 		a.userHandler.RegisterRoutes(r)
+		a.sessionHandler.RegisterPublicRoutes(r)
+		a.digestHandler.RegisterPublicRoutes(r)
 
 		// Protected routes
 		r.Group(func(r chi.Router) {
 			r.Use(jwtauth.Verifier(tokenAuth))
 			r.Use(jwtauth.Authenticator(tokenAuth))
 			r.Use(a.PutUserIDMiddleware)
+			r.Use(a.ActiveSessionMiddleware)
+
+			a.sessionHandler.RegisterRoutes(r)
+			a.userHandler.RegisterProtectedRoutes(r)
+
+			r.Group(func(r chi.Router) {
+				r.Use(a.VerifiedEmailMiddleware)
+
+				a.twoFactorHandler.RegisterRoutes(r)
+				a.householdHandler.RegisterRoutes(r)
+				a.digestHandler.RegisterRoutes(r)
+				a.notificationHandler.RegisterRoutes(r)
+				// The export holds personal data, so it's always of the user's own account
+				a.exportHandler.RegisterRoutes(r)
 
-			a.salaryHandler.RegisterRoutes(r)
-			a.goalHandler.RegisterRoutes(r)
-			a.expenseHandler.RegisterRoutes(r)
+				// Budget routes, they can act on a household shared with the user
+				r.Group(func(r chi.Router) {
+					r.Use(a.HouseholdMiddleware)
+
+					a.salaryHandler.RegisterRoutes(r)
+					a.goalHandler.RegisterRoutes(r)
+					a.expenseHandler.RegisterRoutes(r)
+					a.recurringExpenseHandler.RegisterRoutes(r)
+					a.incomeHandler.RegisterRoutes(r)
+					a.savingsTargetHandler.RegisterRoutes(r)
+					a.tagHandler.RegisterRoutes(r)
+					a.reportHandler.RegisterRoutes(r)
+				})
+			})
 		})
 	})
 }
 
+// SetVerificationPolicy overrides the email verification policy read from the config
+func (a *App) SetVerificationPolicy(policy config.VerificationPolicy) {
+	a.verificationPolicy = policy
+}
+
+// SetClock overrides the clock used to validate two-factor codes
+func (a *App) SetClock(now func() time.Time) {
+	a.sessionHandler.twoFactorService.SetClock(now)
+	a.twoFactorHandler.twoFactorService.SetClock(now)
+}
+
 // Helper to send JSON responses
 func (a *App) sendJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
@@ -166,6 +290,104 @@ func (a *App) PutUserIDMiddleware(next http.Handler) http.Handler {
 		}
 
 		ctx := context.WithValue(r.Context(), UserIDKey, userID)
+
+		if sid, ok := claims["sid"].(string); ok {
+			if sessionID, err := uuid.Parse(sid); err == nil {
+				ctx = context.WithValue(ctx, SessionIDKey, sessionID)
+			}
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// ActiveSessionMiddleware rejects access tokens whose session was revoked or has expired
+func (a *App) ActiveSessionMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sessionID, ok := r.Context().Value(SessionIDKey).(uuid.UUID)
+		if !ok {
+			a.sendError(w, http.StatusUnauthorized, "session expired or revoked")
+			return
+		}
+
+		active, err := a.sessionService.IsActive(r.Context(), sessionID, a.GetUserIDFromCtx(r))
+		if err != nil {
+			panic(err)
+		}
+
+		if !active {
+			a.sendError(w, http.StatusUnauthorized, "session expired or revoked")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// VerifiedEmailMiddleware restricts accounts that did not confirm their email yet,
+// according to the configured verification policy
+func (a *App) VerifiedEmailMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if a.verificationPolicy == config.VerificationOff || a.verificationPolicy == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		readOnly := r.Method == http.MethodGet || r.Method == http.MethodHead
+		if a.verificationPolicy == config.VerificationReadOnly && readOnly {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		user, err := a.userService.Get(r.Context(), a.GetUserIDFromCtx(r))
+		if err != nil {
+			panic(err)
+		}
+
+		if !user.IsVerified() {
+			a.sendError(w, http.StatusForbidden, "email not verified")
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// HouseholdMiddleware switches the budget a request acts on to the household in the
+// HouseholdHeader, checking the user is a member with a role allowed to do it
+func (a *App) HouseholdMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get(HouseholdHeader)
+		if header == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		householdID, err := uuid.Parse(header)
+		if err != nil {
+			a.sendError(w, http.StatusBadRequest, "invalid household id")
+			return
+		}
+
+		userID := a.GetUserIDFromCtx(r)
+
+		membership, err := a.householdService.Membership(r.Context(), householdID, userID)
+		if errors.Is(err, errs.ErrNotFound{}) {
+			a.sendError(w, http.StatusForbidden, "you are not a member of this household")
+			return
+		} else if err != nil {
+			panic(err)
+		}
+
+		readOnly := r.Method == http.MethodGet || r.Method == http.MethodHead
+		if !readOnly && !membership.Role.CanWrite() {
+			a.sendError(w, http.StatusForbidden, "viewers can't change the household budget")
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), UserIDKey, membership.OwnerID)
+		ctx = context.WithValue(ctx, MemberIDKey, userID)
+
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
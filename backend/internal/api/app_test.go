@@ -12,6 +12,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/joaopsramos/fincon/internal/api"
 	"github.com/joaopsramos/fincon/internal/auth"
+	"github.com/joaopsramos/fincon/internal/config"
+	"github.com/joaopsramos/fincon/internal/domain"
 	"github.com/joaopsramos/fincon/internal/testhelper"
 	"github.com/joaopsramos/fincon/internal/util"
 	"github.com/stretchr/testify/assert"
@@ -147,7 +149,7 @@ func TestApp_PutUserIDMiddleware(t *testing.T) {
 	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{WithoutSetup: true})
 
 	userID := uuid.New()
-	token := auth.GenerateJWTToken(userID, time.Minute)
+	token := auth.GenerateJWTToken(userID, uuid.New(), time.Minute)
 
 	tokenAuth := auth.NewTokenAuth()
 	app.Router.Use(jwtauth.Verifier(tokenAuth))
@@ -166,3 +168,50 @@ func TestApp_PutUserIDMiddleware(t *testing.T) {
 
 	a.Equal(http.StatusOK, w.Result().StatusCode)
 }
+
+func TestApp_VerifiedEmailMiddleware(t *testing.T) {
+	t.Parallel()
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+
+	now := time.Now().UTC()
+	unverified := f.InsertUser()
+	verified := f.InsertUser(&domain.User{VerifiedAt: &now})
+	f.InsertSalary(&domain.Salary{UserID: unverified.ID}, &domain.Salary{UserID: verified.ID})
+
+	tests := []struct {
+		name        string
+		policy      config.VerificationPolicy
+		userID      uuid.UUID
+		getStatus   int
+		writeStatus int
+	}{
+		{"off allows unverified users", config.VerificationOff, unverified.ID, 200, 200},
+		{"read only allows unverified users to read", config.VerificationReadOnly, unverified.ID, 200, 403},
+		{"block denies unverified users", config.VerificationBlock, unverified.ID, 403, 403},
+		{"block allows verified users", config.VerificationBlock, verified.ID, 200, 200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: tt.userID, VerificationPolicy: tt.policy})
+
+			resp := app.Test(http.MethodGet, "/api/salary")
+			a.Equal(tt.getStatus, resp.StatusCode)
+
+			var respBody util.M
+			resp = app.Test(http.MethodPatch, "/api/salary", util.M{"amount": 1000})
+			app.UnmarshalBody(resp.Body, &respBody)
+			a.Equal(tt.writeStatus, resp.StatusCode)
+
+			if tt.writeStatus == 403 {
+				a.Equal(util.M{"error": "email not verified"}, respBody)
+			}
+
+			// unverified users can always log out
+			resp = app.Test(http.MethodDelete, "/api/sessions")
+			a.Equal(204, resp.StatusCode)
+		})
+	}
+}
@@ -26,6 +26,19 @@ func (h *BaseHandler) getUserIDFromCtx(r *http.Request) uuid.UUID {
 	return r.Context().Value(UserIDKey).(uuid.UUID)
 }
 
+func (h *BaseHandler) getSessionIDFromCtx(r *http.Request) uuid.UUID {
+	return r.Context().Value(SessionIDKey).(uuid.UUID)
+}
+
+// getMemberIDFromCtx returns the user acting on a household budget, nil outside of households
+func (h *BaseHandler) getMemberIDFromCtx(r *http.Request) *uuid.UUID {
+	if memberID, ok := r.Context().Value(MemberIDKey).(uuid.UUID); ok {
+		return &memberID
+	}
+
+	return nil
+}
+
 func (h *BaseHandler) sendJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -53,6 +66,10 @@ func (h *BaseHandler) HandleError(w http.ResponseWriter, err error) {
 	case errors.Is(err, errs.ErrInvalidToken):
 		h.sendError(w, http.StatusBadRequest, err.Error())
 		return
+
+	case errors.Is(err, errs.ErrForbidden):
+		h.sendError(w, http.StatusForbidden, err.Error())
+		return
 	}
 
 	panic(err)
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	z "github.com/Oudwins/zog"
+	"github.com/go-chi/chi/v5"
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/service"
+	"github.com/joaopsramos/fincon/internal/util"
+)
+
+type DigestHandler struct {
+	*BaseHandler
+	digestService service.DigestService
+}
+
+var (
+	digestPreferenceSchema = z.Struct(z.Schema{
+		"frequency": z.String().Trim().OneOf(
+			domain.DigestFrequencies(),
+			z.Message("frequency must be one of: none, weekly, monthly"),
+		).Required(),
+	})
+
+	digestUnsubscribeSchema = z.Struct(z.Schema{
+		"token": z.String().Trim().Required(),
+	})
+)
+
+func NewDigestHandler(baseHandler *BaseHandler, digestService service.DigestService) *DigestHandler {
+	return &DigestHandler{
+		BaseHandler:   baseHandler,
+		digestService: digestService,
+	}
+}
+
+func (h *DigestHandler) RegisterPublicRoutes(r chi.Router) {
+	r.With(h.rateLimiter(10, time.Hour)).Post("/digest/unsubscribe", h.Unsubscribe)
+}
+
+func (h *DigestHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/me/digest", h.Get)
+	r.Put("/me/digest", h.Update)
+}
+
+func (h *DigestHandler) Get(w http.ResponseWriter, r *http.Request) {
+	p, err := h.digestService.Preference(r.Context(), h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, p.ToDTO())
+}
+
+func (h *DigestHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Frequency string `json:"frequency"`
+	}
+
+	if errs := util.ParseZodSchema(digestPreferenceSchema, r.Body, &params); errs != nil {
+		h.HandleZodError(w, errs)
+		return
+	}
+
+	p, err := h.digestService.UpdatePreference(r.Context(), domain.DigestFrequency(params.Frequency), h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, p.ToDTO())
+}
+
+func (h *DigestHandler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Token string `json:"token"`
+	}
+
+	if errs := util.ParseZodSchema(digestUnsubscribeSchema, r.Body, &params); errs != nil {
+		h.HandleZodError(w, errs)
+		return
+	}
+
+	if err := h.digestService.Unsubscribe(r.Context(), params.Token); err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
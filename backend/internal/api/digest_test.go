@@ -0,0 +1,78 @@
+package api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/joaopsramos/fincon/internal/auth"
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/testhelper"
+	"github.com/joaopsramos/fincon/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDigestHandler(t *testing.T) {
+	t.Parallel()
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+
+	user := f.InsertUser()
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: user.ID})
+	a := assert.New(t)
+
+	t.Run("digests are off by default", func(t *testing.T) {
+		var respBody util.M
+		resp := app.Test(http.MethodGet, "/api/me/digest")
+		app.UnmarshalBody(resp.Body, &respBody)
+
+		a.Equal(200, resp.StatusCode)
+		a.Equal(util.M{"frequency": "none"}, respBody)
+	})
+
+	t.Run("update frequency", func(t *testing.T) {
+		var respBody util.M
+		resp := app.Test(http.MethodPut, "/api/me/digest", util.M{"frequency": "weekly"})
+		app.UnmarshalBody(resp.Body, &respBody)
+
+		a.Equal(200, resp.StatusCode)
+		a.Equal(util.M{"frequency": "weekly"}, respBody)
+
+		resp = app.Test(http.MethodPut, "/api/me/digest", util.M{"frequency": "monthly"})
+		a.Equal(200, resp.StatusCode)
+
+		resp = app.Test(http.MethodGet, "/api/me/digest")
+		app.UnmarshalBody(resp.Body, &respBody)
+		a.Equal(util.M{"frequency": "monthly"}, respBody)
+	})
+
+	t.Run("invalid frequency", func(t *testing.T) {
+		var respBody util.M
+		resp := app.Test(http.MethodPut, "/api/me/digest", util.M{"frequency": "daily"})
+		app.UnmarshalBody(resp.Body, &respBody)
+
+		a.Equal(400, resp.StatusCode)
+		a.Equal(util.M{"errors": util.M{"frequency": []any{"frequency must be one of: none, weekly, monthly"}}}, respBody)
+	})
+
+	t.Run("unsubscribe without authentication", func(t *testing.T) {
+		public := testhelper.NewTestApp(t, tx)
+
+		resp := public.Test(http.MethodPost, "/api/digest/unsubscribe", util.M{"token": auth.GenerateUnsubscribeToken(user.ID)})
+		a.Equal(204, resp.StatusCode)
+
+		var p domain.DigestPreference
+		tx.First(&p, "user_id = ?", user.ID)
+		a.Equal(domain.DigestNone, p.Frequency)
+	})
+
+	t.Run("unsubscribe with invalid token", func(t *testing.T) {
+		public := testhelper.NewTestApp(t, tx)
+
+		var respBody util.M
+		resp := public.Test(http.MethodPost, "/api/digest/unsubscribe", util.M{"token": "invalid"})
+		public.UnmarshalBody(resp.Body, &respBody)
+
+		a.Equal(400, resp.StatusCode)
+		a.Equal(util.M{"error": "invalid or expired token"}, respBody)
+	})
+}
@@ -3,14 +3,19 @@ package api
 import (
 	"encoding/json"
 	"net/http"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	z "github.com/Oudwins/zog"
 	"github.com/go-chi/chi/v5"
 	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/importer"
 	"github.com/joaopsramos/fincon/internal/service"
 	"github.com/joaopsramos/fincon/internal/util"
+	"github.com/shopspring/decimal"
 )
 
 type ExpenseHandler struct {
@@ -24,6 +29,7 @@ var expenseCreateSchema = z.Struct(z.Schema{
 	"date":         z.Time(z.Time.Format(util.ApiDateLayout)).Required(),
 	"goalID":       z.Int().Required(),
 	"installments": z.Int().GTE(1, z.Message("installments must be greater than or equal to 1")).Optional(),
+	"tagIDs":       z.Slice(z.Int()).Optional(),
 })
 
 var expenseUpdateSchema = z.Struct(z.Schema{
@@ -31,6 +37,7 @@ var expenseUpdateSchema = z.Struct(z.Schema{
 	"value":  z.Float().GTE(0.01, z.Message("value must be greater than 0.01")).Optional(),
 	"date":   z.Time(z.Time.Format(util.ApiDateLayout)).Optional(),
 	"goalID": z.Int().Optional(),
+	"tagIDs": z.Ptr(z.Slice(z.Int())),
 })
 
 func NewExpenseHandler(baseHandler *BaseHandler, expenseService service.ExpenseService) *ExpenseHandler {
@@ -41,7 +48,9 @@ func NewExpenseHandler(baseHandler *BaseHandler, expenseService service.ExpenseS
 }
 
 func (h *ExpenseHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/expenses", h.Index)
 	r.Post("/expenses", h.Create)
+	r.Post("/expenses/import", h.Import)
 	r.Patch("/expenses/{id}", h.Update)
 	r.Delete("/expenses/{id}", h.Delete)
 	r.Patch("/expenses/{id}/update-goal", h.UpdateGoal)
@@ -49,6 +58,114 @@ func (h *ExpenseHandler) RegisterRoutes(r chi.Router) {
 	r.Get("/expenses/matching-names", h.FindSuggestions)
 }
 
+// Index lists the expenses across all months and goals, a page at a time. Every filter
+// is optional: from and to (inclusive dates), goal_ids and tag_ids (comma separated), min_value,
+// max_value, q (part of the name), sort, limit and the cursor returned by the previous page.
+func (h *ExpenseHandler) Index(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	filter := domain.ExpenseFilter{
+		Query: strings.TrimSpace(query.Get("q")),
+		Sort:  domain.ExpenseSort(query.Get("sort")),
+	}
+
+	if from := query.Get("from"); from != "" {
+		date, err := time.Parse(util.ApiDateLayout, from)
+		if err != nil {
+			h.sendError(w, http.StatusBadRequest, "invalid from date")
+			return
+		}
+
+		filter.From = date
+	}
+
+	if to := query.Get("to"); to != "" {
+		date, err := time.Parse(util.ApiDateLayout, to)
+		if err != nil {
+			h.sendError(w, http.StatusBadRequest, "invalid to date")
+			return
+		}
+
+		filter.To = date.AddDate(0, 0, 1)
+	}
+
+	ids := []struct {
+		param  string
+		target *[]uint
+	}{
+		{"goal_ids", &filter.GoalIDs},
+		{"tag_ids", &filter.TagIDs},
+	}
+
+	for _, v := range ids {
+		param := query.Get(v.param)
+		if param == "" {
+			continue
+		}
+
+		for _, value := range strings.Split(param, ",") {
+			id, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || id < 1 {
+				h.sendError(w, http.StatusBadRequest, "invalid "+strings.ReplaceAll(v.param, "_", " "))
+				return
+			}
+
+			*v.target = append(*v.target, uint(id))
+		}
+	}
+
+	values := []struct {
+		param  string
+		target *int64
+	}{
+		{"min_value", &filter.MinValue},
+		{"max_value", &filter.MaxValue},
+	}
+
+	for _, v := range values {
+		param := query.Get(v.param)
+		if param == "" {
+			continue
+		}
+
+		value, err := strconv.ParseFloat(param, 64)
+		if err != nil || value < 0 {
+			h.sendError(w, http.StatusBadRequest, "invalid "+strings.ReplaceAll(v.param, "_", " "))
+			return
+		}
+
+		*v.target = decimal.NewFromFloat(value).Mul(decimal.NewFromInt(100)).IntPart()
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil {
+			h.sendError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+
+		filter.Limit = value
+	}
+
+	page, err := h.expenseService.Search(r.Context(), filter, query.Get("cursor"), h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	expenseDTOs := make([]domain.ExpenseDTO, len(page.Expenses))
+	for i, e := range page.Expenses {
+		expenseDTOs[i] = e.ToDTO()
+	}
+
+	var nextCursor *string
+	if page.NextCursor != "" {
+		nextCursor = &page.NextCursor
+	}
+
+	h.sendJSON(w, http.StatusOK, util.M{"data": expenseDTOs, "next_cursor": nextCursor})
+}
+
 func (h *ExpenseHandler) FindSuggestions(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query().Get("query")
 	if len(query) < 2 {
@@ -96,6 +213,7 @@ func (h *ExpenseHandler) Create(w http.ResponseWriter, r *http.Request) {
 		Date         time.Time
 		GoalID       int `zog:"goal_id"`
 		Installments int
+		TagIDs       []int `zog:"tag_ids"`
 	}
 
 	if errs := util.ParseZodSchema(expenseCreateSchema, r.Body, &params); errs != nil {
@@ -111,6 +229,8 @@ func (h *ExpenseHandler) Create(w http.ResponseWriter, r *http.Request) {
 		Date:         params.Date,
 		GoalID:       params.GoalID,
 		Installments: params.Installments,
+		TagIDs:       params.TagIDs,
+		CreatedByID:  h.getMemberIDFromCtx(r),
 	}
 
 	expenses, err := h.expenseService.Create(r.Context(), dto, userID)
@@ -139,6 +259,7 @@ func (h *ExpenseHandler) Update(w http.ResponseWriter, r *http.Request) {
 		Value  float64   `json:"value"`
 		Date   time.Time `json:"date"`
 		GoalID int       `zog:"goal_id"`
+		TagIDs *[]int    `zog:"tag_ids"`
 	}
 
 	if errs := util.ParseZodSchema(expenseUpdateSchema, r.Body, &params); errs != nil {
@@ -151,6 +272,7 @@ func (h *ExpenseHandler) Update(w http.ResponseWriter, r *http.Request) {
 		Value:  params.Value,
 		Date:   params.Date,
 		GoalID: params.GoalID,
+		TagIDs: params.TagIDs,
 	}
 
 	expense, err := h.expenseService.UpdateByID(r.Context(), uint(id), dto, h.getUserIDFromCtx(r))
@@ -212,3 +334,175 @@ func (h *ExpenseHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// maxImportFileSize is the maximum size of a statement file accepted by the import endpoint
+const maxImportFileSize = 5 << 20
+
+// Import parses a CSV or OFX bank statement sent as a multipart form. By default it only
+// previews the expenses, flagging possible duplicates. When "commit" is true the rows listed
+// in "rows" (or all the non duplicated ones when omitted) are created.
+func (h *ExpenseHandler) Import(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportFileSize)
+	if err := r.ParseMultipartForm(maxImportFileSize); err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid multipart form")
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "file is required")
+		return
+	}
+	defer file.Close()
+
+	goalID, err := strconv.Atoi(r.FormValue("goal_id"))
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid goal id")
+		return
+	}
+
+	format := importer.Format(strings.ToLower(r.FormValue("format")))
+	if format == "" {
+		format = importer.Format(strings.TrimPrefix(strings.ToLower(filepath.Ext(header.Filename)), "."))
+	}
+
+	var dtos []service.CreateExpenseDTO
+
+	switch format {
+	case importer.CSV:
+		opts, ok := csvOptionsFromForm(r)
+		if !ok {
+			h.sendError(w, http.StatusBadRequest, "delimiter must be a single character")
+			return
+		}
+
+		opts.GoalID = goalID
+		dtos, err = importer.ParseCSV(file, opts)
+	case importer.OFX:
+		dtos, err = importer.ParseOFX(file, goalID)
+	default:
+		h.sendError(w, http.StatusBadRequest, "format must be one of: csv, ofx")
+		return
+	}
+
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	userID := h.getUserIDFromCtx(r)
+
+	preview, err := h.expenseService.PreviewImport(r.Context(), dtos, userID)
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	if commit, _ := strconv.ParseBool(r.FormValue("commit")); !commit {
+		rows := make([]util.M, len(preview))
+		for i, p := range preview {
+			rows[i] = util.M{
+				"row":       i,
+				"name":      p.Name,
+				"value":     p.Value,
+				"date":      p.Date.Format(util.ApiDateLayout),
+				"goal_id":   p.GoalID,
+				"duplicate": p.Duplicate,
+			}
+		}
+
+		h.sendJSON(w, http.StatusOK, util.M{"data": rows})
+		return
+	}
+
+	selected, ok := selectImportRows(preview, r.FormValue("rows"))
+	if !ok {
+		h.sendError(w, http.StatusBadRequest, "invalid rows")
+		return
+	}
+
+	expenses, err := h.expenseService.Import(r.Context(), selected, userID)
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	expenseDTOs := make([]domain.ExpenseDTO, len(expenses))
+	for i, e := range expenses {
+		expenseDTOs[i] = e.ToDTO()
+	}
+
+	h.sendJSON(w, http.StatusCreated, util.M{"data": expenseDTOs})
+}
+
+func csvOptionsFromForm(r *http.Request) (importer.CSVOptions, bool) {
+	opts := importer.DefaultCSVOptions()
+
+	if v := r.FormValue("date_column"); v != "" {
+		opts.DateColumn = v
+	}
+
+	if v := r.FormValue("name_column"); v != "" {
+		opts.NameColumn = v
+	}
+
+	if v := r.FormValue("value_column"); v != "" {
+		opts.ValueColumn = v
+	}
+
+	if v := r.FormValue("date_format"); v != "" {
+		opts.DateFormat = v
+	}
+
+	if v := r.FormValue("decimal_separator"); v != "" {
+		opts.DecimalSeparator = v
+	}
+
+	if v, err := strconv.ParseBool(r.FormValue("has_header")); err == nil {
+		opts.HasHeader = v
+	}
+
+	if v, err := strconv.ParseBool(r.FormValue("debits_positive")); err == nil {
+		opts.DebitsPositive = v
+	}
+
+	if v := r.FormValue("delimiter"); v != "" {
+		if utf8.RuneCountInString(v) != 1 {
+			return opts, false
+		}
+
+		opts.Delimiter, _ = utf8.DecodeRuneInString(v)
+	}
+
+	return opts, true
+}
+
+// selectImportRows returns the rows listed in a comma separated list of indexes,
+// or all the non duplicated ones when the list is empty. Listing a row more than
+// once is invalid, so a line of the statement is never imported twice.
+func selectImportRows(preview []service.ImportPreviewRow, rows string) ([]service.CreateExpenseDTO, bool) {
+	var selected []service.CreateExpenseDTO
+
+	if strings.TrimSpace(rows) == "" {
+		for _, p := range preview {
+			if !p.Duplicate {
+				selected = append(selected, p.CreateExpenseDTO)
+			}
+		}
+
+		return selected, true
+	}
+
+	seen := make(map[int]bool)
+	for _, row := range strings.Split(rows, ",") {
+		i, err := strconv.Atoi(strings.TrimSpace(row))
+		if err != nil || i < 0 || i >= len(preview) || seen[i] {
+			return nil, false
+		}
+
+		seen[i] = true
+		selected = append(selected, preview[i].CreateExpenseDTO)
+	}
+
+	return selected, true
+}
@@ -154,6 +154,150 @@ func TestExpenseHandler_Create(t *testing.T) {
 	}
 }
 
+func TestExpenseHandler_Index(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	user := f.InsertUser()
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: user.ID})
+
+	comfort := f.InsertGoal(&domain.Goal{Name: "Comfort", UserID: user.ID})
+	pleasures := f.InsertGoal(&domain.Goal{Name: "Pleasures", UserID: user.ID})
+
+	date := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	expenses := []*domain.Expense{
+		{Name: "Rent", Value: 1000 * 100, Date: date, GoalID: comfort.ID, UserID: user.ID},
+		{Name: "Cinema", Value: 20 * 100, Date: date.AddDate(0, 1, 0), GoalID: pleasures.ID, UserID: user.ID},
+		{Name: "Market", Value: 200 * 100, Date: date.AddDate(0, 2, 0), GoalID: comfort.ID, UserID: user.ID},
+		// Random user
+		{Name: "Other", Value: 100, Date: date, GoalID: comfort.ID, UserID: f.InsertUser().ID},
+	}
+	f.InsertExpense(expenses...)
+
+	ids := func(respBody util.M) []any {
+		return util.Map(respBody["data"].([]any), func(e any) any { return e.(util.M)["id"] })
+	}
+
+	var respBody util.M
+
+	resp := app.Test(http.MethodGet, "/api/expenses?limit=2")
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(200, resp.StatusCode)
+	assert.Equal([]any{float64(expenses[2].ID), float64(expenses[1].ID)}, ids(respBody))
+	assert.Equal(testhelper.FormatExpense(*expenses[2], comfort), respBody["data"].([]any)[0])
+
+	cursor := respBody["next_cursor"].(string)
+	resp = app.Test(http.MethodGet, "/api/expenses?limit=2&cursor="+cursor)
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(200, resp.StatusCode)
+	assert.Equal([]any{float64(expenses[0].ID)}, ids(respBody))
+	assert.Nil(respBody["next_cursor"])
+
+	filters := []struct {
+		query string
+		want  []any
+	}{
+		{"from=2025-02-15&to=2025-03-15", []any{float64(expenses[2].ID), float64(expenses[1].ID)}},
+		{"to=2025-01-15", []any{float64(expenses[0].ID)}},
+		{fmt.Sprintf("goal_ids=%d", comfort.ID), []any{float64(expenses[2].ID), float64(expenses[0].ID)}},
+		{"min_value=20&max_value=200", []any{float64(expenses[2].ID), float64(expenses[1].ID)}},
+		{"q=cine", []any{float64(expenses[1].ID)}},
+		{"sort=value_desc", []any{float64(expenses[0].ID), float64(expenses[2].ID), float64(expenses[1].ID)}},
+	}
+
+	for _, tt := range filters {
+		resp := app.Test(http.MethodGet, "/api/expenses?"+tt.query)
+		app.UnmarshalBody(resp.Body, &respBody)
+		assert.Equal(200, resp.StatusCode, tt.query)
+		assert.Equal(tt.want, ids(respBody), tt.query)
+	}
+
+	invalid := []struct {
+		query string
+		error string
+	}{
+		{"from=2025-13-01", "invalid from date"},
+		{"to=invalid", "invalid to date"},
+		{"goal_ids=1,a", "invalid goal ids"},
+		{"min_value=-1", "invalid min value"},
+		{"min_value=20&max_value=10", "min value must not be greater than max value"},
+		{"limit=101", "limit must be between 1 and 100"},
+		{"sort=name", "sort must be one of: date_desc, date_asc, value_desc, value_asc"},
+		{"cursor=invalid", "invalid cursor"},
+		{"sort=value_asc&cursor=" + cursor, "invalid cursor"},
+	}
+
+	for _, tt := range invalid {
+		resp := app.Test(http.MethodGet, "/api/expenses?"+tt.query)
+		app.UnmarshalBody(resp.Body, &respBody)
+		assert.Equal(400, resp.StatusCode, tt.query)
+		assert.Equal(util.M{"error": tt.error}, respBody, tt.query)
+	}
+}
+
+func TestExpenseHandler_Tags(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	user := f.InsertUser()
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: user.ID})
+
+	goal := f.InsertGoal(&domain.Goal{Name: "Comfort", UserID: user.ID})
+	car := f.InsertTag(&domain.Tag{Name: "Car", UserID: user.ID})
+	trip := f.InsertTag(&domain.Tag{Name: "Trip", UserID: user.ID})
+	otherTag := f.InsertTag(&domain.Tag{Name: "Other", UserID: f.InsertUser().ID})
+
+	carDTO := util.M{"id": float64(car.ID), "name": "Car"}
+	tripDTO := util.M{"id": float64(trip.ID), "name": "Trip"}
+
+	var respBody util.M
+
+	body := util.M{"name": "Fuel", "value": 50, "date": "2025-01-15", "goal_id": goal.ID, "tag_ids": []uint{otherTag.ID}}
+	resp := app.Test(http.MethodPost, "/api/expenses", body)
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(404, resp.StatusCode)
+	assert.Equal(util.M{"error": "tag not found"}, respBody)
+
+	body["tag_ids"] = []uint{car.ID}
+	body["installments"] = 2
+	resp = app.Test(http.MethodPost, "/api/expenses", body)
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(201, resp.StatusCode)
+
+	data := respBody["data"].([]any)
+	assert.Len(data, 2)
+	for _, e := range data {
+		assert.Equal([]any{carDTO}, e.(util.M)["tags"])
+	}
+
+	path := fmt.Sprintf("/api/expenses/%v", data[0].(util.M)["id"])
+
+	// tags are kept when not sent
+	resp = app.Test(http.MethodPatch, path, util.M{"name": "Gas"})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(200, resp.StatusCode)
+	assert.Equal([]any{carDTO}, respBody["tags"])
+
+	resp = app.Test(http.MethodPatch, path, util.M{"tag_ids": []uint{trip.ID, car.ID}})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(200, resp.StatusCode)
+	assert.ElementsMatch([]any{carDTO, tripDTO}, respBody["tags"])
+
+	resp = app.Test(http.MethodPatch, path, util.M{"tag_ids": []uint{}})
+	respBody = util.M{}
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(200, resp.StatusCode)
+	assert.NotContains(respBody, "tags")
+
+	resp = app.Test(http.MethodGet, fmt.Sprintf("/api/expenses?tag_ids=%d", car.ID))
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(200, resp.StatusCode)
+	assert.Equal([]any{data[1].(util.M)["id"]}, util.Map(respBody["data"].([]any), func(e any) any { return e.(util.M)["id"] }))
+}
+
 func TestExpenseHandler_FindMatchingNames(t *testing.T) {
 	t.Parallel()
 	assert := assert.New(t)
@@ -339,7 +483,7 @@ func TestExpenseHandler_Update(t *testing.T) {
 	assert.Equal(400, resp.StatusCode)
 	assert.Equal(util.M{"error": "invalid expense id"}, respBody)
 
-	anotherUserApp := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: uuid.New()})
+	anotherUserApp := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: f.InsertUser().ID})
 	resp = anotherUserApp.Test(http.MethodPatch, fmt.Sprintf("/api/expenses/%d", expense.ID), util.M{})
 	app.UnmarshalBody(resp.Body, &respBody)
 	assert.Equal(404, resp.StatusCode)
@@ -405,7 +549,7 @@ func TestExpenseHandler_UpdateGoal(t *testing.T) {
 	assert.Equal(400, resp.StatusCode)
 	assert.Equal(util.M{"error": "invalid expense id"}, respBody)
 
-	anotherUserApp := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: uuid.New()})
+	anotherUserApp := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: f.InsertUser().ID})
 	resp = anotherUserApp.Test(http.MethodPatch, fmt.Sprintf("/api/expenses/%d/update-goal", expense.ID), util.M{"goal_id": goal1.ID})
 	app.UnmarshalBody(resp.Body, &respBody)
 	assert.Equal(404, resp.StatusCode)
@@ -426,7 +570,7 @@ func TestExpenseHandler_Delete(t *testing.T) {
 
 	var respBody util.M
 
-	anotherUserApp := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: uuid.New()})
+	anotherUserApp := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: f.InsertUser().ID})
 
 	data := []struct {
 		name      string
@@ -479,3 +623,145 @@ func TestExpenseHandler_Delete(t *testing.T) {
 	_, err := repo.Get(context.Background(), expense.ID, user.ID)
 	assert.Equal("expense not found", err.Error())
 }
+
+func TestExpenseHandler_Import(t *testing.T) {
+	t.Parallel()
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	user := f.InsertUser()
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: user.ID})
+
+	goal := f.InsertGoal(&domain.Goal{Name: domain.Comfort, UserID: user.ID})
+	f.InsertExpense(&domain.Expense{
+		Name:   "MARKET",
+		Value:  12050,
+		Date:   time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC),
+		GoalID: goal.ID,
+		UserID: user.ID,
+	})
+
+	csv := []byte("Data;Descrição;Valor\n05/01/2025;Market;-120,50\n06/01/2025;Gas station;-80,00\n06/01/2025;Salary;5.000,00\n06/01/2025;Gas station;-80,00\n")
+	csvFields := map[string]string{
+		"goal_id":           fmt.Sprint(goal.ID),
+		"date_column":       "data",
+		"name_column":       "descrição",
+		"value_column":      "valor",
+		"delimiter":         ";",
+		"date_format":       "DD/MM/YYYY",
+		"decimal_separator": ",",
+	}
+
+	preview := []any{
+		util.M{"row": 0.0, "name": "Market", "value": 120.5, "date": "2025-01-05", "goal_id": float64(goal.ID), "duplicate": true},
+		util.M{"row": 1.0, "name": "Gas station", "value": 80.0, "date": "2025-01-06", "goal_id": float64(goal.ID), "duplicate": false},
+		util.M{"row": 2.0, "name": "Gas station", "value": 80.0, "date": "2025-01-06", "goal_id": float64(goal.ID), "duplicate": true},
+	}
+
+	tests := []struct {
+		name     string
+		fields   map[string]string
+		fileName string
+		file     []byte
+		status   int
+		want     util.M
+	}{
+		{
+			"missing file",
+			map[string]string{"goal_id": fmt.Sprint(goal.ID)},
+			"",
+			nil,
+			400,
+			util.M{"error": "file is required"},
+		},
+		{
+			"unsupported format",
+			map[string]string{"goal_id": fmt.Sprint(goal.ID)},
+			"statement.pdf",
+			[]byte("%PDF"),
+			400,
+			util.M{"error": "format must be one of: csv, ofx"},
+		},
+		{
+			"invalid file",
+			map[string]string{"goal_id": fmt.Sprint(goal.ID)},
+			"statement.csv",
+			csv,
+			400,
+			util.M{"error": `column "date" not found`},
+		},
+		{
+			"goal not found",
+			map[string]string{"goal_id": fmt.Sprint(goal.ID + 1), "commit": "true"},
+			"statement.ofx",
+			[]byte("<OFX><STMTTRN><DTPOSTED>20250105<TRNAMT>-10.00<NAME>Market</STMTTRN></OFX>"),
+			404,
+			util.M{"error": "goal not found"},
+		},
+		{
+			"preview flags duplicates",
+			csvFields,
+			"statement.csv",
+			csv,
+			200,
+			util.M{"data": preview},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			var respBody util.M
+
+			resp := app.TestMultipart("/api/expenses/import", tt.fields, tt.fileName, tt.file)
+			app.UnmarshalBody(resp.Body, &respBody)
+
+			a.Equal(tt.status, resp.StatusCode)
+			a.Equal(tt.want, respBody)
+		})
+	}
+
+	t.Run("commit skips duplicates by default", func(t *testing.T) {
+		a := assert.New(t)
+		var respBody struct{ Data []domain.ExpenseDTO }
+
+		fields := map[string]string{"commit": "true"}
+		for k, v := range csvFields {
+			fields[k] = v
+		}
+
+		resp := app.TestMultipart("/api/expenses/import", fields, "statement.csv", csv)
+		app.UnmarshalBody(resp.Body, &respBody)
+
+		a.Equal(201, resp.StatusCode)
+		a.Len(respBody.Data, 1)
+		a.Equal("Gas station", respBody.Data[0].Name)
+		a.Equal(80.0, respBody.Data[0].Value)
+
+		var count int64
+		tx.Model(&domain.Expense{}).Where("user_id = ?", user.ID).Count(&count)
+		a.Equal(int64(2), count)
+	})
+
+	t.Run("commit chosen rows", func(t *testing.T) {
+		a := assert.New(t)
+		var respBody struct{ Data []domain.ExpenseDTO }
+
+		fields := map[string]string{"commit": "true", "rows": "0"}
+		for k, v := range csvFields {
+			fields[k] = v
+		}
+
+		resp := app.TestMultipart("/api/expenses/import", fields, "statement.csv", csv)
+		app.UnmarshalBody(resp.Body, &respBody)
+
+		a.Equal(201, resp.StatusCode)
+		a.Len(respBody.Data, 1)
+		a.Equal("Market", respBody.Data[0].Name)
+
+		for _, rows := range []string{"5", "1,1", "1, 1"} {
+			fields["rows"] = rows
+			resp = app.TestMultipart("/api/expenses/import", fields, "statement.csv", csv)
+			a.Equal(400, resp.StatusCode, rows)
+		}
+	})
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joaopsramos/fincon/internal/service"
+)
+
+type ExportHandler struct {
+	*BaseHandler
+	exportService service.ExportService
+}
+
+func NewExportHandler(baseHandler *BaseHandler, exportService service.ExportService) *ExportHandler {
+	return &ExportHandler{
+		BaseHandler:   baseHandler,
+		exportService: exportService,
+	}
+}
+
+func (h *ExportHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/export", h.Export)
+}
+
+func (h *ExportHandler) Export(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+
+	if format != "json" && format != "csv" {
+		h.sendError(w, http.StatusBadRequest, "format must be one of: json, csv")
+		return
+	}
+
+	filename := fmt.Sprintf("fincon-export-%s", time.Now().UTC().Format("2006-01-02"))
+
+	aw := &attachmentWriter{ResponseWriter: w, contentType: "application/json", filename: filename + ".json"}
+	write := h.exportService.WriteJSON
+
+	if format == "csv" {
+		aw.contentType = "application/zip"
+		aw.filename = filename + ".zip"
+		write = h.exportService.WriteCSVZip
+	}
+
+	err := write(r.Context(), h.getUserIDFromCtx(r), aw)
+	if err == nil {
+		return
+	}
+
+	// Once the export started being written the status was already sent, so all that
+	// can be done is logging
+	if !aw.started {
+		h.HandleError(w, err)
+	} else if h.logger != nil {
+		h.logger.Error("Failed to write export", "error", err)
+	}
+}
+
+// attachmentWriter sends the attachment headers on the first write, so errors found
+// before the export starts being written still get their own status
+type attachmentWriter struct {
+	http.ResponseWriter
+	contentType string
+	filename    string
+	started     bool
+}
+
+func (w *attachmentWriter) Write(b []byte) (int, error) {
+	if !w.started {
+		w.started = true
+		w.Header().Set("Content-Type", w.contentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", w.filename))
+		w.WriteHeader(http.StatusOK)
+	}
+
+	return w.ResponseWriter.Write(b)
+}
@@ -0,0 +1,139 @@
+package api_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/testhelper"
+	"github.com/joaopsramos/fincon/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportHandler_Export(t *testing.T) {
+	t.Parallel()
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	user := f.InsertUser(&domain.User{HashPassword: "secret"})
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: user.ID})
+
+	now := time.Now().UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	f.InsertSalary(&domain.Salary{Amount: 500_000, EffectiveFrom: monthStart, UserID: user.ID})
+	goal := f.InsertGoal(&domain.Goal{Name: domain.Comfort, Percentage: 100, UserID: user.ID})
+	date := time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)
+	expense := f.InsertExpense(&domain.Expense{Name: "Market, downtown", Value: 12050, Date: date, GoalID: goal.ID, UserID: user.ID})
+	formula := f.InsertExpense(&domain.Expense{Name: "=HYPERLINK(\"x\")", Value: 100, Date: date.AddDate(0, 0, 1), GoalID: goal.ID, UserID: user.ID})
+	tag := f.InsertTag(&domain.Tag{Name: "@home", UserID: user.ID})
+	target := f.InsertSavingsTarget(&domain.SavingsTarget{Name: "Trip", TargetAmount: 300000, StartDate: date, Deadline: date.AddDate(1, 0, 0), GoalID: goal.ID, UserID: user.ID})
+	rent := f.InsertRecurringExpense(&domain.RecurringExpense{Name: "Rent", Value: 100000, DayOfMonth: 5, StartDate: date, GoalID: goal.ID, UserID: user.ID})
+
+	// data of other users must not leak
+	otherUser := f.InsertUser()
+	otherGoal := f.InsertGoal(&domain.Goal{Name: domain.Comfort, UserID: otherUser.ID})
+	f.InsertExpense(&domain.Expense{GoalID: otherGoal.ID, UserID: otherUser.ID})
+
+	t.Run("invalid format", func(t *testing.T) {
+		a := assert.New(t)
+		var respBody util.M
+
+		resp := app.Test(http.MethodGet, "/api/export?format=xml")
+		app.UnmarshalBody(resp.Body, &respBody)
+
+		a.Equal(400, resp.StatusCode)
+		a.Equal(util.M{"error": "format must be one of: json, csv"}, respBody)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		a := assert.New(t)
+		var respBody util.M
+
+		resp := app.Test(http.MethodGet, "/api/export")
+		app.UnmarshalBody(resp.Body, &respBody)
+
+		a.Equal(200, resp.StatusCode)
+		a.Contains(resp.Header.Get("Content-Disposition"), ".json")
+		a.NotEmpty(respBody["exported_at"])
+		a.Equal(util.M{"id": user.ID.String(), "email": user.Email, "verified": false}, respBody["user"])
+		a.Equal(util.M{"amount": 5000.0}, respBody["salary"])
+		a.Equal([]any{util.M{"amount": 5000.0, "effective_from": testhelper.DateToJsonString(monthStart)}}, respBody["salary_history"])
+		a.Equal([]any{util.M{
+			"id":          float64(goal.ID),
+			"name":        "Comfort",
+			"percentage":  100.0,
+			"color":       "",
+			"position":    0.0,
+			"archived_at": nil,
+
+			"alert_thresholds": []any{80.0, 100.0},
+			"email_alerts":     false,
+			"rollover_policy":  "deficit",
+		}}, respBody["goals"])
+		a.Equal([]any{testhelper.FormatExpense(expense, goal), testhelper.FormatExpense(formula, goal)}, respBody["expenses"])
+		a.Equal([]any{util.M{"id": float64(tag.ID), "name": "@home"}}, respBody["tags"])
+		a.Equal([]any{util.M{
+			"id":            float64(target.ID),
+			"name":          "Trip",
+			"target_amount": 3000.0,
+			"start_date":    "2025-01-05T00:00:00Z",
+			"deadline":      "2026-01-05T00:00:00Z",
+			"goal_id":       float64(goal.ID),
+		}}, respBody["savings_targets"])
+		a.Equal([]any{util.M{
+			"id":           float64(rent.ID),
+			"name":         "Rent",
+			"value":        1000.0,
+			"day_of_month": 5.0,
+			"cadence":      "monthly",
+			"start_date":   "2025-01-05T00:00:00Z",
+			"end_date":     nil,
+			"goal_id":      float64(goal.ID),
+		}}, respBody["recurring_expenses"])
+		a.NotContains(respBody["user"], "hash_password")
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		a := assert.New(t)
+
+		resp := app.Test(http.MethodGet, "/api/export?format=csv")
+		a.Equal(200, resp.StatusCode)
+		a.Equal("application/zip", resp.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(resp.Body)
+		a.NoError(err)
+
+		zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+		a.NoError(err)
+
+		files := make(map[string][][]string)
+		for _, zf := range zr.File {
+			rc, err := zf.Open()
+			a.NoError(err)
+
+			records, err := csv.NewReader(rc).ReadAll()
+			a.NoError(err)
+			files[zf.Name] = records
+			rc.Close()
+		}
+
+		a.Equal([][]string{{"id", "email"}, {user.ID.String(), user.Email}}, files["user.csv"])
+		a.Equal([][]string{{"amount", "effective_from"}, {"5000.00", monthStart.Format(util.ApiDateLayout)}}, files["salary.csv"])
+		a.Len(files["goals.csv"], 2)
+		a.Equal([][]string{
+			{"id", "name", "value", "date", "goal_id", "tags"},
+			{fmt.Sprint(expense.ID), "Market, downtown", "120.50", "2025-01-05", fmt.Sprint(goal.ID), ""},
+			{fmt.Sprint(formula.ID), `'=HYPERLINK("x")`, "1.00", "2025-01-06", fmt.Sprint(goal.ID), ""},
+		}, files["expenses.csv"])
+		a.Equal([][]string{{"id", "name"}, {fmt.Sprint(tag.ID), "'@home"}}, files["tags.csv"])
+		a.Len(files["savings_targets.csv"], 2)
+		a.Len(files["incomes.csv"], 1)
+		a.Len(files["income_amounts.csv"], 1)
+		a.Len(files["recurring_expenses.csv"], 2)
+	})
+}
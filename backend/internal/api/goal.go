@@ -3,15 +3,41 @@ package api
 import (
 	"encoding/json"
 	"net/http"
+	"regexp"
 	"strconv"
 	"time"
 
+	z "github.com/Oudwins/zog"
 	"github.com/go-chi/chi/v5"
 	"github.com/joaopsramos/fincon/internal/domain"
 	"github.com/joaopsramos/fincon/internal/service"
 	"github.com/joaopsramos/fincon/internal/util"
 )
 
+var (
+	colorMessage = z.Message("color must be an hex color like #22c55e")
+	colorRegex   = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+	thresholdMessage = z.Message("alert thresholds must be between 1 and 1000")
+
+	goalCreateSchema = z.Struct(z.Schema{
+		"name":  z.String().Trim().Min(2, z.Message("name must contain at least 2 characters")).Max(50, z.Message("name must contain at most 50 characters")).Required(),
+		"color": z.String().Trim().Match(colorRegex, colorMessage).Optional(),
+	})
+
+	goalUpdateSchema = z.Struct(z.Schema{
+		"name":     z.String().Trim().Min(2, z.Message("name must contain at least 2 characters")).Max(50, z.Message("name must contain at most 50 characters")).Optional(),
+		"color":    z.String().Trim().Match(colorRegex, colorMessage).Optional(),
+		"position": z.Ptr(z.Int().GTE(0, z.Message("position must be greater than or equal to 0"))),
+		"archived": z.Ptr(z.Bool()),
+		"alertThresholds": z.Ptr(z.Slice(
+			z.Int().GTE(1, thresholdMessage).LTE(1000, thresholdMessage),
+		)),
+		"emailAlerts":    z.Ptr(z.Bool()),
+		"rolloverPolicy": z.String().Trim().Optional(),
+	})
+)
+
 type GoalHandler struct {
 	*BaseHandler
 	goalService    service.GoalService
@@ -29,12 +55,24 @@ func NewGoalHandler(handler *BaseHandler, goalService service.GoalService, expen
 func (h *GoalHandler) RegisterRoutes(r chi.Router) {
 	r.Get("/goals", h.AllGoals)
 	r.Get("/goals/{id}/expenses", h.GetGoalExpenses)
-	r.Post("/goals", h.UpdateGoals)
+	r.Put("/goals", h.UpdateGoals)
+	r.Post("/goals", h.Create)
+	r.Get("/goals/allocation", h.GetAllocation)
+	r.Put("/goals/allocation", h.UpdateAllocation)
+	r.Patch("/goals/{id}", h.Update)
+	r.Delete("/goals/{id}", h.Delete)
 }
 
 func (h *GoalHandler) AllGoals(w http.ResponseWriter, r *http.Request) {
 	userID := h.getUserIDFromCtx(r)
-	goals := h.goalService.All(r.Context(), userID)
+
+	var goals []domain.Goal
+	if includeArchived, _ := strconv.ParseBool(r.URL.Query().Get("include_archived")); includeArchived {
+		goals = h.goalService.AllWithArchived(r.Context(), userID)
+	} else {
+		goals = h.goalService.All(r.Context(), userID)
+	}
+
 	goalDTOs := util.Map(goals, func(g domain.Goal) domain.GoalDTO { return g.ToDTO() })
 	h.sendJSON(w, http.StatusOK, goalDTOs)
 }
@@ -87,6 +125,76 @@ func (h *GoalHandler) GetGoalExpenses(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *GoalHandler) UpdateGoals(w http.ResponseWriter, r *http.Request) {
+	dtos, ok := h.decodeGoalPercentages(w, r)
+	if !ok {
+		return
+	}
+
+	goals, err := h.goalService.UpdateAll(r.Context(), dtos, h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	goalDTOs := util.Map(goals, func(g domain.Goal) domain.GoalDTO { return g.ToDTO() })
+	h.sendJSON(w, http.StatusOK, goalDTOs)
+}
+
+// GetAllocation returns the active goals with the percentages of the month of the "date" query param
+func (h *GoalHandler) GetAllocation(w http.ResponseWriter, r *http.Request) {
+	date, ok := h.parseAllocationDate(w, r)
+	if !ok {
+		return
+	}
+
+	goals, err := h.goalService.Allocation(r.Context(), date, h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	goalDTOs := util.Map(goals, func(g domain.Goal) domain.GoalDTO { return g.ToDTO() })
+	h.sendJSON(w, http.StatusOK, goalDTOs)
+}
+
+// UpdateAllocation sets the percentages of the month of the "date" query param
+func (h *GoalHandler) UpdateAllocation(w http.ResponseWriter, r *http.Request) {
+	date, ok := h.parseAllocationDate(w, r)
+	if !ok {
+		return
+	}
+
+	dtos, ok := h.decodeGoalPercentages(w, r)
+	if !ok {
+		return
+	}
+
+	goals, err := h.goalService.SetAllocation(r.Context(), date, dtos, h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	goalDTOs := util.Map(goals, func(g domain.Goal) domain.GoalDTO { return g.ToDTO() })
+	h.sendJSON(w, http.StatusOK, goalDTOs)
+}
+
+func (h *GoalHandler) parseAllocationDate(w http.ResponseWriter, r *http.Request) (time.Time, bool) {
+	queryDate := r.URL.Query().Get("date")
+	if queryDate == "" {
+		return time.Now(), true
+	}
+
+	date, err := time.Parse(util.ApiDateLayout, queryDate)
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid date")
+		return time.Time{}, false
+	}
+
+	return date, true
+}
+
+func (h *GoalHandler) decodeGoalPercentages(w http.ResponseWriter, r *http.Request) ([]service.UpdateGoalDTO, bool) {
 	var params []struct {
 		ID         int `json:"id"`
 		Percentage int `json:"percentage"`
@@ -94,11 +202,9 @@ func (h *GoalHandler) UpdateGoals(w http.ResponseWriter, r *http.Request) {
 
 	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
 		h.InvalidJSONBody(w, err)
-		return
+		return nil, false
 	}
 
-	userID := h.getUserIDFromCtx(r)
-
 	dtos := make([]service.UpdateGoalDTO, len(params))
 	for i, p := range params {
 		dtos[i] = service.UpdateGoalDTO{
@@ -107,12 +213,94 @@ func (h *GoalHandler) UpdateGoals(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	goals, err := h.goalService.UpdateAll(r.Context(), dtos, userID)
+	return dtos, true
+}
+
+func (h *GoalHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Name  string
+		Color string
+	}
+
+	if errs := util.ParseZodSchema(goalCreateSchema, r.Body, &params); errs != nil {
+		h.HandleZodError(w, errs)
+		return
+	}
+
+	dto := service.CreateGoalDTO{Name: params.Name, Color: params.Color}
+
+	goal, err := h.goalService.Create(r.Context(), dto, h.getUserIDFromCtx(r))
 	if err != nil {
 		h.HandleError(w, err)
 		return
 	}
 
-	goalDTOs := util.Map(goals, func(g domain.Goal) domain.GoalDTO { return g.ToDTO() })
-	h.sendJSON(w, http.StatusOK, goalDTOs)
+	h.sendJSON(w, http.StatusCreated, goal.ToDTO())
+}
+
+func (h *GoalHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid goal id")
+		return
+	}
+
+	var params struct {
+		Name            string
+		Color           string
+		Position        *int
+		Archived        *bool
+		AlertThresholds *[]int `zog:"alert_thresholds"`
+		EmailAlerts     *bool  `zog:"email_alerts"`
+		RolloverPolicy  string `zog:"rollover_policy"`
+	}
+
+	if errs := util.ParseZodSchema(goalUpdateSchema, r.Body, &params); errs != nil {
+		h.HandleZodError(w, errs)
+		return
+	}
+
+	dto := service.UpdateGoalDetailsDTO{
+		Name:            params.Name,
+		Color:           params.Color,
+		Position:        params.Position,
+		Archived:        params.Archived,
+		AlertThresholds: params.AlertThresholds,
+		EmailAlerts:     params.EmailAlerts,
+		RolloverPolicy:  params.RolloverPolicy,
+	}
+
+	goal, err := h.goalService.UpdateByID(r.Context(), uint(id), dto, h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, goal.ToDTO())
+}
+
+// Delete removes a goal, the "reassign_to" query param sets the goal that receives its
+// expenses and percentage
+func (h *GoalHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid goal id")
+		return
+	}
+
+	var reassignTo int
+	if queryReassignTo := r.URL.Query().Get("reassign_to"); queryReassignTo != "" {
+		reassignTo, err = strconv.Atoi(queryReassignTo)
+		if err != nil || reassignTo < 1 {
+			h.sendError(w, http.StatusBadRequest, "invalid reassign_to goal id")
+			return
+		}
+	}
+
+	if err := h.goalService.Delete(r.Context(), uint(id), uint(reassignTo), h.getUserIDFromCtx(r)); err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
 }
@@ -43,9 +43,9 @@ func TestGoalHandler_Index(t *testing.T) {
 			app,
 			200,
 			[]util.M{
-				{"id": float64(goals[0].ID), "name": "Comfort", "percentage": float64(40)},
-				{"id": float64(goals[1].ID), "name": "Goals", "percentage": float64(20)},
-				{"id": float64(goals[2].ID), "name": "Fixed costs", "percentage": float64(30)},
+				formatGoal(goals[0], 40),
+				formatGoal(goals[1], 20),
+				formatGoal(goals[2], 30),
 			},
 		},
 		{
@@ -53,7 +53,7 @@ func TestGoalHandler_Index(t *testing.T) {
 			anotherUserApp,
 			200,
 			[]util.M{
-				{"id": float64(goals[3].ID), "name": "Pleasures", "percentage": float64(100)},
+				formatGoal(goals[3], 100),
 			},
 		},
 	}
@@ -208,10 +208,6 @@ func TestGoalHandler_UpdateGoals(t *testing.T) {
 	}
 	f.InsertGoal(goals...)
 
-	formatGoal := func(g *domain.Goal, percentage uint) util.M {
-		return util.M{"id": float64(g.ID), "name": string(g.Name), "percentage": float64(percentage)}
-	}
-
 	data := []struct {
 		name           string
 		app            *testhelper.TestApp
@@ -330,7 +326,7 @@ func TestGoalHandler_UpdateGoals(t *testing.T) {
 		t.Run(d.name, func(t *testing.T) {
 			var respBody any
 
-			resp := d.app.Test(http.MethodPost, "/api/goals", d.body)
+			resp := d.app.Test(http.MethodPut, "/api/goals", d.body)
 			d.app.UnmarshalBody(resp.Body, &respBody)
 
 			assert.Equal(d.expectedStatus, resp.StatusCode)
@@ -368,3 +364,340 @@ func TestGoalHandler_UpdateGoals(t *testing.T) {
 		formatGoal(goals[11], goals[11].Percentage),
 	}, respBody)
 }
+
+func formatGoal(g *domain.Goal, percentage uint) util.M {
+	return util.M{
+		"id":          float64(g.ID),
+		"name":        string(g.Name),
+		"percentage":  float64(percentage),
+		"color":       g.Color,
+		"position":    float64(g.Position),
+		"archived_at": nil,
+
+		"alert_thresholds": []any{80.0, 100.0},
+		"email_alerts":     false,
+		"rollover_policy":  "deficit",
+	}
+}
+
+func TestGoalHandler_Allocation(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	user := f.InsertUser()
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: user.ID})
+
+	comfort := f.InsertGoal(&domain.Goal{Name: "Comfort", Percentage: 60, UserID: user.ID})
+	pleasures := f.InsertGoal(&domain.Goal{Name: "Pleasures", Percentage: 40, Position: 1, UserID: user.ID})
+
+	now := testhelper.MiddleOfMonth()
+	twoMonthsAgo := testhelper.DateToJsonString(now.AddDate(0, -2, 0))
+	lastMonth := testhelper.DateToJsonString(now.AddDate(0, -1, 0))
+
+	percentages := func(path string) []any {
+		var goals []util.M
+		resp := app.Test(http.MethodGet, path)
+		app.UnmarshalBody(resp.Body, &goals)
+		assert.Equal(200, resp.StatusCode)
+
+		return util.Map(goals, func(g util.M) any { return g["percentage"] })
+	}
+
+	var respBody any
+
+	resp := app.Test(http.MethodGet, "/api/goals/allocation?date=invalid")
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(400, resp.StatusCode)
+	assert.Equal(util.M{"error": "invalid date"}, respBody)
+
+	resp = app.Test(http.MethodPut, "/api/goals/allocation?date="+twoMonthsAgo, []util.M{
+		{"id": comfort.ID, "percentage": 50},
+		{"id": pleasures.ID, "percentage": 40},
+	})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(400, resp.StatusCode)
+	assert.Equal(util.M{"error": "the sum of all percentages must be equal to 100"}, respBody)
+
+	resp = app.Test(http.MethodPut, "/api/goals/allocation?date="+twoMonthsAgo, []util.M{
+		{"id": comfort.ID, "percentage": 50},
+		{"id": pleasures.ID, "percentage": 50},
+	})
+	var goals []util.M
+	app.UnmarshalBody(resp.Body, &goals)
+	assert.Equal(200, resp.StatusCode)
+	assert.Equal([]util.M{formatGoal(&comfort, 50), formatGoal(&pleasures, 50)}, goals)
+
+	// changing a past month keeps the following months and the current percentages
+	assert.Equal([]any{50.0, 50.0}, percentages("/api/goals/allocation?date="+twoMonthsAgo))
+	assert.Equal([]any{60.0, 40.0}, percentages("/api/goals/allocation?date="+lastMonth))
+	assert.Equal([]any{60.0, 40.0}, percentages("/api/goals/allocation"))
+	assert.Equal([]any{60.0, 40.0}, percentages("/api/goals"))
+
+	// updating the goals only changes the current month onwards
+	resp = app.Test(http.MethodPut, "/api/goals", []util.M{
+		{"id": comfort.ID, "percentage": 70},
+		{"id": pleasures.ID, "percentage": 30},
+	})
+	assert.Equal(200, resp.StatusCode)
+
+	assert.Equal([]any{50.0, 50.0}, percentages("/api/goals/allocation?date="+twoMonthsAgo))
+	assert.Equal([]any{60.0, 40.0}, percentages("/api/goals/allocation?date="+lastMonth))
+	assert.Equal([]any{70.0, 30.0}, percentages("/api/goals/allocation"))
+	assert.Equal([]any{70.0, 30.0}, percentages("/api/goals"))
+}
+
+func TestGoalHandler_Create(t *testing.T) {
+	t.Parallel()
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	user := f.InsertUser()
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: user.ID})
+
+	f.InsertGoal(&domain.Goal{Name: "Comfort", Percentage: 100, Position: 3, UserID: user.ID})
+
+	tests := []struct {
+		name   string
+		body   util.M
+		status int
+		want   util.M
+	}{
+		{
+			"ensure required fields",
+			util.M{},
+			400,
+			util.M{"errors": util.M{"name": []any{"is required"}}},
+		},
+		{
+			"invalid values",
+			util.M{"name": "P", "color": "red"},
+			400,
+			util.M{"errors": util.M{
+				"name":  []any{"name must contain at least 2 characters"},
+				"color": []any{"color must be an hex color like #22c55e"},
+			}},
+		},
+		{
+			"duplicated name",
+			util.M{"name": "comfort"},
+			400,
+			util.M{"error": "a goal with this name already exists"},
+		},
+		{
+			"goes after the existing goals with 0%",
+			util.M{"name": "Pets", "color": "#22c55e"},
+			201,
+			util.M{"name": "Pets", "percentage": 0.0, "color": "#22c55e", "position": 4.0, "archived_at": nil, "alert_thresholds": []any{80.0, 100.0}, "email_alerts": false, "rollover_policy": "deficit"},
+		},
+		{
+			"defaults color",
+			util.M{"name": "Travel"},
+			201,
+			util.M{"name": "Travel", "percentage": 0.0, "color": domain.GoalColors[2], "position": 5.0, "archived_at": nil, "alert_thresholds": []any{80.0, 100.0}, "email_alerts": false, "rollover_policy": "deficit"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			var respBody util.M
+
+			resp := app.Test(http.MethodPost, "/api/goals", tt.body)
+			app.UnmarshalBody(resp.Body, &respBody)
+
+			a.Equal(tt.status, resp.StatusCode)
+
+			if resp.StatusCode == http.StatusCreated {
+				a.NotZero(respBody["id"])
+				delete(respBody, "id")
+			}
+
+			a.Equal(tt.want, respBody)
+		})
+	}
+}
+
+func TestGoalHandler_UpdateAndArchive(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	user := f.InsertUser()
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: user.ID})
+	anotherUserApp := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: f.InsertUser().ID})
+
+	comfort := f.InsertGoal(&domain.Goal{Name: "Comfort", Percentage: 100, UserID: user.ID})
+	knowledge := f.InsertGoal(&domain.Goal{Name: "Knowledge", Percentage: 0, Position: 1, UserID: user.ID})
+	path := fmt.Sprintf("/api/goals/%d", knowledge.ID)
+
+	var respBody util.M
+
+	resp := anotherUserApp.Test(http.MethodPatch, path, util.M{"name": "Books"})
+	anotherUserApp.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(404, resp.StatusCode)
+	assert.Equal(util.M{"error": "goal not found"}, respBody)
+
+	resp = app.Test(http.MethodPatch, path, util.M{"name": "Comfort"})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(400, resp.StatusCode)
+	assert.Equal(util.M{"error": "a goal with this name already exists"}, respBody)
+
+	resp = app.Test(http.MethodPatch, path, util.M{"name": "Books", "color": "#3b82f6", "position": 0})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(200, resp.StatusCode)
+	assert.Equal(util.M{
+		"id":          float64(knowledge.ID),
+		"name":        "Books",
+		"percentage":  0.0,
+		"color":       "#3b82f6",
+		"position":    0.0,
+		"archived_at": nil,
+
+		"alert_thresholds": []any{80.0, 100.0},
+		"email_alerts":     false,
+		"rollover_policy":  "deficit",
+	}, respBody)
+
+	resp = app.Test(http.MethodPatch, fmt.Sprintf("/api/goals/%d", comfort.ID), util.M{"archived": true})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(400, resp.StatusCode)
+	assert.Equal(util.M{"error": "set the goal percentage to 0 before archiving it"}, respBody)
+
+	resp = app.Test(http.MethodPatch, path, util.M{"archived": true})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(200, resp.StatusCode)
+	assert.NotNil(respBody["archived_at"])
+
+	var goals []util.M
+	resp = app.Test(http.MethodGet, "/api/goals")
+	app.UnmarshalBody(resp.Body, &goals)
+	assert.Equal([]any{float64(comfort.ID)}, util.Map(goals, func(g util.M) any { return g["id"] }))
+
+	resp = app.Test(http.MethodGet, "/api/goals?include_archived=true")
+	app.UnmarshalBody(resp.Body, &goals)
+	assert.Equal([]any{float64(comfort.ID), float64(knowledge.ID)}, util.Map(goals, func(g util.M) any { return g["id"] }))
+
+	// archived goals are not part of the percentages anymore
+	resp = app.Test(http.MethodPut, "/api/goals", []util.M{{"id": comfort.ID, "percentage": 100}})
+	assert.Equal(200, resp.StatusCode)
+
+	resp = app.Test(http.MethodPatch, path, util.M{"archived": false})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(200, resp.StatusCode)
+	assert.Nil(respBody["archived_at"])
+}
+
+func TestGoalHandler_UpdateRolloverPolicy(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	user := f.InsertUser()
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: user.ID})
+
+	now := testhelper.MiddleOfMonth()
+	lastMonth := now.AddDate(0, -1, 0)
+
+	f.InsertSalary(&domain.Salary{Amount: 1000 * 100, EffectiveFrom: lastMonth, UserID: user.ID})
+	goal := f.InsertGoal(&domain.Goal{Name: "Comfort", Percentage: 100, UserID: user.ID})
+	f.InsertExpense(&domain.Expense{Value: 600 * 100, Date: lastMonth, GoalID: goal.ID, UserID: user.ID})
+	path := fmt.Sprintf("/api/goals/%d", goal.ID)
+	summaryPath := "/api/expenses/summary?date=" + now.Format(util.ApiDateLayout)
+
+	var respBody util.M
+
+	resp := app.Test(http.MethodPatch, path, util.M{"rollover_policy": "sometimes"})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(400, resp.StatusCode)
+	assert.Equal(util.M{"error": "rollover policy must be one of: none, surplus, deficit, both"}, respBody)
+
+	resp = app.Test(http.MethodGet, summaryPath)
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(200, resp.StatusCode)
+	assert.Equal(0.0, respBody["goals"].([]any)[0].(util.M)["carried_over"])
+
+	resp = app.Test(http.MethodPatch, path, util.M{"rollover_policy": "surplus"})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(200, resp.StatusCode)
+	assert.Equal("surplus", respBody["rollover_policy"])
+
+	resp = app.Test(http.MethodGet, summaryPath)
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(200, resp.StatusCode)
+	assert.Equal(util.M{
+		"name":         "Comfort",
+		"spent":        0.0,
+		"must_spend":   1400.0,
+		"used":         0.0,
+		"total":        0.0,
+		"carried_over": 400.0,
+	}, respBody["goals"].([]any)[0])
+}
+
+func TestGoalHandler_Delete(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	user := f.InsertUser()
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: user.ID})
+
+	comfort := f.InsertGoal(&domain.Goal{Name: "Comfort", Percentage: 60, UserID: user.ID})
+	knowledge := f.InsertGoal(&domain.Goal{Name: "Knowledge", Percentage: 40, UserID: user.ID})
+	empty := f.InsertGoal(&domain.Goal{Name: "Empty", Percentage: 0, UserID: user.ID})
+	expense := f.InsertExpense(&domain.Expense{GoalID: knowledge.ID, UserID: user.ID})
+	target := f.InsertSavingsTarget(&domain.SavingsTarget{GoalID: knowledge.ID, UserID: user.ID})
+
+	withRecurring := f.InsertGoal(&domain.Goal{Name: "Rent", Percentage: 0, UserID: user.ID})
+	f.InsertRecurringExpense(&domain.RecurringExpense{GoalID: withRecurring.ID, UserID: user.ID})
+
+	withTarget := f.InsertGoal(&domain.Goal{Name: "Trip", Percentage: 0, UserID: user.ID})
+	f.InsertSavingsTarget(&domain.SavingsTarget{GoalID: withTarget.ID, UserID: user.ID})
+
+	tests := []struct {
+		name   string
+		path   string
+		status int
+		want   util.M
+	}{
+		{"invalid reassign goal", fmt.Sprintf("/api/goals/%d?reassign_to=abc", knowledge.ID), 400, util.M{"error": "invalid reassign_to goal id"}},
+		{"reassign goal not found", fmt.Sprintf("/api/goals/%d?reassign_to=%d", knowledge.ID, empty.ID+100), 404, util.M{"error": "goal not found"}},
+		{"reassign to itself", fmt.Sprintf("/api/goals/%d?reassign_to=%d", knowledge.ID, knowledge.ID), 400, util.M{"error": "a goal cannot be reassigned to itself"}},
+		{"goal with percentage", fmt.Sprintf("/api/goals/%d", knowledge.ID), 400, util.M{"error": "goal has a percentage, choose a goal to reassign it to"}},
+		{"goal with recurring expenses", fmt.Sprintf("/api/goals/%d", withRecurring.ID), 400, util.M{"error": "goal has recurring expenses, choose a goal to reassign them to"}},
+		{"goal with savings targets", fmt.Sprintf("/api/goals/%d", withTarget.ID), 400, util.M{"error": "goal has savings targets, choose a goal to reassign them to"}},
+		{"goal without expenses", fmt.Sprintf("/api/goals/%d", empty.ID), 204, nil},
+		{"reassign expenses and percentage", fmt.Sprintf("/api/goals/%d?reassign_to=%d", knowledge.ID, comfort.ID), 204, nil},
+		{"reassign recurring expenses", fmt.Sprintf("/api/goals/%d?reassign_to=%d", withRecurring.ID, comfort.ID), 204, nil},
+		{"reassign savings targets", fmt.Sprintf("/api/goals/%d?reassign_to=%d", withTarget.ID, comfort.ID), 204, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var respBody util.M
+
+			resp := app.Test(http.MethodDelete, tt.path)
+			app.UnmarshalBody(resp.Body, &respBody)
+
+			assert.Equal(tt.status, resp.StatusCode)
+			assert.Equal(tt.want, respBody)
+		})
+	}
+
+	var goals []util.M
+	resp := app.Test(http.MethodGet, "/api/goals")
+	app.UnmarshalBody(resp.Body, &goals)
+	assert.Equal([]util.M{formatGoal(&comfort, 100)}, goals)
+
+	var e domain.Expense
+	assert.NoError(tx.First(&e, expense.ID).Error)
+	assert.Equal(comfort.ID, e.GoalID)
+
+	var st domain.SavingsTarget
+	assert.NoError(tx.First(&st, target.ID).Error)
+	assert.Equal(comfort.ID, st.GoalID)
+
+	var count int64
+	tx.Model(&domain.RecurringExpense{}).Where("goal_id = ?", comfort.ID).Count(&count)
+	assert.Equal(int64(1), count)
+}
@@ -0,0 +1,227 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	z "github.com/Oudwins/zog"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/errs"
+	"github.com/joaopsramos/fincon/internal/service"
+	"github.com/joaopsramos/fincon/internal/util"
+)
+
+// HouseholdHeader selects the household whose budget a request acts on, when absent
+// requests act on the budget of the authenticated user
+const HouseholdHeader = "X-Household-ID"
+
+type HouseholdHandler struct {
+	*BaseHandler
+	householdService service.HouseholdService
+}
+
+var (
+	householdSchema = z.Struct(z.Schema{
+		"name": z.String().Trim().Min(1, z.Message("name must not be empty")).Max(50, z.Message("name must contain at most 50 characters")).Required(),
+	})
+
+	householdInviteSchema = z.Struct(z.Schema{
+		"email": z.String().Trim().Max(160).Email(z.Message("must be valid")).Required(),
+		"role":  z.String().Trim().Required(),
+	})
+
+	householdRoleSchema = z.Struct(z.Schema{
+		"role": z.String().Trim().Required(),
+	})
+
+	householdJoinSchema = z.Struct(z.Schema{
+		"token": z.String().Trim().Required(),
+	})
+)
+
+func NewHouseholdHandler(baseHandler *BaseHandler, householdService service.HouseholdService) *HouseholdHandler {
+	return &HouseholdHandler{
+		BaseHandler:      baseHandler,
+		householdService: householdService,
+	}
+}
+
+func (h *HouseholdHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/households", h.Index)
+	r.Post("/households", h.Create)
+	r.Post("/households/join", h.Join)
+	r.Delete("/households/{id}", h.Delete)
+	r.Get("/households/{id}/members", h.Members)
+	r.Patch("/households/{id}/members/{user_id}", h.UpdateMember)
+	r.Delete("/households/{id}/members/{user_id}", h.RemoveMember)
+	r.With(h.rateLimiter(20, time.Hour)).Post("/households/{id}/invitations", h.Invite)
+}
+
+func (h *HouseholdHandler) Index(w http.ResponseWriter, r *http.Request) {
+	memberships, err := h.householdService.All(r.Context(), h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	dtos := util.Map(memberships, func(m domain.Membership) domain.HouseholdDTO { return m.ToDTO() })
+	h.sendJSON(w, http.StatusOK, dtos)
+}
+
+func (h *HouseholdHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Name string
+	}
+
+	if errs := util.ParseZodSchema(householdSchema, r.Body, &params); errs != nil {
+		h.HandleZodError(w, errs)
+		return
+	}
+
+	membership, err := h.householdService.Create(r.Context(), params.Name, h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusCreated, membership.ToDTO())
+}
+
+func (h *HouseholdHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid household id")
+		return
+	}
+
+	if err := h.householdService.Delete(r.Context(), id, h.getUserIDFromCtx(r)); err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *HouseholdHandler) Members(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid household id")
+		return
+	}
+
+	members, err := h.householdService.Members(r.Context(), id, h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	dtos := util.Map(members, func(m domain.HouseholdMember) domain.HouseholdMemberDTO { return m.ToDTO() })
+	h.sendJSON(w, http.StatusOK, dtos)
+}
+
+func (h *HouseholdHandler) UpdateMember(w http.ResponseWriter, r *http.Request) {
+	id, memberID, ok := h.parseMemberParams(w, r)
+	if !ok {
+		return
+	}
+
+	var params struct {
+		Role string
+	}
+
+	if errs := util.ParseZodSchema(householdRoleSchema, r.Body, &params); errs != nil {
+		h.HandleZodError(w, errs)
+		return
+	}
+
+	err := h.householdService.UpdateMemberRole(r.Context(), id, memberID, domain.HouseholdRole(params.Role), h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *HouseholdHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	id, memberID, ok := h.parseMemberParams(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.householdService.RemoveMember(r.Context(), id, memberID, h.getUserIDFromCtx(r)); err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *HouseholdHandler) Invite(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid household id")
+		return
+	}
+
+	var params struct {
+		Email string
+		Role  string
+	}
+
+	if errs := util.ParseZodSchema(householdInviteSchema, r.Body, &params); errs != nil {
+		h.HandleZodError(w, errs)
+		return
+	}
+
+	dto := service.InviteMemberDTO{Email: params.Email, Role: domain.HouseholdRole(params.Role)}
+
+	invitation, err := h.householdService.Invite(r.Context(), id, dto, h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusCreated, invitation.ToDTO())
+}
+
+func (h *HouseholdHandler) Join(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Token string
+	}
+
+	if errs := util.ParseZodSchema(householdJoinSchema, r.Body, &params); errs != nil {
+		h.HandleZodError(w, errs)
+		return
+	}
+
+	membership, err := h.householdService.Accept(r.Context(), params.Token, h.getUserIDFromCtx(r))
+	if errors.Is(err, errs.ErrForbidden) {
+		h.sendError(w, http.StatusForbidden, "invitation was sent to another email")
+		return
+	} else if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, membership.ToDTO())
+}
+
+func (h *HouseholdHandler) parseMemberParams(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid household id")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	memberID, err := uuid.Parse(chi.URLParam(r, "user_id"))
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid user id")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return id, memberID, true
+}
@@ -0,0 +1,224 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/joaopsramos/fincon/internal/api"
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/mail"
+	"github.com/joaopsramos/fincon/internal/testhelper"
+	"github.com/joaopsramos/fincon/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHouseholdHandler(t *testing.T) {
+	t.Parallel()
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+
+	owner := f.InsertUser()
+	editor := f.InsertUser()
+	viewer := f.InsertUser()
+	stranger := f.InsertUser()
+	f.InsertSalary(&domain.Salary{UserID: viewer.ID})
+
+	goal := f.InsertGoal(&domain.Goal{Name: domain.Comfort, UserID: owner.ID})
+	expense := f.InsertExpense(&domain.Expense{Date: time.Now().UTC(), GoalID: goal.ID, UserID: owner.ID})
+
+	mailer := testhelper.NewMockMailer(t)
+	ownerApp := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: owner.ID, Mailer: mailer})
+	editorApp := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: editor.ID})
+	viewerApp := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: viewer.ID})
+	strangerApp := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: stranger.ID})
+
+	var householdID string
+
+	invite := func(email string, role domain.HouseholdRole) string {
+		var token string
+		mailer.EXPECT().Send(mock.Anything, mock.MatchedBy(func(e mail.Email) bool {
+			return e.To.Email == email && e.Template == mail.HouseholdInvitationTemplate
+		})).Run(func(_ context.Context, e mail.Email) {
+			link, err := url.Parse(e.Data["Link"].(string))
+			require.NoError(t, err)
+			token = link.Query().Get("token")
+		}).Return(nil).Once()
+
+		resp := ownerApp.Test(http.MethodPost, "/api/households/"+householdID+"/invitations", util.M{"email": email, "role": role})
+		require.Equal(t, 201, resp.StatusCode)
+
+		return token
+	}
+
+	t.Run("create", func(t *testing.T) {
+		a := assert.New(t)
+
+		var respBody util.M
+		resp := ownerApp.Test(http.MethodPost, "/api/households", util.M{"name": "Family"})
+		ownerApp.UnmarshalBody(resp.Body, &respBody)
+
+		a.Equal(201, resp.StatusCode)
+		householdID = respBody["id"].(string)
+		a.Equal(util.M{"id": householdID, "name": "Family", "owner_id": owner.ID.String(), "role": "owner"}, respBody)
+
+		resp = ownerApp.Test(http.MethodPost, "/api/households", util.M{"name": "Another"})
+		ownerApp.UnmarshalBody(resp.Body, &respBody)
+		a.Equal(400, resp.StatusCode)
+		a.Equal(util.M{"error": "you already own a household"}, respBody)
+	})
+
+	t.Run("invite", func(t *testing.T) {
+		a := assert.New(t)
+
+		var respBody util.M
+		resp := ownerApp.Test(http.MethodPost, "/api/households/"+householdID+"/invitations", util.M{"email": editor.Email, "role": "owner"})
+		ownerApp.UnmarshalBody(resp.Body, &respBody)
+		a.Equal(400, resp.StatusCode)
+		a.Equal(util.M{"error": "role must be one of: editor, viewer"}, respBody)
+
+		resp = strangerApp.Test(http.MethodPost, "/api/households/"+householdID+"/invitations", util.M{"email": stranger.Email, "role": "editor"})
+		a.Equal(404, resp.StatusCode)
+
+		// the invitation is rolled back when the email can't be sent
+		mailer.EXPECT().Send(mock.Anything, mock.Anything).Return(errors.New("connection refused")).Once()
+		resp = ownerApp.Test(http.MethodPost, "/api/households/"+householdID+"/invitations", util.M{"email": stranger.Email, "role": "editor"})
+		a.Equal(500, resp.StatusCode)
+
+		var count int64
+		tx.Model(&domain.HouseholdInvitation{}).Where("email = ?", stranger.Email).Count(&count)
+		a.Zero(count)
+	})
+
+	t.Run("join", func(t *testing.T) {
+		a := assert.New(t)
+		editorToken := invite(editor.Email, domain.RoleEditor)
+		viewerToken := invite(viewer.Email, domain.RoleViewer)
+
+		var respBody util.M
+		resp := strangerApp.Test(http.MethodPost, "/api/households/join", util.M{"token": editorToken})
+		strangerApp.UnmarshalBody(resp.Body, &respBody)
+		a.Equal(403, resp.StatusCode)
+		a.Equal(util.M{"error": "invitation was sent to another email"}, respBody)
+
+		resp = editorApp.Test(http.MethodPost, "/api/households/join", util.M{"token": editorToken})
+		editorApp.UnmarshalBody(resp.Body, &respBody)
+		a.Equal(200, resp.StatusCode)
+		a.Equal("editor", respBody["role"])
+
+		resp = editorApp.Test(http.MethodPost, "/api/households/join", util.M{"token": editorToken})
+		a.Equal(400, resp.StatusCode)
+
+		resp = viewerApp.Test(http.MethodPost, "/api/households/join", util.M{"token": viewerToken})
+		a.Equal(200, resp.StatusCode)
+
+		var members []util.M
+		resp = viewerApp.Test(http.MethodGet, "/api/households/"+householdID+"/members")
+		viewerApp.UnmarshalBody(resp.Body, &members)
+		a.Equal([]util.M{
+			{"user_id": owner.ID.String(), "email": owner.Email, "role": "owner"},
+			{"user_id": editor.ID.String(), "email": editor.Email, "role": "editor"},
+			{"user_id": viewer.ID.String(), "email": viewer.Email, "role": "viewer"},
+		}, members)
+	})
+
+	t.Run("access the household budget", func(t *testing.T) {
+		a := assert.New(t)
+		editorApp.Headers.Set(api.HouseholdHeader, householdID)
+		viewerApp.Headers.Set(api.HouseholdHeader, householdID)
+		strangerApp.Headers.Set(api.HouseholdHeader, householdID)
+		defer strangerApp.Headers.Del(api.HouseholdHeader)
+
+		var respBody util.M
+		resp := viewerApp.Test(http.MethodGet, "/api/expenses")
+		viewerApp.UnmarshalBody(resp.Body, &respBody)
+		a.Equal(200, resp.StatusCode)
+		a.Equal([]any{testhelper.FormatExpense(expense, goal)}, respBody["data"])
+
+		body := util.M{"name": "Groceries", "value": 10.5, "date": testhelper.DateToJsonString(time.Now().UTC()), "goal_id": goal.ID}
+
+		resp = viewerApp.Test(http.MethodPost, "/api/expenses", body)
+		viewerApp.UnmarshalBody(resp.Body, &respBody)
+		a.Equal(403, resp.StatusCode)
+		a.Equal(util.M{"error": "viewers can't change the household budget"}, respBody)
+
+		var created []util.M
+		resp = editorApp.Test(http.MethodPost, "/api/expenses", body)
+		editorApp.UnmarshalBody(resp.Body, &created)
+		a.Equal(201, resp.StatusCode)
+		a.Equal(editor.ID.String(), created[0]["created_by"])
+
+		var ownerExpense domain.Expense
+		tx.First(&ownerExpense, created[0]["id"])
+		a.Equal(owner.ID, ownerExpense.UserID)
+
+		resp = strangerApp.Test(http.MethodGet, "/api/expenses")
+		strangerApp.UnmarshalBody(resp.Body, &respBody)
+		a.Equal(403, resp.StatusCode)
+		a.Equal(util.M{"error": "you are not a member of this household"}, respBody)
+
+		strangerApp.Headers.Set(api.HouseholdHeader, "invalid")
+		resp = strangerApp.Test(http.MethodGet, "/api/expenses")
+		a.Equal(400, resp.StatusCode)
+
+		// the export is never of the household, only of the user's own account
+		resp = viewerApp.Test(http.MethodGet, "/api/export")
+		viewerApp.UnmarshalBody(resp.Body, &respBody)
+		a.Equal(200, resp.StatusCode)
+		a.Equal(viewer.Email, respBody["user"].(util.M)["email"])
+		a.Empty(respBody["expenses"])
+	})
+
+	t.Run("manage members", func(t *testing.T) {
+		a := assert.New(t)
+		membersPath := "/api/households/" + householdID + "/members/"
+
+		resp := editorApp.Test(http.MethodPatch, membersPath+viewer.ID.String(), util.M{"role": "editor"})
+		a.Equal(403, resp.StatusCode)
+
+		resp = ownerApp.Test(http.MethodPatch, membersPath+viewer.ID.String(), util.M{"role": "editor"})
+		a.Equal(204, resp.StatusCode)
+
+		resp = viewerApp.Test(http.MethodPost, "/api/tags", util.M{"name": "Shared"})
+		a.Equal(201, resp.StatusCode)
+
+		var respBody util.M
+		resp = ownerApp.Test(http.MethodDelete, membersPath+owner.ID.String())
+		ownerApp.UnmarshalBody(resp.Body, &respBody)
+		a.Equal(400, resp.StatusCode)
+		a.Equal(util.M{"error": "the owner can't leave the household, delete it instead"}, respBody)
+
+		// members can leave
+		resp = viewerApp.Test(http.MethodDelete, membersPath+viewer.ID.String())
+		a.Equal(204, resp.StatusCode)
+		a.Equal(403, viewerApp.Test(http.MethodGet, "/api/expenses").StatusCode)
+
+		resp = ownerApp.Test(http.MethodDelete, membersPath+editor.ID.String())
+		a.Equal(204, resp.StatusCode)
+		a.Equal(403, editorApp.Test(http.MethodGet, "/api/expenses").StatusCode)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		a := assert.New(t)
+
+		resp := ownerApp.Test(http.MethodDelete, "/api/households/"+householdID)
+		a.Equal(204, resp.StatusCode)
+
+		var households []util.M
+		resp = ownerApp.Test(http.MethodGet, "/api/households")
+		ownerApp.UnmarshalBody(resp.Body, &households)
+		a.Empty(households)
+
+		// the budget stays with the owner
+		var respBody util.M
+		resp = ownerApp.Test(http.MethodGet, "/api/expenses")
+		ownerApp.UnmarshalBody(resp.Body, &respBody)
+		a.Equal(200, resp.StatusCode)
+		a.Len(respBody["data"], 2)
+	})
+}
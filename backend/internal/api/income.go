@@ -0,0 +1,234 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	z "github.com/Oudwins/zog"
+	"github.com/go-chi/chi/v5"
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/service"
+	"github.com/joaopsramos/fincon/internal/util"
+)
+
+type IncomeHandler struct {
+	*BaseHandler
+	incomeService service.IncomeService
+}
+
+var (
+	incomeCreateSchema = z.Struct(z.Schema{
+		"name":      z.String().Trim().Min(2, z.Message("name must contain at least 2 characters")).Required(),
+		"amount":    z.Float().GTE(0.01, z.Message("amount must be greater than or equal to 0.01")).Required(),
+		"recurring": z.Bool().Optional(),
+		"startDate": z.Time(z.Time.Format(util.ApiDateLayout)).Required(),
+		"endDate":   z.Ptr(z.Time(z.Time.Format(util.ApiDateLayout))),
+	})
+
+	incomeUpdateSchema = z.Struct(z.Schema{
+		"name":      z.String().Trim().Min(2, z.Message("name must contain at least 2 characters")).Optional(),
+		"amount":    z.Float().GTE(0.01, z.Message("amount must be greater than or equal to 0.01")).Optional(),
+		"recurring": z.Ptr(z.Bool()),
+		"startDate": z.Time(z.Time.Format(util.ApiDateLayout)).Optional(),
+		"endDate":   z.Ptr(z.Time(z.Time.Format(util.ApiDateLayout))),
+	})
+
+	incomeAmountSchema = z.Struct(z.Schema{
+		"amount": z.Float().GTE(0, z.Message("amount must be greater than or equal to 0")).Required(),
+	})
+)
+
+func NewIncomeHandler(baseHandler *BaseHandler, incomeService service.IncomeService) *IncomeHandler {
+	return &IncomeHandler{
+		BaseHandler:   baseHandler,
+		incomeService: incomeService,
+	}
+}
+
+func (h *IncomeHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/incomes", h.Index)
+	r.Post("/incomes", h.Create)
+	r.Get("/incomes/{id}", h.Show)
+	r.Patch("/incomes/{id}", h.Update)
+	r.Delete("/incomes/{id}", h.Delete)
+	r.Put("/incomes/{id}/amounts/{month}", h.SetAmount)
+	r.Delete("/incomes/{id}/amounts/{month}", h.DeleteAmount)
+}
+
+func (h *IncomeHandler) Index(w http.ResponseWriter, r *http.Request) {
+	incomes, err := h.incomeService.All(r.Context(), h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	dtos := util.Map(incomes, func(i domain.Income) domain.IncomeDTO { return i.ToDTO() })
+	h.sendJSON(w, http.StatusOK, dtos)
+}
+
+func (h *IncomeHandler) Show(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid income id")
+		return
+	}
+
+	income, err := h.incomeService.Get(r.Context(), uint(id), h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, income.ToDTO())
+}
+
+func (h *IncomeHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Name      string
+		Amount    float64
+		Recurring bool
+		StartDate time.Time  `zog:"start_date"`
+		EndDate   *time.Time `zog:"end_date"`
+	}
+
+	if errs := util.ParseZodSchema(incomeCreateSchema, r.Body, &params); errs != nil {
+		h.HandleZodError(w, errs)
+		return
+	}
+
+	dto := service.CreateIncomeDTO{
+		Name:      params.Name,
+		Amount:    params.Amount,
+		Recurring: params.Recurring,
+		StartDate: params.StartDate,
+		EndDate:   params.EndDate,
+	}
+
+	income, err := h.incomeService.Create(r.Context(), dto, h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusCreated, income.ToDTO())
+}
+
+func (h *IncomeHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid income id")
+		return
+	}
+
+	var params struct {
+		Name      string
+		Amount    float64
+		Recurring *bool
+		StartDate time.Time  `zog:"start_date"`
+		EndDate   *time.Time `zog:"end_date"`
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid json body")
+		return
+	}
+
+	if errs := util.ParseZodSchema(incomeUpdateSchema, bytes.NewReader(body), &params); errs != nil {
+		h.HandleZodError(w, errs)
+		return
+	}
+
+	dto := service.UpdateIncomeDTO{
+		Name:      params.Name,
+		Amount:    params.Amount,
+		Recurring: params.Recurring,
+		StartDate: params.StartDate,
+		EndDate:   params.EndDate,
+		// "end_date": null removes the end date
+		ClearEndDate: util.IsNullField(body, "end_date"),
+	}
+
+	income, err := h.incomeService.UpdateByID(r.Context(), uint(id), dto, h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, income.ToDTO())
+}
+
+func (h *IncomeHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid income id")
+		return
+	}
+
+	if err := h.incomeService.Delete(r.Context(), uint(id), h.getUserIDFromCtx(r)); err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SetAmount sets what the income paid in the month of the path, e.g. 2025-03
+func (h *IncomeHandler) SetAmount(w http.ResponseWriter, r *http.Request) {
+	id, month, ok := h.parseAmountPath(w, r)
+	if !ok {
+		return
+	}
+
+	var params struct {
+		Amount float64
+	}
+
+	if errs := util.ParseZodSchema(incomeAmountSchema, r.Body, &params); errs != nil {
+		h.HandleZodError(w, errs)
+		return
+	}
+
+	income, err := h.incomeService.SetAmount(r.Context(), id, month, params.Amount, h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, income.ToDTO())
+}
+
+// DeleteAmount makes the income pay its usual amount again in the month of the path
+func (h *IncomeHandler) DeleteAmount(w http.ResponseWriter, r *http.Request) {
+	id, month, ok := h.parseAmountPath(w, r)
+	if !ok {
+		return
+	}
+
+	income, err := h.incomeService.DeleteAmount(r.Context(), id, month, h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, income.ToDTO())
+}
+
+func (h *IncomeHandler) parseAmountPath(w http.ResponseWriter, r *http.Request) (uint, time.Time, bool) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid income id")
+		return 0, time.Time{}, false
+	}
+
+	month, err := time.Parse(util.ApiMonthLayout, chi.URLParam(r, "month"))
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid month")
+		return 0, time.Time{}, false
+	}
+
+	return uint(id), month, true
+}
@@ -0,0 +1,236 @@
+package api_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/testhelper"
+	"github.com/joaopsramos/fincon/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncomeHandler_Create(t *testing.T) {
+	t.Parallel()
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	user := f.InsertUser()
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: user.ID})
+
+	tests := []struct {
+		name   string
+		body   util.M
+		status int
+		want   util.M
+	}{
+		{
+			"ensure required fields",
+			util.M{},
+			400,
+			util.M{"errors": util.M{
+				"name":       []any{"is required"},
+				"amount":     []any{"is required"},
+				"start_date": []any{"is required"},
+			}},
+		},
+		{
+			"invalid values",
+			util.M{"name": "F", "amount": 0, "start_date": "2025-01-01"},
+			400,
+			util.M{"errors": util.M{
+				"name":   []any{"name must contain at least 2 characters"},
+				"amount": []any{"amount must be greater than or equal to 0.01"},
+			}},
+		},
+		{
+			"one-off income with end date",
+			util.M{"name": "Bonus", "amount": 1000, "start_date": "2025-01-01", "end_date": "2025-02-01"},
+			400,
+			util.M{"error": "only recurring incomes can have an end date"},
+		},
+		{
+			"end date before start date",
+			util.M{"name": "Rent", "amount": 1000, "recurring": true, "start_date": "2025-02-01", "end_date": "2025-01-01"},
+			400,
+			util.M{"error": "end date must not be before start date"},
+		},
+		{
+			"one-off income",
+			util.M{"name": "Bonus", "amount": 1500.5, "start_date": "2025-01-10"},
+			201,
+			util.M{
+				"name":       "Bonus",
+				"amount":     1500.5,
+				"recurring":  false,
+				"start_date": "2025-01-10T00:00:00Z",
+				"end_date":   nil,
+				"amounts":    []any{},
+			},
+		},
+		{
+			"recurring income",
+			util.M{"name": "Freelance", "amount": 800, "recurring": true, "start_date": "2025-01-01", "end_date": "2025-06-01"},
+			201,
+			util.M{
+				"name":       "Freelance",
+				"amount":     800.0,
+				"recurring":  true,
+				"start_date": "2025-01-01T00:00:00Z",
+				"end_date":   "2025-06-01T00:00:00Z",
+				"amounts":    []any{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			var respBody util.M
+
+			resp := app.Test(http.MethodPost, "/api/incomes", tt.body)
+			app.UnmarshalBody(resp.Body, &respBody)
+
+			a.Equal(tt.status, resp.StatusCode)
+
+			if resp.StatusCode == http.StatusCreated {
+				a.NotZero(respBody["id"])
+				delete(respBody, "id")
+			}
+
+			a.Equal(tt.want, respBody)
+		})
+	}
+}
+
+func TestIncomeHandler_UpdateAndDelete(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	user := f.InsertUser()
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: user.ID})
+	anotherUserApp := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: f.InsertUser().ID})
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	income := f.InsertIncome(&domain.Income{Name: "Rent", Amount: 100000, Recurring: true, StartDate: start, UserID: user.ID})
+	path := fmt.Sprintf("/api/incomes/%d", income.ID)
+
+	var respBody util.M
+
+	resp := anotherUserApp.Test(http.MethodPatch, path, util.M{"amount": 1200})
+	anotherUserApp.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(404, resp.StatusCode)
+	assert.Equal(util.M{"error": "income not found"}, respBody)
+	clear(respBody)
+
+	resp = app.Test(http.MethodPatch, path, util.M{"amount": 1200, "end_date": "2025-12-01"})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(200, resp.StatusCode)
+	assert.Equal(util.M{
+		"id":         float64(income.ID),
+		"name":       "Rent",
+		"amount":     1200.0,
+		"recurring":  true,
+		"start_date": "2025-01-01T00:00:00Z",
+		"end_date":   "2025-12-01T00:00:00Z",
+		"amounts":    []any{},
+	}, respBody)
+	clear(respBody)
+
+	resp = app.Test(http.MethodPatch, path, util.M{"recurring": false})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(400, resp.StatusCode)
+	assert.Equal(util.M{"error": "only recurring incomes can have an end date"}, respBody)
+	clear(respBody)
+
+	// "end_date": null makes it open-ended, and omitting it keeps it
+	resp = app.Test(http.MethodPatch, path, util.M{"end_date": nil})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(200, resp.StatusCode)
+	assert.Nil(respBody["end_date"])
+
+	resp = app.Test(http.MethodPatch, path, util.M{"recurring": false, "end_date": "2025-12-01"})
+	assert.Equal(400, resp.StatusCode)
+
+	resp = app.Test(http.MethodPatch, path, util.M{"name": "Rental"})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(200, resp.StatusCode)
+	assert.Nil(respBody["end_date"])
+
+	var listBody []util.M
+	resp = app.Test(http.MethodGet, "/api/incomes")
+	app.UnmarshalBody(resp.Body, &listBody)
+	assert.Equal(200, resp.StatusCode)
+	assert.Len(listBody, 1)
+
+	resp = app.Test(http.MethodDelete, path)
+	assert.Equal(204, resp.StatusCode)
+
+	resp = app.Test(http.MethodGet, path)
+	assert.Equal(404, resp.StatusCode)
+}
+
+func TestIncomeHandler_Amounts(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	user := f.InsertUser()
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: user.ID})
+	anotherUserApp := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: f.InsertUser().ID})
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	income := f.InsertIncome(&domain.Income{Name: "Freelance", Amount: 100000, Recurring: true, StartDate: start, UserID: user.ID})
+	path := fmt.Sprintf("/api/incomes/%d/amounts", income.ID)
+
+	var respBody util.M
+
+	resp := app.Test(http.MethodPut, path+"/2025-03", util.M{"amount": 1500.5})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(200, resp.StatusCode)
+	assert.Equal(1000.0, respBody["amount"])
+	assert.Equal([]any{util.M{"month": "2025-03", "amount": 1500.5}}, respBody["amounts"])
+
+	// setting it again replaces the amount
+	resp = app.Test(http.MethodPut, path+"/2025-03", util.M{"amount": 0})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(200, resp.StatusCode)
+	assert.Equal([]any{util.M{"month": "2025-03", "amount": 0.0}}, respBody["amounts"])
+
+	tests := []struct {
+		name   string
+		app    *testhelper.TestApp
+		path   string
+		body   util.M
+		status int
+		want   util.M
+	}{
+		{"invalid month", app, path + "/2025-3", util.M{"amount": 10}, 400, util.M{"error": "invalid month"}},
+		{"negative amount", app, path + "/2025-04", util.M{"amount": -1}, 400, util.M{"errors": util.M{"amount": []any{"amount must be greater than or equal to 0"}}}},
+		{"month before the start", app, path + "/2024-12", util.M{"amount": 10}, 400, util.M{"error": "the income is not received in this month"}},
+		{"another user", anotherUserApp, path + "/2025-04", util.M{"amount": 10}, 404, util.M{"error": "income not found"}},
+	}
+
+	clear(respBody)
+
+	for _, tt := range tests {
+		resp := tt.app.Test(http.MethodPut, tt.path, tt.body)
+		tt.app.UnmarshalBody(resp.Body, &respBody)
+		assert.Equal(tt.status, resp.StatusCode, tt.name)
+		assert.Equal(tt.want, respBody, tt.name)
+		clear(respBody)
+	}
+
+	resp = app.Test(http.MethodDelete, path+"/2025-03")
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(200, resp.StatusCode)
+	assert.Equal([]any{}, respBody["amounts"])
+	clear(respBody)
+
+	resp = app.Test(http.MethodDelete, path+"/2025-03")
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(404, resp.StatusCode)
+	assert.Equal(util.M{"error": "income amount not found"}, respBody)
+}
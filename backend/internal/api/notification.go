@@ -0,0 +1,66 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/service"
+	"github.com/joaopsramos/fincon/internal/util"
+)
+
+type NotificationHandler struct {
+	*BaseHandler
+	alertService service.AlertService
+}
+
+func NewNotificationHandler(baseHandler *BaseHandler, alertService service.AlertService) *NotificationHandler {
+	return &NotificationHandler{
+		BaseHandler:  baseHandler,
+		alertService: alertService,
+	}
+}
+
+func (h *NotificationHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/notifications", h.Index)
+	r.Post("/notifications/read", h.ReadAll)
+	r.Post("/notifications/{id}/read", h.Read)
+}
+
+func (h *NotificationHandler) Index(w http.ResponseWriter, r *http.Request) {
+	unreadOnly, _ := strconv.ParseBool(r.URL.Query().Get("unread"))
+
+	notifications, err := h.alertService.All(r.Context(), unreadOnly, h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	dtos := util.Map(notifications, func(n domain.Notification) domain.NotificationDTO { return n.ToDTO() })
+	h.sendJSON(w, http.StatusOK, dtos)
+}
+
+func (h *NotificationHandler) Read(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid notification id")
+		return
+	}
+
+	if err := h.alertService.MarkRead(r.Context(), uint(id), h.getUserIDFromCtx(r)); err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *NotificationHandler) ReadAll(w http.ResponseWriter, r *http.Request) {
+	if err := h.alertService.MarkAllRead(r.Context(), h.getUserIDFromCtx(r)); err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,129 @@
+package api_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/testhelper"
+	"github.com/joaopsramos/fincon/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationHandler(t *testing.T) {
+	t.Parallel()
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+
+	user := f.InsertUser()
+	f.InsertSalary(&domain.Salary{Amount: 1000 * 100, UserID: user.ID})
+	goal := f.InsertGoal(&domain.Goal{Name: domain.Pleasures, Percentage: 100, UserID: user.ID})
+
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: user.ID})
+	a := assert.New(t)
+	today := time.Now().UTC().Format(util.ApiDateLayout)
+
+	var notificationID float64
+
+	t.Run("crossing a threshold creates a notification", func(t *testing.T) {
+		resp := app.Test(http.MethodPost, "/api/expenses", util.M{"name": "Trip", "value": 900, "date": today, "goal_id": goal.ID})
+		require.Equal(t, 201, resp.StatusCode)
+
+		var respBody []util.M
+		resp = app.Test(http.MethodGet, "/api/notifications")
+		app.UnmarshalBody(resp.Body, &respBody)
+
+		a.Equal(200, resp.StatusCode)
+		require.Len(t, respBody, 1)
+
+		notificationID = respBody[0]["id"].(float64)
+		delete(respBody[0], "id")
+		delete(respBody[0], "created_at")
+
+		a.Equal(util.M{
+			"kind":      "goal_threshold",
+			"goal_id":   float64(goal.ID),
+			"goal_name": "Pleasures",
+			"threshold": 80.0,
+			"period":    time.Now().UTC().Format(util.ApiMonthLayout),
+			"spent":     900.0,
+			"limit":     1000.0,
+			"read":      false,
+		}, respBody[0])
+	})
+
+	t.Run("mark as read", func(t *testing.T) {
+		resp := app.Test(http.MethodPost, fmt.Sprintf("/api/notifications/%d/read", int(notificationID)))
+		a.Equal(204, resp.StatusCode)
+
+		var respBody []util.M
+		resp = app.Test(http.MethodGet, "/api/notifications?unread=true")
+		app.UnmarshalBody(resp.Body, &respBody)
+		a.Empty(respBody)
+
+		resp = app.Test(http.MethodPost, "/api/notifications/999999/read")
+		a.Equal(404, resp.StatusCode)
+	})
+
+	t.Run("mark all as read", func(t *testing.T) {
+		resp := app.Test(http.MethodPatch, fmt.Sprintf("/api/goals/%d", goal.ID), util.M{"alert_thresholds": []int{50, 80, 100}})
+		require.Equal(t, 200, resp.StatusCode)
+
+		resp = app.Test(http.MethodPost, "/api/expenses", util.M{"name": "Hotel", "value": 200, "date": today, "goal_id": goal.ID})
+		require.Equal(t, 201, resp.StatusCode)
+
+		var respBody []util.M
+		resp = app.Test(http.MethodGet, "/api/notifications?unread=true")
+		app.UnmarshalBody(resp.Body, &respBody)
+		// 50% is crossed as well but it's lower than the 80% that already fired
+		a.Len(respBody, 2)
+
+		resp = app.Test(http.MethodPost, "/api/notifications/read")
+		a.Equal(204, resp.StatusCode)
+
+		resp = app.Test(http.MethodGet, "/api/notifications?unread=true")
+		app.UnmarshalBody(resp.Body, &respBody)
+		a.Empty(respBody)
+	})
+}
+
+func TestGoalHandler_UpdateAlerts(t *testing.T) {
+	t.Parallel()
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+
+	user := f.InsertUser()
+	goal := f.InsertGoal(&domain.Goal{Name: domain.Pleasures, UserID: user.ID})
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: user.ID})
+	path := fmt.Sprintf("/api/goals/%d", goal.ID)
+
+	tests := []struct {
+		name   string
+		body   util.M
+		status int
+		want   util.M
+	}{
+		{"sort and deduplicate thresholds", util.M{"alert_thresholds": []int{100, 50, 100}, "email_alerts": true}, 200, util.M{"alert_thresholds": []any{50.0, 100.0}, "email_alerts": true}},
+		{"disable alerts", util.M{"alert_thresholds": []int{}}, 200, util.M{"alert_thresholds": []any{}, "email_alerts": true}},
+		{"invalid threshold", util.M{"alert_thresholds": []int{0}}, 400, nil},
+		{"too many thresholds", util.M{"alert_thresholds": []int{10, 20, 30, 40, 50, 60}}, 400, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			var respBody util.M
+
+			resp := app.Test(http.MethodPatch, path, tt.body)
+			app.UnmarshalBody(resp.Body, &respBody)
+			a.Equal(tt.status, resp.StatusCode)
+
+			for k, v := range tt.want {
+				a.Equal(v, respBody[k], k)
+			}
+		})
+	}
+}
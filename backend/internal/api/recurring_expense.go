@@ -0,0 +1,188 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	z "github.com/Oudwins/zog"
+	"github.com/go-chi/chi/v5"
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/service"
+	"github.com/joaopsramos/fincon/internal/util"
+)
+
+type RecurringExpenseHandler struct {
+	*BaseHandler
+	recurringExpenseService service.RecurringExpenseService
+}
+
+var (
+	cadenceMessage = z.Message("cadence must be one of: monthly, quarterly, yearly")
+	dayMessage     = z.Message("day of month must be between 1 and 31")
+
+	recurringExpenseCreateSchema = z.Struct(z.Schema{
+		"name":       z.String().Trim().Min(2, z.Message("name must contain at least 2 characters")).Required(),
+		"value":      z.Float().GTE(0.01, z.Message("value must be greater than or equal to 0.01")).Required(),
+		"dayOfMonth": z.Int().GTE(1, dayMessage).LTE(31, dayMessage).Required(),
+		"cadence":    z.String().OneOf(domain.RecurringCadences(), cadenceMessage).Optional(),
+		"startDate":  z.Time(z.Time.Format(util.ApiDateLayout)).Required(),
+		"endDate":    z.Ptr(z.Time(z.Time.Format(util.ApiDateLayout))),
+		"goalID":     z.Int().Required(),
+	})
+
+	recurringExpenseUpdateSchema = z.Struct(z.Schema{
+		"name":       z.String().Trim().Min(2, z.Message("name must contain at least 2 characters")).Optional(),
+		"value":      z.Float().GTE(0.01, z.Message("value must be greater than or equal to 0.01")).Optional(),
+		"dayOfMonth": z.Int().GTE(1, dayMessage).LTE(31, dayMessage).Optional(),
+		"cadence":    z.String().OneOf(domain.RecurringCadences(), cadenceMessage).Optional(),
+		"startDate":  z.Time(z.Time.Format(util.ApiDateLayout)).Optional(),
+		"endDate":    z.Ptr(z.Time(z.Time.Format(util.ApiDateLayout))),
+		"goalID":     z.Int().Optional(),
+	})
+)
+
+func NewRecurringExpenseHandler(baseHandler *BaseHandler, recurringExpenseService service.RecurringExpenseService) *RecurringExpenseHandler {
+	return &RecurringExpenseHandler{
+		BaseHandler:             baseHandler,
+		recurringExpenseService: recurringExpenseService,
+	}
+}
+
+func (h *RecurringExpenseHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/recurring-expenses", h.Index)
+	r.Post("/recurring-expenses", h.Create)
+	r.Get("/recurring-expenses/{id}", h.Show)
+	r.Patch("/recurring-expenses/{id}", h.Update)
+	r.Delete("/recurring-expenses/{id}", h.Delete)
+}
+
+func (h *RecurringExpenseHandler) Index(w http.ResponseWriter, r *http.Request) {
+	userID := h.getUserIDFromCtx(r)
+
+	recurringExpenses, err := h.recurringExpenseService.All(r.Context(), userID)
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	dtos := util.Map(recurringExpenses, func(re domain.RecurringExpense) domain.RecurringExpenseDTO { return re.ToDTO() })
+	h.sendJSON(w, http.StatusOK, dtos)
+}
+
+func (h *RecurringExpenseHandler) Show(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid recurring expense id")
+		return
+	}
+
+	recurringExpense, err := h.recurringExpenseService.Get(r.Context(), uint(id), h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, recurringExpense.ToDTO())
+}
+
+func (h *RecurringExpenseHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Name       string
+		Value      float64
+		DayOfMonth int `zog:"day_of_month"`
+		Cadence    string
+		StartDate  time.Time  `zog:"start_date"`
+		EndDate    *time.Time `zog:"end_date"`
+		GoalID     int        `zog:"goal_id"`
+	}
+
+	if errs := util.ParseZodSchema(recurringExpenseCreateSchema, r.Body, &params); errs != nil {
+		h.HandleZodError(w, errs)
+		return
+	}
+
+	dto := service.CreateRecurringExpenseDTO{
+		Name:       params.Name,
+		Value:      params.Value,
+		DayOfMonth: params.DayOfMonth,
+		Cadence:    params.Cadence,
+		StartDate:  params.StartDate,
+		EndDate:    params.EndDate,
+		GoalID:     params.GoalID,
+	}
+
+	recurringExpense, err := h.recurringExpenseService.Create(r.Context(), dto, h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusCreated, recurringExpense.ToDTO())
+}
+
+func (h *RecurringExpenseHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid recurring expense id")
+		return
+	}
+
+	var params struct {
+		Name       string
+		Value      float64
+		DayOfMonth int `zog:"day_of_month"`
+		Cadence    string
+		StartDate  time.Time  `zog:"start_date"`
+		EndDate    *time.Time `zog:"end_date"`
+		GoalID     int        `zog:"goal_id"`
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid json body")
+		return
+	}
+
+	if errs := util.ParseZodSchema(recurringExpenseUpdateSchema, bytes.NewReader(body), &params); errs != nil {
+		h.HandleZodError(w, errs)
+		return
+	}
+
+	dto := service.UpdateRecurringExpenseDTO{
+		Name:       params.Name,
+		Value:      params.Value,
+		DayOfMonth: params.DayOfMonth,
+		Cadence:    params.Cadence,
+		StartDate:  params.StartDate,
+		EndDate:    params.EndDate,
+		// "end_date": null removes the end date
+		ClearEndDate: util.IsNullField(body, "end_date"),
+		GoalID:       params.GoalID,
+	}
+
+	recurringExpense, err := h.recurringExpenseService.UpdateByID(r.Context(), uint(id), dto, h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, recurringExpense.ToDTO())
+}
+
+func (h *RecurringExpenseHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid recurring expense id")
+		return
+	}
+
+	if err := h.recurringExpenseService.Delete(r.Context(), uint(id), h.getUserIDFromCtx(r)); err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
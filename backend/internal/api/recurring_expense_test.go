@@ -0,0 +1,178 @@
+package api_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/testhelper"
+	"github.com/joaopsramos/fincon/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecurringExpenseHandler_Create(t *testing.T) {
+	t.Parallel()
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	user := f.InsertUser()
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: user.ID})
+
+	goal := domain.Goal{Name: "Fixed costs", UserID: user.ID}
+	f.InsertGoal(&goal)
+
+	tests := []struct {
+		name   string
+		body   util.M
+		status int
+		want   util.M
+	}{
+		{
+			"ensure required fields",
+			util.M{},
+			400,
+			util.M{"errors": util.M{
+				"name":         []any{"is required"},
+				"value":        []any{"is required"},
+				"day_of_month": []any{"is required"},
+				"start_date":   []any{"is required"},
+				"goal_id":      []any{"is required"},
+			}},
+		},
+		{
+			"invalid values",
+			util.M{"name": "Rent", "value": 1500, "day_of_month": 32, "cadence": "weekly", "start_date": "2025-01-01", "goal_id": goal.ID},
+			400,
+			util.M{"errors": util.M{
+				"day_of_month": []any{"day of month must be between 1 and 31"},
+				"cadence":      []any{"cadence must be one of: monthly, quarterly, yearly"},
+			}},
+		},
+		{
+			"end date before start date",
+			util.M{"name": "Rent", "value": 1500, "day_of_month": 5, "start_date": "2025-02-01", "end_date": "2025-01-01", "goal_id": goal.ID},
+			400,
+			util.M{"error": "end date must not be before start date"},
+		},
+		{
+			"goal not found",
+			util.M{"name": "Rent", "value": 1500, "day_of_month": 5, "start_date": "2025-01-01", "goal_id": goal.ID + 1},
+			404,
+			util.M{"error": "goal not found"},
+		},
+		{
+			"defaults to monthly cadence",
+			util.M{"name": "Rent", "value": 1500, "day_of_month": 5, "start_date": "2025-01-01", "goal_id": goal.ID},
+			201,
+			util.M{
+				"name":         "Rent",
+				"value":        1500.0,
+				"day_of_month": 5.0,
+				"cadence":      "monthly",
+				"start_date":   "2025-01-01T00:00:00Z",
+				"end_date":     nil,
+				"goal_id":      float64(goal.ID),
+			},
+		},
+		{
+			"with end date and cadence",
+			util.M{"name": "Insurance", "value": 99.9, "day_of_month": 10, "cadence": "yearly", "start_date": "2025-01-01", "end_date": "2027-01-01", "goal_id": goal.ID},
+			201,
+			util.M{
+				"name":         "Insurance",
+				"value":        99.9,
+				"day_of_month": 10.0,
+				"cadence":      "yearly",
+				"start_date":   "2025-01-01T00:00:00Z",
+				"end_date":     "2027-01-01T00:00:00Z",
+				"goal_id":      float64(goal.ID),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			var respBody util.M
+
+			resp := app.Test(http.MethodPost, "/api/recurring-expenses", tt.body)
+			app.UnmarshalBody(resp.Body, &respBody)
+
+			a.Equal(tt.status, resp.StatusCode)
+
+			if resp.StatusCode == http.StatusCreated {
+				a.NotZero(respBody["id"])
+				delete(respBody, "id")
+			}
+
+			a.Equal(tt.want, respBody)
+		})
+	}
+}
+
+func TestRecurringExpenseHandler_UpdateAndDelete(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	user := f.InsertUser()
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: user.ID})
+	anotherUserApp := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: f.InsertUser().ID})
+
+	goal := domain.Goal{Name: "Fixed costs", UserID: user.ID}
+	f.InsertGoal(&goal)
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	rent := f.InsertRecurringExpense(&domain.RecurringExpense{Name: "Rent", Value: 100000, DayOfMonth: 5, StartDate: start, GoalID: goal.ID, UserID: user.ID})
+	path := fmt.Sprintf("/api/recurring-expenses/%d", rent.ID)
+
+	var respBody util.M
+
+	resp := anotherUserApp.Test(http.MethodPatch, path, util.M{"value": 1200})
+	anotherUserApp.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(404, resp.StatusCode)
+	assert.Equal(util.M{"error": "recurring expense not found"}, respBody)
+
+	resp = app.Test(http.MethodPatch, path, util.M{"value": 1200, "day_of_month": 10})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(200, resp.StatusCode)
+	assert.Equal(util.M{
+		"id":           float64(rent.ID),
+		"name":         "Rent",
+		"value":        1200.0,
+		"day_of_month": 10.0,
+		"cadence":      "monthly",
+		"start_date":   "2025-01-01T00:00:00Z",
+		"end_date":     nil,
+		"goal_id":      float64(goal.ID),
+	}, respBody)
+
+	resp = app.Test(http.MethodPatch, path, util.M{"end_date": "2025-06-01"})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(200, resp.StatusCode)
+	assert.Equal("2025-06-01T00:00:00Z", respBody["end_date"])
+
+	// omitting the end date keeps it, null removes it
+	resp = app.Test(http.MethodPatch, path, util.M{"value": 1300})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(200, resp.StatusCode)
+	assert.Equal("2025-06-01T00:00:00Z", respBody["end_date"])
+
+	resp = app.Test(http.MethodPatch, path, util.M{"end_date": nil})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(200, resp.StatusCode)
+	assert.Nil(respBody["end_date"])
+
+	var listBody []util.M
+	resp = app.Test(http.MethodGet, "/api/recurring-expenses")
+	app.UnmarshalBody(resp.Body, &listBody)
+	assert.Equal(200, resp.StatusCode)
+	assert.Len(listBody, 1)
+
+	resp = app.Test(http.MethodDelete, path)
+	assert.Equal(204, resp.StatusCode)
+
+	resp = app.Test(http.MethodGet, path)
+	assert.Equal(404, resp.StatusCode)
+}
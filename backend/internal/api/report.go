@@ -0,0 +1,92 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/joaopsramos/fincon/internal/service"
+	"github.com/joaopsramos/fincon/internal/util"
+)
+
+type ReportHandler struct {
+	*BaseHandler
+	tagService     service.TagService
+	expenseService service.ExpenseService
+}
+
+func NewReportHandler(baseHandler *BaseHandler, tagService service.TagService, expenseService service.ExpenseService) *ReportHandler {
+	return &ReportHandler{
+		BaseHandler:    baseHandler,
+		tagService:     tagService,
+		expenseService: expenseService,
+	}
+}
+
+func (h *ReportHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/reports/tags", h.Tags)
+	r.Get("/reports/trend", h.Trend)
+}
+
+// Trend returns the spent and must spend of each goal in every month between the "from" and
+// "to" months (YYYY-MM), plus yearly totals and averages. By default from January up to the
+// current month.
+func (h *ReportHandler) Trend(w http.ResponseWriter, r *http.Request) {
+	from, to, ok := h.parseMonthRange(w, r)
+	if !ok {
+		return
+	}
+
+	trend, err := h.expenseService.GetTrend(r.Context(), from, to, h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, trend)
+}
+
+// Tags returns the monthly totals of each tag between the "from" and "to" months (YYYY-MM),
+// by default from January up to the current month
+func (h *ReportHandler) Tags(w http.ResponseWriter, r *http.Request) {
+	from, to, ok := h.parseMonthRange(w, r)
+	if !ok {
+		return
+	}
+
+	reports, err := h.tagService.Report(r.Context(), from, to, h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, reports)
+}
+
+func (h *ReportHandler) parseMonthRange(w http.ResponseWriter, r *http.Request) (time.Time, time.Time, bool) {
+	now := time.Now()
+	from := time.Date(now.Year(), time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	if v := r.URL.Query().Get("from"); v != "" {
+		month, err := time.Parse(util.ApiMonthLayout, v)
+		if err != nil {
+			h.sendError(w, http.StatusBadRequest, "invalid from month")
+			return from, to, false
+		}
+
+		from = month
+	}
+
+	if v := r.URL.Query().Get("to"); v != "" {
+		month, err := time.Parse(util.ApiMonthLayout, v)
+		if err != nil {
+			h.sendError(w, http.StatusBadRequest, "invalid to month")
+			return from, to, false
+		}
+
+		to = month
+	}
+
+	return from, to, true
+}
@@ -0,0 +1,180 @@
+package api_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/testhelper"
+	"github.com/joaopsramos/fincon/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportHandler_Tags(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	user := f.InsertUser()
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: user.ID})
+
+	goal := f.InsertGoal(&domain.Goal{Name: "Comfort", UserID: user.ID})
+	car := f.InsertTag(&domain.Tag{Name: "Car", UserID: user.ID})
+	trip := f.InsertTag(&domain.Tag{Name: "Trip", UserID: user.ID})
+
+	jan := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	f.InsertExpense(
+		&domain.Expense{Value: 100 * 100, Date: jan, GoalID: goal.ID, UserID: user.ID, Tags: []domain.Tag{car}},
+		&domain.Expense{Value: 50 * 100, Date: jan.AddDate(0, 0, 5), GoalID: goal.ID, UserID: user.ID, Tags: []domain.Tag{car, trip}},
+		&domain.Expense{Value: 30 * 100, Date: jan.AddDate(0, 2, 0), GoalID: goal.ID, UserID: user.ID, Tags: []domain.Tag{car}},
+		// Outside of the range
+		&domain.Expense{Value: 999 * 100, Date: jan.AddDate(0, 3, 0), GoalID: goal.ID, UserID: user.ID, Tags: []domain.Tag{car}},
+		// Without tags
+		&domain.Expense{Value: 999 * 100, Date: jan, GoalID: goal.ID, UserID: user.ID},
+	)
+
+	var respBody any
+
+	resp := app.Test(http.MethodGet, "/api/reports/tags?from=2025-01&to=2025-03")
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(200, resp.StatusCode)
+	assert.Equal([]any{
+		util.M{
+			"id":    float64(car.ID),
+			"name":  "Car",
+			"total": 180.0,
+			"months": []any{
+				util.M{"month": "2025-01", "total": 150.0},
+				util.M{"month": "2025-02", "total": 0.0},
+				util.M{"month": "2025-03", "total": 30.0},
+			},
+		},
+		util.M{
+			"id":    float64(trip.ID),
+			"name":  "Trip",
+			"total": 50.0,
+			"months": []any{
+				util.M{"month": "2025-01", "total": 50.0},
+				util.M{"month": "2025-02", "total": 0.0},
+				util.M{"month": "2025-03", "total": 0.0},
+			},
+		},
+	}, respBody)
+
+	invalid := []struct {
+		query string
+		error string
+	}{
+		{"from=2025-13", "invalid from month"},
+		{"to=2025-01-01", "invalid to month"},
+		{"from=2025-03&to=2025-01", "to must not be before from"},
+	}
+
+	for _, tt := range invalid {
+		resp := app.Test(http.MethodGet, "/api/reports/tags?"+tt.query)
+		app.UnmarshalBody(resp.Body, &respBody)
+		assert.Equal(400, resp.StatusCode, tt.query)
+		assert.Equal(util.M{"error": tt.error}, respBody, tt.query)
+	}
+}
+
+func TestReportHandler_Trend(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	user := f.InsertUser()
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: user.ID})
+
+	nov := time.Date(2024, 11, 10, 0, 0, 0, 0, time.UTC)
+
+	f.InsertSalary(&domain.Salary{Amount: 1000 * 100, EffectiveFrom: nov, UserID: user.ID})
+	comfort := f.InsertGoal(&domain.Goal{Name: "Comfort", Percentage: 60, UserID: user.ID})
+	pleasures := f.InsertGoal(&domain.Goal{Name: "Pleasures", Percentage: 40, Position: 1, UserID: user.ID})
+	archivedAt := time.Date(2024, 12, 20, 0, 0, 0, 0, time.UTC)
+	travel := f.InsertGoal(&domain.Goal{Name: "Travel", Position: 2, ArchivedAt: &archivedAt, UserID: user.ID})
+
+	f.InsertExpense(
+		&domain.Expense{Value: 700 * 100, Date: nov, GoalID: comfort.ID, UserID: user.ID},
+		&domain.Expense{Value: 50 * 100, Date: nov, GoalID: travel.ID, UserID: user.ID},
+		&domain.Expense{Value: 200 * 100, Date: nov.AddDate(0, 1, 0), GoalID: comfort.ID, UserID: user.ID},
+		&domain.Expense{Value: 100 * 100, Date: nov.AddDate(0, 2, 0), GoalID: pleasures.ID, UserID: user.ID},
+	)
+
+	var respBody util.M
+
+	resp := app.Test(http.MethodGet, "/api/reports/trend?from=2024-11&to=2025-02")
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(200, resp.StatusCode)
+
+	type series struct {
+		month     string
+		spent     []any
+		mustSpend []any
+	}
+
+	months := util.Map(respBody["months"].([]any), func(m any) series {
+		goals := m.(util.M)["goals"].([]any)
+
+		return series{
+			m.(util.M)["month"].(string),
+			util.Map(goals, func(g any) any { return g.(util.M)["spent"] }),
+			util.Map(goals, func(g any) any { return g.(util.M)["must_spend"] }),
+		}
+	})
+
+	// the excess of November is carried over to December
+	assert.Equal([]series{
+		{"2024-11", []any{700.0, 0.0}, []any{600.0, 400.0}},
+		{"2024-12", []any{300.0, 0.0}, []any{600.0, 400.0}},
+		{"2025-01", []any{0.0, 100.0}, []any{600.0, 400.0}},
+		{"2025-02", []any{0.0, 0.0}, []any{600.0, 400.0}},
+	}, months)
+
+	assert.Equal([]any{
+		util.M{
+			"year":               2024.0,
+			"months":             2.0,
+			"spent":              950.0,
+			"must_spend":         2000.0,
+			"average_spent":      475.0,
+			"average_must_spend": 1000.0,
+			"goals": []any{
+				util.M{"name": "Comfort", "spent": 900.0, "must_spend": 1200.0, "average_spent": 450.0, "average_must_spend": 600.0},
+				util.M{"name": "Pleasures", "spent": 0.0, "must_spend": 800.0, "average_spent": 0.0, "average_must_spend": 400.0},
+				// archived goals count in the months before they were archived
+				util.M{"name": "Travel", "spent": 50.0, "must_spend": 0.0, "average_spent": 25.0, "average_must_spend": 0.0},
+			},
+		},
+		util.M{
+			"year":               2025.0,
+			"months":             2.0,
+			"spent":              100.0,
+			"must_spend":         2000.0,
+			"average_spent":      50.0,
+			"average_must_spend": 1000.0,
+			"goals": []any{
+				util.M{"name": "Comfort", "spent": 0.0, "must_spend": 1200.0, "average_spent": 0.0, "average_must_spend": 600.0},
+				util.M{"name": "Pleasures", "spent": 100.0, "must_spend": 800.0, "average_spent": 50.0, "average_must_spend": 400.0},
+			},
+		},
+	}, respBody["years"])
+
+	invalid := []struct {
+		query string
+		error string
+	}{
+		{"from=2024/11", "invalid from month"},
+		{"from=2025-02&to=2024-11", "to must not be before from"},
+		{"from=2020-01&to=2025-01", "the range must have at most 60 months"},
+	}
+
+	for _, tt := range invalid {
+		resp := app.Test(http.MethodGet, "/api/reports/trend?"+tt.query)
+		var errBody util.M
+		app.UnmarshalBody(resp.Body, &errBody)
+		assert.Equal(400, resp.StatusCode, tt.query)
+		assert.Equal(util.M{"error": tt.error}, errBody, tt.query)
+	}
+}
@@ -2,9 +2,11 @@ package api
 
 import (
 	"net/http"
+	"time"
 
 	z "github.com/Oudwins/zog"
 	"github.com/go-chi/chi/v5"
+	"github.com/joaopsramos/fincon/internal/domain"
 	"github.com/joaopsramos/fincon/internal/service"
 	"github.com/joaopsramos/fincon/internal/util"
 )
@@ -15,7 +17,8 @@ type SalaryHandler struct {
 }
 
 var salaryUpdateSchema = z.Struct(z.Schema{
-	"amount": z.Float().GT(0, z.Message("must be greater than 0")).Required(),
+	"amount":        z.Float().GT(0, z.Message("must be greater than 0")).Required(),
+	"effectiveFrom": z.Time(z.Time.Format(util.ApiDateLayout)).Optional(),
 })
 
 func NewSalaryHandler(baseHandler *BaseHandler, salaryService service.SalaryService) *SalaryHandler {
@@ -28,6 +31,7 @@ func NewSalaryHandler(baseHandler *BaseHandler, salaryService service.SalaryServ
 func (h *SalaryHandler) RegisterRoutes(r chi.Router) {
 	r.Get("/salary", h.GetSalary)
 	r.Patch("/salary", h.UpdateSalary)
+	r.Get("/salary/history", h.GetHistory)
 }
 
 func (h *SalaryHandler) GetSalary(w http.ResponseWriter, r *http.Request) {
@@ -38,7 +42,8 @@ func (h *SalaryHandler) GetSalary(w http.ResponseWriter, r *http.Request) {
 
 func (h *SalaryHandler) UpdateSalary(w http.ResponseWriter, r *http.Request) {
 	var params struct {
-		Amount float64 `json:"amount"`
+		Amount        float64   `json:"amount"`
+		EffectiveFrom time.Time `zog:"effective_from"`
 	}
 
 	if errs := util.ParseZodSchema(salaryUpdateSchema, r.Body, &params); errs != nil {
@@ -47,12 +52,26 @@ func (h *SalaryHandler) UpdateSalary(w http.ResponseWriter, r *http.Request) {
 	}
 
 	userID := h.getUserIDFromCtx(r)
-	salary := util.Must(h.salaryService.Get(r.Context(), userID))
-
-	if err := h.salaryService.UpdateAmount(r.Context(), salary, params.Amount); err != nil {
+	salary, err := h.salaryService.UpdateAmount(r.Context(), userID, params.Amount, params.EffectiveFrom)
+	if err != nil {
 		h.HandleError(w, err)
 		return
 	}
 
 	h.sendJSON(w, http.StatusOK, salary.ToDTO())
 }
+
+func (h *SalaryHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	history, err := h.salaryService.History(r.Context(), h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	dtos := make([]domain.SalaryVersionDTO, len(history))
+	for i, s := range history {
+		dtos[i] = s.ToVersionDTO()
+	}
+
+	h.sendJSON(w, http.StatusOK, dtos)
+}
@@ -3,6 +3,7 @@ package api_test
 import (
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/joaopsramos/fincon/internal/domain"
 	"github.com/joaopsramos/fincon/internal/testhelper"
@@ -123,3 +124,53 @@ func TestSalaryHandler_UpdateSalary(t *testing.T) {
 		})
 	}
 }
+
+func TestSalaryHandler_History(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	user := f.InsertUser()
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: user.ID})
+
+	now := time.Now().UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	nextMonth := monthStart.AddDate(0, 1, 0)
+
+	f.InsertSalary(&domain.Salary{Amount: 50000, EffectiveFrom: monthStart.AddDate(0, -1, 0), UserID: user.ID})
+
+	var respBody util.M
+
+	// a raise in the middle of next month applies from the start of it
+	resp := app.Test(http.MethodPatch, "/api/salary", util.M{"amount": 700, "effective_from": nextMonth.AddDate(0, 0, 14).Format(util.ApiDateLayout)})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(200, resp.StatusCode)
+	assert.Equal(util.M{"amount": 700.0}, respBody)
+
+	resp = app.Test(http.MethodPatch, "/api/salary", util.M{"amount": 600})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(200, resp.StatusCode)
+
+	// updating the same month again overwrites that version
+	resp = app.Test(http.MethodPatch, "/api/salary", util.M{"amount": 650})
+	assert.Equal(200, resp.StatusCode)
+
+	resp = app.Test(http.MethodPatch, "/api/salary", util.M{"amount": 650, "effective_from": "2025-13-01"})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(400, resp.StatusCode)
+	assert.Equal(util.M{"errors": util.M{"effective_from": []any{"time is invalid"}}}, respBody)
+
+	var history []util.M
+	resp = app.Test(http.MethodGet, "/api/salary/history")
+	app.UnmarshalBody(resp.Body, &history)
+	assert.Equal(200, resp.StatusCode)
+	assert.Equal([]util.M{
+		{"amount": 500.0, "effective_from": testhelper.DateToJsonString(monthStart.AddDate(0, -1, 0))},
+		{"amount": 650.0, "effective_from": testhelper.DateToJsonString(monthStart)},
+		{"amount": 700.0, "effective_from": testhelper.DateToJsonString(nextMonth)},
+	}, history)
+
+	resp = app.Test(http.MethodGet, "/api/salary")
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(util.M{"amount": 650.0}, respBody)
+}
@@ -0,0 +1,178 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	z "github.com/Oudwins/zog"
+	"github.com/go-chi/chi/v5"
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/service"
+	"github.com/joaopsramos/fincon/internal/util"
+)
+
+type SavingsTargetHandler struct {
+	*BaseHandler
+	savingsTargetService service.SavingsTargetService
+}
+
+var (
+	savingsTargetCreateSchema = z.Struct(z.Schema{
+		"name":         z.String().Trim().Min(2, z.Message("name must contain at least 2 characters")).Required(),
+		"targetAmount": z.Float().GTE(0.01, z.Message("target amount must be greater than or equal to 0.01")).Required(),
+		"startDate":    z.Time(z.Time.Format(util.ApiDateLayout)).Optional(),
+		"deadline":     z.Time(z.Time.Format(util.ApiDateLayout)).Required(),
+		"goalID":       z.Int().Required(),
+	})
+
+	savingsTargetUpdateSchema = z.Struct(z.Schema{
+		"name":         z.String().Trim().Min(2, z.Message("name must contain at least 2 characters")).Optional(),
+		"targetAmount": z.Float().GTE(0.01, z.Message("target amount must be greater than or equal to 0.01")).Optional(),
+		"startDate":    z.Time(z.Time.Format(util.ApiDateLayout)).Optional(),
+		"deadline":     z.Time(z.Time.Format(util.ApiDateLayout)).Optional(),
+		"goalID":       z.Int().Optional(),
+	})
+)
+
+func NewSavingsTargetHandler(baseHandler *BaseHandler, savingsTargetService service.SavingsTargetService) *SavingsTargetHandler {
+	return &SavingsTargetHandler{
+		BaseHandler:          baseHandler,
+		savingsTargetService: savingsTargetService,
+	}
+}
+
+func (h *SavingsTargetHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/savings-targets", h.Index)
+	r.Post("/savings-targets", h.Create)
+	r.Get("/savings-targets/{id}", h.Show)
+	r.Get("/savings-targets/{id}/progress", h.Progress)
+	r.Patch("/savings-targets/{id}", h.Update)
+	r.Delete("/savings-targets/{id}", h.Delete)
+}
+
+func (h *SavingsTargetHandler) Index(w http.ResponseWriter, r *http.Request) {
+	targets, err := h.savingsTargetService.All(r.Context(), h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	dtos := util.Map(targets, func(t domain.SavingsTarget) domain.SavingsTargetDTO { return t.ToDTO() })
+	h.sendJSON(w, http.StatusOK, dtos)
+}
+
+func (h *SavingsTargetHandler) Show(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid savings target id")
+		return
+	}
+
+	target, err := h.savingsTargetService.Get(r.Context(), uint(id), h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, target.ToDTO())
+}
+
+func (h *SavingsTargetHandler) Progress(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid savings target id")
+		return
+	}
+
+	target, progress, err := h.savingsTargetService.Progress(r.Context(), uint(id), time.Now(), h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, target.ToProgressDTO(progress))
+}
+
+func (h *SavingsTargetHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Name         string
+		TargetAmount float64   `zog:"target_amount"`
+		StartDate    time.Time `zog:"start_date"`
+		Deadline     time.Time
+		GoalID       int `zog:"goal_id"`
+	}
+
+	if errs := util.ParseZodSchema(savingsTargetCreateSchema, r.Body, &params); errs != nil {
+		h.HandleZodError(w, errs)
+		return
+	}
+
+	dto := service.CreateSavingsTargetDTO{
+		Name:         params.Name,
+		TargetAmount: params.TargetAmount,
+		StartDate:    params.StartDate,
+		Deadline:     params.Deadline,
+		GoalID:       params.GoalID,
+	}
+
+	target, err := h.savingsTargetService.Create(r.Context(), dto, h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusCreated, target.ToDTO())
+}
+
+func (h *SavingsTargetHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid savings target id")
+		return
+	}
+
+	var params struct {
+		Name         string
+		TargetAmount float64   `zog:"target_amount"`
+		StartDate    time.Time `zog:"start_date"`
+		Deadline     time.Time
+		GoalID       int `zog:"goal_id"`
+	}
+
+	if errs := util.ParseZodSchema(savingsTargetUpdateSchema, r.Body, &params); errs != nil {
+		h.HandleZodError(w, errs)
+		return
+	}
+
+	dto := service.UpdateSavingsTargetDTO{
+		Name:         params.Name,
+		TargetAmount: params.TargetAmount,
+		StartDate:    params.StartDate,
+		Deadline:     params.Deadline,
+		GoalID:       params.GoalID,
+	}
+
+	target, err := h.savingsTargetService.UpdateByID(r.Context(), uint(id), dto, h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, target.ToDTO())
+}
+
+func (h *SavingsTargetHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid savings target id")
+		return
+	}
+
+	if err := h.savingsTargetService.Delete(r.Context(), uint(id), h.getUserIDFromCtx(r)); err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,201 @@
+package api_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/testhelper"
+	"github.com/joaopsramos/fincon/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSavingsTargetHandler_Create(t *testing.T) {
+	t.Parallel()
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	user := f.InsertUser()
+	goal := f.InsertGoal(&domain.Goal{UserID: user.ID})
+	anotherUserGoal := f.InsertGoal(&domain.Goal{UserID: f.InsertUser().ID})
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: user.ID})
+
+	tests := []struct {
+		name   string
+		body   util.M
+		status int
+		want   util.M
+	}{
+		{
+			"ensure required fields",
+			util.M{},
+			400,
+			util.M{"errors": util.M{
+				"name":          []any{"is required"},
+				"target_amount": []any{"is required"},
+				"deadline":      []any{"is required"},
+				"goal_id":       []any{"is required"},
+			}},
+		},
+		{
+			"invalid values",
+			util.M{"name": "T", "target_amount": 0, "deadline": "2025-12-31", "goal_id": goal.ID},
+			400,
+			util.M{"errors": util.M{
+				"name":          []any{"name must contain at least 2 characters"},
+				"target_amount": []any{"target amount must be greater than or equal to 0.01"},
+			}},
+		},
+		{
+			"deadline before start date",
+			util.M{"name": "Trip", "target_amount": 5000, "start_date": "2025-06-01", "deadline": "2025-05-31", "goal_id": goal.ID},
+			400,
+			util.M{"error": "deadline must not be before start date"},
+		},
+		{
+			"goal of another user",
+			util.M{"name": "Trip", "target_amount": 5000, "deadline": "2099-12-31", "goal_id": anotherUserGoal.ID},
+			404,
+			util.M{"error": "goal not found"},
+		},
+		{
+			"valid target",
+			util.M{"name": "Trip", "target_amount": 5000.5, "start_date": "2025-01-01", "deadline": "2025-12-31", "goal_id": goal.ID},
+			201,
+			util.M{
+				"name":          "Trip",
+				"target_amount": 5000.5,
+				"start_date":    "2025-01-01T00:00:00Z",
+				"deadline":      "2025-12-31T00:00:00Z",
+				"goal_id":       float64(goal.ID),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			var respBody util.M
+
+			resp := app.Test(http.MethodPost, "/api/savings-targets", tt.body)
+			app.UnmarshalBody(resp.Body, &respBody)
+
+			a.Equal(tt.status, resp.StatusCode)
+
+			if resp.StatusCode == http.StatusCreated {
+				a.NotZero(respBody["id"])
+				delete(respBody, "id")
+			}
+
+			a.Equal(tt.want, respBody)
+		})
+	}
+}
+
+func TestSavingsTargetHandler_UpdateAndDelete(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	user := f.InsertUser()
+	goal := f.InsertGoal(&domain.Goal{UserID: user.ID})
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: user.ID})
+	anotherUserApp := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: f.InsertUser().ID})
+
+	var respBody util.M
+
+	resp := app.Test(http.MethodPost, "/api/savings-targets", util.M{
+		"name": "Trip", "target_amount": 5000, "start_date": "2025-01-01", "deadline": "2025-12-31", "goal_id": goal.ID,
+	})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(201, resp.StatusCode)
+	path := fmt.Sprintf("/api/savings-targets/%v", respBody["id"])
+	id := respBody["id"]
+
+	resp = anotherUserApp.Test(http.MethodPatch, path, util.M{"target_amount": 6000})
+	anotherUserApp.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(404, resp.StatusCode)
+	assert.Equal(util.M{"error": "savings target not found"}, respBody)
+
+	resp = app.Test(http.MethodPatch, path, util.M{"target_amount": 6000, "deadline": "2026-06-30"})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(200, resp.StatusCode)
+	assert.Equal(util.M{
+		"id":            id,
+		"name":          "Trip",
+		"target_amount": 6000.0,
+		"start_date":    "2025-01-01T00:00:00Z",
+		"deadline":      "2026-06-30T00:00:00Z",
+		"goal_id":       float64(goal.ID),
+	}, respBody)
+
+	resp = app.Test(http.MethodPatch, path, util.M{"deadline": "2024-12-31"})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(400, resp.StatusCode)
+	assert.Equal(util.M{"error": "deadline must not be before start date"}, respBody)
+
+	var listBody []util.M
+	resp = app.Test(http.MethodGet, "/api/savings-targets")
+	app.UnmarshalBody(resp.Body, &listBody)
+	assert.Equal(200, resp.StatusCode)
+	assert.Len(listBody, 1)
+
+	resp = app.Test(http.MethodDelete, path)
+	assert.Equal(204, resp.StatusCode)
+
+	resp = app.Test(http.MethodGet, path)
+	assert.Equal(404, resp.StatusCode)
+}
+
+func TestSavingsTargetHandler_Progress(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	user := f.InsertUser()
+	goal := f.InsertGoal(&domain.Goal{UserID: user.ID})
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: user.ID})
+
+	now := time.Now().UTC()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	deadline := start.AddDate(0, 10, -1)
+
+	var respBody util.M
+
+	resp := app.Test(http.MethodPost, "/api/savings-targets", util.M{
+		"name":          "Emergency fund",
+		"target_amount": 1000,
+		"start_date":    start.Format(util.ApiDateLayout),
+		"deadline":      deadline.Format(util.ApiDateLayout),
+		"goal_id":       goal.ID,
+	})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(201, resp.StatusCode)
+	path := fmt.Sprintf("/api/savings-targets/%v/progress", respBody["id"])
+
+	f.InsertExpense(&domain.Expense{Value: 250_00, Date: start, GoalID: goal.ID, UserID: user.ID})
+	// Future installment, not saved yet
+	f.InsertExpense(&domain.Expense{Value: 250_00, Date: start.AddDate(0, 1, 0), GoalID: goal.ID, UserID: user.ID})
+
+	resp = app.Test(http.MethodGet, path)
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(200, resp.StatusCode)
+
+	assert.NotNil(respBody["projected_completion"])
+	delete(respBody, "projected_completion")
+	delete(respBody, "target")
+
+	assert.Equal(util.M{
+		"saved":            250.0,
+		"remaining":        750.0,
+		"percentage":       25.0,
+		"completed":        false,
+		"monthly_average":  250.0,
+		"required_monthly": 75.0,
+		"on_track":         true,
+	}, respBody)
+
+	resp = app.Test(http.MethodGet, "/api/savings-targets/999999/progress")
+	assert.Equal(404, resp.StatusCode)
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	z "github.com/Oudwins/zog"
+	"github.com/go-chi/chi/v5"
+	"github.com/joaopsramos/fincon/internal/errs"
+	"github.com/joaopsramos/fincon/internal/service"
+	"github.com/joaopsramos/fincon/internal/util"
+)
+
+type SessionHandler struct {
+	*BaseHandler
+	sessionService   service.SessionService
+	twoFactorService service.TwoFactorService
+}
+
+var (
+	sessionRefreshSchema = z.Struct(z.Schema{
+		"refreshToken": z.String().Trim().Required(),
+	})
+
+	sessionTwoFactorSchema = z.Struct(z.Schema{
+		"challengeToken": z.String().Trim().Required(),
+		"code":           z.String().Trim().Required(),
+	})
+)
+
+func NewSessionHandler(baseHandler *BaseHandler, sessionService service.SessionService, twoFactorService service.TwoFactorService) *SessionHandler {
+	return &SessionHandler{
+		BaseHandler:      baseHandler,
+		sessionService:   sessionService,
+		twoFactorService: twoFactorService,
+	}
+}
+
+func (h *SessionHandler) RegisterPublicRoutes(r chi.Router) {
+	r.With(h.rateLimiter(30, 5*time.Minute)).Post("/sessions/refresh", h.Refresh)
+	r.With(h.rateLimiter(10, 5*time.Minute)).Post("/sessions/2fa", h.CreateWithTwoFactor)
+}
+
+func (h *SessionHandler) RegisterRoutes(r chi.Router) {
+	r.Delete("/sessions", h.Delete)
+	r.Delete("/sessions/all", h.DeleteAll)
+}
+
+func (h *SessionHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		RefreshToken string `zog:"refresh_token"`
+	}
+
+	if errs := util.ParseZodSchema(sessionRefreshSchema, r.Body, &params); errs != nil {
+		h.HandleZodError(w, errs)
+		return
+	}
+
+	tokens, err := h.sessionService.Refresh(r.Context(), params.RefreshToken)
+	if errors.Is(err, errs.ErrInvalidToken) {
+		h.sendError(w, http.StatusUnauthorized, "invalid or expired refresh token")
+		return
+	} else if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, tokens)
+}
+
+// CreateWithTwoFactor completes the login of users with two-factor authentication enabled,
+// exchanging the challenge token returned by the password step for a session
+func (h *SessionHandler) CreateWithTwoFactor(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		ChallengeToken string `zog:"challenge_token"`
+		Code           string `zog:"code"`
+	}
+
+	if errs := util.ParseZodSchema(sessionTwoFactorSchema, r.Body, &params); errs != nil {
+		h.HandleZodError(w, errs)
+		return
+	}
+
+	userID, err := h.twoFactorService.VerifyChallenge(r.Context(), params.ChallengeToken, params.Code)
+	if errors.Is(err, errs.ErrInvalidToken) {
+		h.sendError(w, http.StatusUnauthorized, "invalid or expired challenge")
+		return
+	} else if errors.Is(err, errs.ErrInvalidCode) {
+		h.sendError(w, http.StatusUnauthorized, err.Error())
+		return
+	} else if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	tokens, err := h.sessionService.Create(r.Context(), userID, r.UserAgent())
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusCreated, tokens)
+}
+
+// Delete logs out of the current session
+func (h *SessionHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := h.sessionService.Revoke(r.Context(), h.getSessionIDFromCtx(r), h.getUserIDFromCtx(r)); err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteAll logs out of every device, including the current one
+func (h *SessionHandler) DeleteAll(w http.ResponseWriter, r *http.Request) {
+	if err := h.sessionService.RevokeAll(r.Context(), h.getUserIDFromCtx(r)); err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,137 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/joaopsramos/fincon/internal/testhelper"
+	"github.com/joaopsramos/fincon/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSessionHandler_Refresh(t *testing.T) {
+	t.Parallel()
+	tx := testhelper.NewTestPostgresTx(t)
+	mailer := testhelper.NewMockMailer(t)
+	mailer.EXPECT().Send(mock.Anything, mock.AnythingOfType("mail.Email")).Return(nil).Once()
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{Mailer: mailer})
+
+	var signup util.M
+	resp := app.Test(http.MethodPost, "/api/users", util.M{"email": "test@example.com", "password": "password123", "salary": 5000.00})
+	app.UnmarshalBody(resp.Body, &signup)
+	assert.Equal(t, 201, resp.StatusCode)
+
+	t.Run("missing refresh token", func(t *testing.T) {
+		a := assert.New(t)
+		var respBody util.M
+
+		resp := app.Test(http.MethodPost, "/api/sessions/refresh", util.M{})
+		app.UnmarshalBody(resp.Body, &respBody)
+
+		a.Equal(400, resp.StatusCode)
+		a.Equal(util.M{"errors": util.M{"refresh_token": []any{"is required"}}}, respBody)
+	})
+
+	t.Run("unknown refresh token", func(t *testing.T) {
+		a := assert.New(t)
+		var respBody util.M
+
+		resp := app.Test(http.MethodPost, "/api/sessions/refresh", util.M{"refresh_token": "unknown"})
+		app.UnmarshalBody(resp.Body, &respBody)
+
+		a.Equal(401, resp.StatusCode)
+		a.Equal(util.M{"error": "invalid or expired refresh token"}, respBody)
+	})
+
+	t.Run("rotates the refresh token and revokes the session on reuse", func(t *testing.T) {
+		a := assert.New(t)
+		var refreshed util.M
+
+		resp := app.Test(http.MethodPost, "/api/sessions/refresh", util.M{"refresh_token": signup["refresh_token"]})
+		app.UnmarshalBody(resp.Body, &refreshed)
+
+		a.Equal(200, resp.StatusCode)
+		a.NotEmpty(refreshed["token"])
+		a.NotEqual(signup["refresh_token"], refreshed["refresh_token"])
+		a.Equal(200, requestWithToken(app, refreshed["token"].(string)).StatusCode)
+
+		// presenting an already rotated token means it was leaked
+		var respBody util.M
+		resp = app.Test(http.MethodPost, "/api/sessions/refresh", util.M{"refresh_token": signup["refresh_token"]})
+		app.UnmarshalBody(resp.Body, &respBody)
+
+		a.Equal(401, resp.StatusCode)
+		a.Equal(util.M{"error": "invalid or expired refresh token"}, respBody)
+
+		resp = app.Test(http.MethodPost, "/api/sessions/refresh", util.M{"refresh_token": refreshed["refresh_token"]})
+		a.Equal(401, resp.StatusCode)
+		a.Equal(401, requestWithToken(app, refreshed["token"].(string)).StatusCode)
+	})
+}
+
+func TestSessionHandler_Delete(t *testing.T) {
+	t.Parallel()
+	tx := testhelper.NewTestPostgresTx(t)
+	mailer := testhelper.NewMockMailer(t)
+	mailer.EXPECT().Send(mock.Anything, mock.AnythingOfType("mail.Email")).Return(nil).Once()
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{Mailer: mailer})
+
+	resp := app.Test(http.MethodPost, "/api/users", util.M{"email": "test@example.com", "password": "password123", "salary": 5000.00})
+	assert.Equal(t, 201, resp.StatusCode)
+
+	login := func() util.M {
+		var tokens util.M
+		resp := app.Test(http.MethodPost, "/api/sessions", util.M{"email": "test@example.com", "password": "password123"})
+		app.UnmarshalBody(resp.Body, &tokens)
+		return tokens
+	}
+
+	t.Run("logs out of the current session only", func(t *testing.T) {
+		a := assert.New(t)
+		current, other := login(), login()
+
+		resp := requestWithToken(app, current["token"].(string), http.MethodDelete, "/api/sessions")
+		a.Equal(204, resp.StatusCode)
+
+		var respBody util.M
+		resp = requestWithToken(app, current["token"].(string))
+		app.UnmarshalBody(resp.Body, &respBody)
+		a.Equal(401, resp.StatusCode)
+		a.Equal(util.M{"error": "session expired or revoked"}, respBody)
+
+		resp = app.Test(http.MethodPost, "/api/sessions/refresh", util.M{"refresh_token": current["refresh_token"]})
+		a.Equal(401, resp.StatusCode)
+
+		a.Equal(200, requestWithToken(app, other["token"].(string)).StatusCode)
+	})
+
+	t.Run("logs out of all sessions", func(t *testing.T) {
+		a := assert.New(t)
+		current, other := login(), login()
+
+		resp := requestWithToken(app, current["token"].(string), http.MethodDelete, "/api/sessions/all")
+		a.Equal(204, resp.StatusCode)
+
+		a.Equal(401, requestWithToken(app, current["token"].(string)).StatusCode)
+		a.Equal(401, requestWithToken(app, other["token"].(string)).StatusCode)
+	})
+}
+
+// requestWithToken sends a request authenticated with the given access token,
+// defaulting to GET /api/salary
+func requestWithToken(app *testhelper.TestApp, token string, methodAndPath ...string) *http.Response {
+	method, path := http.MethodGet, "/api/salary"
+	if len(methodAndPath) == 2 {
+		method, path = methodAndPath[0], methodAndPath[1]
+	}
+
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	w := httptest.NewRecorder()
+	app.Router.ServeHTTP(w, req)
+
+	return w.Result()
+}
@@ -0,0 +1,138 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	z "github.com/Oudwins/zog"
+	"github.com/go-chi/chi/v5"
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/service"
+	"github.com/joaopsramos/fincon/internal/util"
+)
+
+type TagHandler struct {
+	*BaseHandler
+	tagService service.TagService
+}
+
+var (
+	tagSchema = z.Struct(z.Schema{
+		"name": z.String().Trim().Min(1, z.Message("name must not be empty")).Max(50, z.Message("name must contain at most 50 characters")).Required(),
+	})
+
+	tagMergeSchema = z.Struct(z.Schema{
+		"targetID": z.Int().Required(),
+	})
+)
+
+func NewTagHandler(baseHandler *BaseHandler, tagService service.TagService) *TagHandler {
+	return &TagHandler{
+		BaseHandler: baseHandler,
+		tagService:  tagService,
+	}
+}
+
+func (h *TagHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/tags", h.Index)
+	r.Post("/tags", h.Create)
+	r.Patch("/tags/{id}", h.Update)
+	r.Delete("/tags/{id}", h.Delete)
+	r.Post("/tags/{id}/merge", h.Merge)
+}
+
+func (h *TagHandler) Index(w http.ResponseWriter, r *http.Request) {
+	tags, err := h.tagService.All(r.Context(), h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	dtos := util.Map(tags, func(t domain.Tag) domain.TagDTO { return t.ToDTO() })
+	h.sendJSON(w, http.StatusOK, dtos)
+}
+
+func (h *TagHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Name string
+	}
+
+	if errs := util.ParseZodSchema(tagSchema, r.Body, &params); errs != nil {
+		h.HandleZodError(w, errs)
+		return
+	}
+
+	tag, err := h.tagService.Create(r.Context(), params.Name, h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusCreated, tag.ToDTO())
+}
+
+func (h *TagHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid tag id")
+		return
+	}
+
+	var params struct {
+		Name string
+	}
+
+	if errs := util.ParseZodSchema(tagSchema, r.Body, &params); errs != nil {
+		h.HandleZodError(w, errs)
+		return
+	}
+
+	tag, err := h.tagService.Rename(r.Context(), uint(id), params.Name, h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, tag.ToDTO())
+}
+
+func (h *TagHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid tag id")
+		return
+	}
+
+	if err := h.tagService.Delete(r.Context(), uint(id), h.getUserIDFromCtx(r)); err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Merge moves the expenses of the tag to the one in "target_id" and deletes it
+func (h *TagHandler) Merge(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		h.sendError(w, http.StatusBadRequest, "invalid tag id")
+		return
+	}
+
+	var params struct {
+		TargetID int `zog:"target_id"`
+	}
+
+	if errs := util.ParseZodSchema(tagMergeSchema, r.Body, &params); errs != nil {
+		h.HandleZodError(w, errs)
+		return
+	}
+
+	tag, err := h.tagService.Merge(r.Context(), uint(id), uint(params.TargetID), h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, tag.ToDTO())
+}
@@ -0,0 +1,130 @@
+package api_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/testhelper"
+	"github.com/joaopsramos/fincon/internal/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagHandler_CRUD(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	user := f.InsertUser()
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: user.ID})
+	anotherUserApp := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: f.InsertUser().ID})
+
+	car := f.InsertTag(&domain.Tag{Name: "Car", UserID: user.ID})
+
+	var respBody util.M
+
+	resp := app.Test(http.MethodPost, "/api/tags", util.M{"name": " "})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(400, resp.StatusCode)
+	assert.Equal(util.M{"errors": util.M{"name": []any{"name must not be empty"}}}, respBody)
+
+	resp = app.Test(http.MethodPost, "/api/tags", util.M{"name": "car"})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(400, resp.StatusCode)
+	assert.Equal(util.M{"error": "a tag with this name already exists"}, respBody)
+
+	resp = app.Test(http.MethodPost, "/api/tags", util.M{"name": " Trip "})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(201, resp.StatusCode)
+	assert.Equal("Trip", respBody["name"])
+	tripID := respBody["id"]
+
+	// other users can have tags with the same name
+	resp = anotherUserApp.Test(http.MethodPost, "/api/tags", util.M{"name": "Car"})
+	assert.Equal(201, resp.StatusCode)
+
+	var tags []util.M
+	resp = app.Test(http.MethodGet, "/api/tags")
+	app.UnmarshalBody(resp.Body, &tags)
+	assert.Equal(200, resp.StatusCode)
+	assert.Equal([]util.M{{"id": float64(car.ID), "name": "Car"}, {"id": tripID, "name": "Trip"}}, tags)
+
+	path := fmt.Sprintf("/api/tags/%d", car.ID)
+
+	resp = anotherUserApp.Test(http.MethodPatch, path, util.M{"name": "Vehicle"})
+	anotherUserApp.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(404, resp.StatusCode)
+	assert.Equal(util.M{"error": "tag not found"}, respBody)
+
+	resp = app.Test(http.MethodPatch, path, util.M{"name": "trip"})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(400, resp.StatusCode)
+	assert.Equal(util.M{"error": "a tag with this name already exists"}, respBody)
+
+	resp = app.Test(http.MethodPatch, path, util.M{"name": "Vehicle"})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(200, resp.StatusCode)
+	assert.Equal(util.M{"id": float64(car.ID), "name": "Vehicle"}, respBody)
+
+	resp = anotherUserApp.Test(http.MethodDelete, path)
+	assert.Equal(404, resp.StatusCode)
+
+	resp = app.Test(http.MethodDelete, path)
+	assert.Equal(204, resp.StatusCode)
+
+	resp = app.Test(http.MethodGet, "/api/tags")
+	app.UnmarshalBody(resp.Body, &tags)
+	assert.Equal([]util.M{{"id": tripID, "name": "Trip"}}, tags)
+}
+
+func TestTagHandler_Merge(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	user := f.InsertUser()
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: user.ID})
+
+	goal := f.InsertGoal(&domain.Goal{Name: "Comfort", UserID: user.ID})
+	car := f.InsertTag(&domain.Tag{Name: "Car", UserID: user.ID})
+	vehicle := f.InsertTag(&domain.Tag{Name: "Vehicle", UserID: user.ID})
+	otherTag := f.InsertTag(&domain.Tag{Name: "Other", UserID: f.InsertUser().ID})
+
+	date := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	fuel := f.InsertExpense(&domain.Expense{Name: "Fuel", Date: date, GoalID: goal.ID, UserID: user.ID, Tags: []domain.Tag{car}})
+	f.InsertExpense(&domain.Expense{Name: "Tires", Date: date, GoalID: goal.ID, UserID: user.ID, Tags: []domain.Tag{car, vehicle}})
+
+	path := fmt.Sprintf("/api/tags/%d/merge", car.ID)
+
+	var respBody util.M
+
+	resp := app.Test(http.MethodPost, path, util.M{"target_id": car.ID})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(400, resp.StatusCode)
+	assert.Equal(util.M{"error": "a tag cannot be merged into itself"}, respBody)
+
+	resp = app.Test(http.MethodPost, path, util.M{"target_id": otherTag.ID})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(404, resp.StatusCode)
+	assert.Equal(util.M{"error": "tag not found"}, respBody)
+
+	resp = app.Test(http.MethodPost, path, util.M{"target_id": vehicle.ID})
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(200, resp.StatusCode)
+	assert.Equal(util.M{"id": float64(vehicle.ID), "name": "Vehicle"}, respBody)
+
+	resp = app.Test(http.MethodGet, fmt.Sprintf("/api/expenses?tag_ids=%d&sort=date_asc", vehicle.ID))
+	app.UnmarshalBody(resp.Body, &respBody)
+	assert.Equal(200, resp.StatusCode)
+
+	data := respBody["data"].([]any)
+	assert.Len(data, 2)
+	assert.Equal(float64(fuel.ID), data[0].(util.M)["id"])
+	assert.Equal([]any{util.M{"id": float64(vehicle.ID), "name": "Vehicle"}}, data[0].(util.M)["tags"])
+
+	var count int64
+	tx.Model(&domain.Tag{}).Where("id = ?", car.ID).Count(&count)
+	assert.Zero(count)
+}
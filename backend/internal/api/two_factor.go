@@ -0,0 +1,100 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	z "github.com/Oudwins/zog"
+	"github.com/go-chi/chi/v5"
+	"github.com/joaopsramos/fincon/internal/errs"
+	"github.com/joaopsramos/fincon/internal/service"
+	"github.com/joaopsramos/fincon/internal/util"
+)
+
+type TwoFactorHandler struct {
+	*BaseHandler
+	twoFactorService service.TwoFactorService
+}
+
+var twoFactorCodeSchema = z.Struct(z.Schema{
+	"code": z.String().Trim().Required(),
+})
+
+func NewTwoFactorHandler(baseHandler *BaseHandler, twoFactorService service.TwoFactorService) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		BaseHandler:      baseHandler,
+		twoFactorService: twoFactorService,
+	}
+}
+
+func (h *TwoFactorHandler) RegisterRoutes(r chi.Router) {
+	r.Get("/2fa", h.Status)
+	r.Post("/2fa/enroll", h.Enroll)
+	r.With(h.rateLimiter(10, 5*time.Minute)).Post("/2fa/confirm", h.Confirm)
+	r.With(h.rateLimiter(10, 5*time.Minute)).Post("/2fa/disable", h.Disable)
+}
+
+func (h *TwoFactorHandler) Status(w http.ResponseWriter, r *http.Request) {
+	status, err := h.twoFactorService.Status(r.Context(), h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, status)
+}
+
+func (h *TwoFactorHandler) Enroll(w http.ResponseWriter, r *http.Request) {
+	enrollment, err := h.twoFactorService.Enroll(r.Context(), h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusCreated, enrollment)
+}
+
+func (h *TwoFactorHandler) Confirm(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Code string `json:"code"`
+	}
+
+	if errs := util.ParseZodSchema(twoFactorCodeSchema, r.Body, &params); errs != nil {
+		h.HandleZodError(w, errs)
+		return
+	}
+
+	codes, err := h.twoFactorService.Confirm(r.Context(), h.getUserIDFromCtx(r), params.Code)
+	if errors.Is(err, errs.ErrInvalidCode) {
+		h.sendError(w, http.StatusBadRequest, err.Error())
+		return
+	} else if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, util.M{"recovery_codes": codes})
+}
+
+func (h *TwoFactorHandler) Disable(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Code string `json:"code"`
+	}
+
+	if errs := util.ParseZodSchema(twoFactorCodeSchema, r.Body, &params); errs != nil {
+		h.HandleZodError(w, errs)
+		return
+	}
+
+	err := h.twoFactorService.Disable(r.Context(), h.getUserIDFromCtx(r), params.Code)
+	if errors.Is(err, errs.ErrInvalidCode) {
+		h.sendError(w, http.StatusBadRequest, err.Error())
+		return
+	} else if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
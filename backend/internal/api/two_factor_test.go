@@ -0,0 +1,165 @@
+package api_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/joaopsramos/fincon/internal/auth"
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/testhelper"
+	"github.com/joaopsramos/fincon/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func totpCode(t *testing.T, secret string, now time.Time, stepOffset int64) string {
+	code, err := auth.TOTPCode(secret, auth.TOTPStep(now)+stepOffset)
+	require.NoError(t, err)
+	return code
+}
+
+func TestTwoFactorHandler(t *testing.T) {
+	t.Parallel()
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+
+	password, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	user := f.InsertUser(&domain.User{Email: "2fa@example.com", HashPassword: string(password)})
+	f.InsertSalary(&domain.Salary{UserID: user.ID})
+
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: user.ID})
+	a := assert.New(t)
+
+	// the middle of a step, so codes don't depend on the wall clock or a step boundary
+	now := time.Date(2025, 1, 5, 12, 0, 15, 0, time.UTC)
+	app.SetClock(func() time.Time { return now })
+
+	var secret string
+	var recoveryCodes []any
+
+	t.Run("confirm before enrolling", func(t *testing.T) {
+		var respBody util.M
+		resp := app.Test(http.MethodPost, "/api/2fa/confirm", util.M{"code": "123456"})
+		app.UnmarshalBody(resp.Body, &respBody)
+
+		a.Equal(400, resp.StatusCode)
+		a.Equal(util.M{"error": "two-factor enrollment was not started"}, respBody)
+	})
+
+	t.Run("enroll", func(t *testing.T) {
+		var respBody util.M
+		resp := app.Test(http.MethodPost, "/api/2fa/enroll")
+		app.UnmarshalBody(resp.Body, &respBody)
+
+		a.Equal(201, resp.StatusCode)
+		secret = respBody["secret"].(string)
+		a.NotEmpty(secret)
+		a.Equal(auth.TOTPURI(secret, user.Email), respBody["uri"])
+	})
+
+	t.Run("confirm with invalid code", func(t *testing.T) {
+		var respBody util.M
+		resp := app.Test(http.MethodPost, "/api/2fa/confirm", util.M{"code": totpCode(t, secret, now, -5)})
+		app.UnmarshalBody(resp.Body, &respBody)
+
+		a.Equal(400, resp.StatusCode)
+		a.Equal(util.M{"error": "invalid code"}, respBody)
+	})
+
+	t.Run("confirm", func(t *testing.T) {
+		var respBody util.M
+		resp := app.Test(http.MethodPost, "/api/2fa/confirm", util.M{"code": totpCode(t, secret, now, -1)})
+		app.UnmarshalBody(resp.Body, &respBody)
+
+		a.Equal(200, resp.StatusCode)
+		recoveryCodes = respBody["recovery_codes"].([]any)
+		a.Len(recoveryCodes, 10)
+
+		resp = app.Test(http.MethodGet, "/api/2fa")
+		app.UnmarshalBody(resp.Body, &respBody)
+		a.Equal(util.M{"enabled": true, "recovery_codes_left": 10.0}, respBody)
+
+		resp = app.Test(http.MethodPost, "/api/2fa/enroll")
+		a.Equal(400, resp.StatusCode)
+	})
+
+	login := func() string {
+		var respBody util.M
+		resp := app.Test(http.MethodPost, "/api/sessions", util.M{"email": user.Email, "password": "password123"})
+		app.UnmarshalBody(resp.Body, &respBody)
+
+		a.Equal(200, resp.StatusCode)
+		a.Equal(true, respBody["two_factor_required"])
+		a.Nil(respBody["token"])
+
+		return respBody["challenge_token"].(string)
+	}
+
+	t.Run("challenge token is not an access token", func(t *testing.T) {
+		resp := requestWithToken(app, login())
+		a.Equal(401, resp.StatusCode)
+	})
+
+	t.Run("login with totp code", func(t *testing.T) {
+		challenge := login()
+		code := totpCode(t, secret, now, 0)
+
+		var respBody util.M
+		resp := app.Test(http.MethodPost, "/api/sessions/2fa", util.M{"challenge_token": challenge, "code": code})
+		app.UnmarshalBody(resp.Body, &respBody)
+
+		a.Equal(201, resp.StatusCode)
+		a.NotEmpty(respBody["refresh_token"])
+		a.Equal(200, requestWithToken(app, respBody["token"].(string)).StatusCode)
+
+		// codes can't be replayed
+		resp = app.Test(http.MethodPost, "/api/sessions/2fa", util.M{"challenge_token": challenge, "code": code})
+		app.UnmarshalBody(resp.Body, &respBody)
+
+		a.Equal(401, resp.StatusCode)
+		a.Equal(util.M{"error": "invalid code"}, respBody)
+	})
+
+	t.Run("login with recovery code", func(t *testing.T) {
+		challenge := login()
+		code := recoveryCodes[0].(string)
+
+		resp := app.Test(http.MethodPost, "/api/sessions/2fa", util.M{"challenge_token": challenge, "code": code})
+		a.Equal(201, resp.StatusCode)
+
+		resp = app.Test(http.MethodPost, "/api/sessions/2fa", util.M{"challenge_token": challenge, "code": code})
+		a.Equal(401, resp.StatusCode)
+
+		var respBody util.M
+		resp = app.Test(http.MethodGet, "/api/2fa")
+		app.UnmarshalBody(resp.Body, &respBody)
+		a.Equal(9.0, respBody["recovery_codes_left"])
+	})
+
+	t.Run("invalid challenge token", func(t *testing.T) {
+		var respBody util.M
+		resp := app.Test(http.MethodPost, "/api/sessions/2fa", util.M{"challenge_token": "invalid", "code": totpCode(t, secret, now, 1)})
+		app.UnmarshalBody(resp.Body, &respBody)
+
+		a.Equal(401, resp.StatusCode)
+		a.Equal(util.M{"error": "invalid or expired challenge"}, respBody)
+	})
+
+	t.Run("disable", func(t *testing.T) {
+		resp := app.Test(http.MethodPost, "/api/2fa/disable", util.M{"code": "000000"})
+		a.Equal(400, resp.StatusCode)
+
+		resp = app.Test(http.MethodPost, "/api/2fa/disable", util.M{"code": recoveryCodes[1]})
+		a.Equal(204, resp.StatusCode)
+
+		var respBody util.M
+		resp = app.Test(http.MethodGet, "/api/2fa")
+		app.UnmarshalBody(resp.Body, &respBody)
+		a.Equal(util.M{"enabled": false, "recovery_codes_left": 0.0}, respBody)
+
+		resp = app.Test(http.MethodPost, "/api/sessions", util.M{"email": user.Email, "password": "password123"})
+		a.Equal(201, resp.StatusCode)
+	})
+}
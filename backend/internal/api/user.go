@@ -17,13 +17,18 @@ import (
 type UserIDKeyType string
 
 var (
-	tokenExpiresIn               = time.Hour * 24 * 7
-	UserIDKey      UserIDKeyType = "user_id"
+	// UserIDKey holds the owner of the budget being accessed, which is the authenticated
+	// user unless the request targets a household
+	UserIDKey    UserIDKeyType = "user_id"
+	SessionIDKey UserIDKeyType = "session_id"
+	// MemberIDKey holds the authenticated user when acting on a household budget
+	MemberIDKey UserIDKeyType = "member_id"
 )
 
 type UserHandler struct {
 	*BaseHandler
-	userService service.UserService
+	userService    service.UserService
+	sessionService service.SessionService
 }
 
 var (
@@ -43,16 +48,35 @@ var (
 		"password": z.String().Trim().Required(),
 	})
 
+	userVerifySchema = z.Struct(z.Schema{
+		"token": z.String().Trim().Required(),
+	})
+
+	userChangePasswordSchema = z.Struct(z.Schema{
+		"currentPassword": z.String().Required(),
+		"newPassword":     passwordFieldSchema,
+	})
+
+	userChangeEmailSchema = z.Struct(z.Schema{
+		"email":    z.String().Trim().Max(160).Email(z.Message("must be valid")).Required(),
+		"password": z.String().Required(),
+	})
+
+	userDeleteSchema = z.Struct(z.Schema{
+		"password": z.String().Required(),
+	})
+
 	userResetPasswordSchema = z.Struct(z.Schema{
 		"token":    z.String().Trim().Required(),
 		"password": passwordFieldSchema,
 	})
 )
 
-func NewUserHandler(baseHandler *BaseHandler, userService service.UserService) *UserHandler {
+func NewUserHandler(baseHandler *BaseHandler, userService service.UserService, sessionService service.SessionService) *UserHandler {
 	return &UserHandler{
-		BaseHandler: baseHandler,
-		userService: userService,
+		BaseHandler:    baseHandler,
+		userService:    userService,
+		sessionService: sessionService,
 	}
 }
 
@@ -61,6 +85,16 @@ func (h *UserHandler) RegisterRoutes(r chi.Router) {
 	r.With(h.rateLimiter(10, 5*time.Minute)).Post("/sessions", h.UserLogin)
 	r.With(h.rateLimiter(5, time.Hour)).Post("/password/forgot", h.ForgotPassword)
 	r.With(h.rateLimiter(5, time.Hour)).Post("/password/reset", h.ResetPassword)
+	r.With(h.rateLimiter(10, time.Hour)).Post("/users/verify", h.VerifyEmail)
+}
+
+// RegisterProtectedRoutes registers the routes unverified users must always be able to reach
+func (h *UserHandler) RegisterProtectedRoutes(r chi.Router) {
+	r.With(h.rateLimiter(5, time.Hour)).Post("/users/verify/resend", h.ResendVerificationEmail)
+	r.Get("/me", h.Me)
+	r.With(h.rateLimiter(10, time.Hour)).Patch("/me/password", h.ChangePassword)
+	r.With(h.rateLimiter(10, time.Hour)).Patch("/me/email", h.ChangeEmail)
+	r.With(h.rateLimiter(5, time.Hour)).Delete("/me", h.Delete)
 }
 
 func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
@@ -89,16 +123,24 @@ func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
 		CreateSalaryDTO: service.CreateSalaryDTO{Amount: params.Salary},
 	}
 
-	user, salary, err := h.userService.Create(r.Context(), dto)
+	user, salary, err := h.userService.Register(r.Context(), dto)
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	tokens, err := h.sessionService.Create(r.Context(), user.ID, r.UserAgent())
 	if err != nil {
 		h.HandleError(w, err)
 		return
 	}
 
 	h.sendJSON(w, http.StatusCreated, util.M{
-		"user":   user.ToDTO(),
-		"salary": salary.ToDTO(),
-		"token":  auth.GenerateJWTToken(user.ID, tokenExpiresIn),
+		"user":          user.ToDTO(),
+		"salary":        salary.ToDTO(),
+		"token":         tokens.AccessToken,
+		"refresh_token": tokens.RefreshToken,
+		"expires_in":    tokens.ExpiresIn,
 	})
 }
 
@@ -121,7 +163,22 @@ func (h *UserHandler) UserLogin(w http.ResponseWriter, r *http.Request) {
 		panic(err)
 	}
 
-	h.sendJSON(w, http.StatusCreated, util.M{"token": auth.GenerateJWTToken(user.ID, tokenExpiresIn)})
+	// The session is only created once a code is sent to POST /sessions/2fa
+	if user.TwoFactorEnabled() {
+		h.sendJSON(w, http.StatusOK, util.M{
+			"two_factor_required": true,
+			"challenge_token":     auth.GenerateChallengeToken(user.ID),
+		})
+		return
+	}
+
+	tokens, err := h.sessionService.Create(r.Context(), user.ID, r.UserAgent())
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusCreated, tokens)
 }
 
 func (h *UserHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
@@ -178,3 +235,140 @@ func (h *UserHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusOK)
 }
+
+func (h *UserHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Token string `json:"token"`
+	}
+
+	if errs := util.ParseZodSchema(userVerifySchema, r.Body, &params); errs != nil {
+		h.HandleZodError(w, errs)
+		return
+	}
+
+	err := h.userService.VerifyEmail(r.Context(), params.Token)
+	if errors.Is(err, errs.ErrNotFound{}) {
+		h.HandleError(w, errs.ErrInvalidToken)
+		return
+	} else if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *UserHandler) ResendVerificationEmail(w http.ResponseWriter, r *http.Request) {
+	user, err := h.userService.Get(r.Context(), h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	err = h.userService.SendVerificationEmail(r.Context(), *user)
+	if errors.Is(err, errs.ErrAlreadyVerified) {
+		h.sendError(w, http.StatusConflict, err.Error())
+		return
+	} else if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *UserHandler) Me(w http.ResponseWriter, r *http.Request) {
+	user, err := h.userService.Get(r.Context(), h.getUserIDFromCtx(r))
+	if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, user.ToDTO())
+}
+
+func (h *UserHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		CurrentPassword string `zog:"current_password"`
+		NewPassword     string `zog:"new_password"`
+	}
+
+	if errs := util.ParseZodSchema(userChangePasswordSchema, r.Body, &params); errs != nil {
+		h.HandleZodError(w, errs)
+		return
+	}
+
+	dto := service.ChangePasswordDTO{
+		CurrentPassword: params.CurrentPassword,
+		NewPassword:     params.NewPassword,
+		SessionID:       h.getSessionIDFromCtx(r),
+	}
+
+	err := h.userService.ChangePassword(r.Context(), h.getUserIDFromCtx(r), dto)
+	if errors.Is(err, errs.ErrInvalidCredentials) {
+		h.sendError(w, http.StatusBadRequest, "current password is incorrect")
+		return
+	} else if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *UserHandler) ChangeEmail(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Email    string `json:"email"`
+		Password string `json:"password"`
+	}
+
+	if errs := util.ParseZodSchema(userChangeEmailSchema, r.Body, &params); errs != nil {
+		h.HandleZodError(w, errs)
+		return
+	}
+
+	if _, err := h.userService.GetByEmail(r.Context(), params.Email); err == nil {
+		h.sendError(w, http.StatusConflict, "email already in use")
+		return
+	} else if !errors.Is(err, errs.ErrNotFound{}) {
+		h.HandleError(w, err)
+		return
+	}
+
+	user, err := h.userService.ChangeEmail(r.Context(), h.getUserIDFromCtx(r), params.Email, params.Password)
+	if errors.Is(err, errs.ErrInvalidCredentials) {
+		h.sendError(w, http.StatusBadRequest, "password is incorrect")
+		return
+	} else if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	h.sendJSON(w, http.StatusOK, user.ToDTO())
+}
+
+func (h *UserHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	var params struct {
+		Password string `json:"password"`
+	}
+
+	if errs := util.ParseZodSchema(userDeleteSchema, r.Body, &params); errs != nil {
+		h.HandleZodError(w, errs)
+		return
+	}
+
+	user, err := h.userService.Delete(r.Context(), h.getUserIDFromCtx(r), params.Password)
+	if errors.Is(err, errs.ErrInvalidCredentials) {
+		h.sendError(w, http.StatusBadRequest, "password is incorrect")
+		return
+	} else if err != nil {
+		h.HandleError(w, err)
+		return
+	}
+
+	if err := h.userService.SendAccountDeletedEmail(r.Context(), *user); err != nil && h.logger != nil {
+		h.logger.Error("Failed to send account deleted email", "error", err)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -1,13 +1,16 @@
 package api_test
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/joaopsramos/fincon/internal/config"
 	"github.com/joaopsramos/fincon/internal/domain"
 	"github.com/joaopsramos/fincon/internal/mail"
 	"github.com/joaopsramos/fincon/internal/testhelper"
@@ -15,6 +18,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
 )
 
 func TestUserHandler_CreateUser(t *testing.T) {
@@ -105,7 +109,17 @@ func TestUserHandler_CreateUser(t *testing.T) {
 	for _, d := range data {
 		t.Run(d.name, func(t *testing.T) {
 			a := assert.New(t)
-			app := testhelper.NewTestApp(t, tx)
+			mailer := testhelper.NewMockMailer(t)
+			app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{Mailer: mailer})
+
+			if d.expectedStatus == 201 {
+				mailer.EXPECT().Send(mock.Anything, mock.MatchedBy(func(e mail.Email) bool {
+					return e.To.Email == d.body["email"] &&
+						e.Subject == mail.VerifyEmailSubject &&
+						e.Template == "verify_email" &&
+						strings.Contains(e.Data["Link"].(string), "/email/verify?token=")
+				})).Return(nil).Once()
+			}
 
 			var respBody util.M
 
@@ -121,7 +135,10 @@ func TestUserHandler_CreateUser(t *testing.T) {
 			a.Equal(d.expectedBody["email"], respBody["email"])
 			a.Equal(d.expectedBody["salary"], respBody["salary"])
 			a.NotEmpty(respBody["user"].(util.M)["id"])
+			a.Equal(false, respBody["user"].(util.M)["verified"])
 			a.NotEmpty(respBody["token"])
+			a.NotEmpty(respBody["refresh_token"])
+			a.Equal(900.0, respBody["expires_in"])
 
 			// assert valid token without using helper function
 			req := httptest.NewRequest("GET", "/api/salary", nil)
@@ -139,7 +156,9 @@ func TestUserHandler_CreateUser(t *testing.T) {
 func TestUserHandler_UserLogin(t *testing.T) {
 	t.Parallel()
 	tx := testhelper.NewTestPostgresTx(t)
-	app := testhelper.NewTestApp(t, tx)
+	mailer := testhelper.NewMockMailer(t)
+	mailer.EXPECT().Send(mock.Anything, mock.AnythingOfType("mail.Email")).Return(nil).Once()
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{Mailer: mailer})
 
 	// Create a user first
 	resp := app.Test(http.MethodPost, "/api/users", util.M{
@@ -205,6 +224,8 @@ func TestUserHandler_UserLogin(t *testing.T) {
 
 			if d.expectedStatus == 201 {
 				assert.NotEmpty(respBody["token"])
+				assert.NotEmpty(respBody["refresh_token"])
+				assert.Equal(900.0, respBody["expires_in"])
 
 				// Verify the token works
 				app2 := testhelper.NewTestApp(t, tx)
@@ -245,7 +266,7 @@ func TestUserHandler_ForgotPassword(t *testing.T) {
 			expectedStatus: 200,
 			expectedBody:   nil,
 			setupMocks: func() {
-				mailer.EXPECT().Send(mock.AnythingOfType("mail.Email")).Return(nil).Once()
+				mailer.EXPECT().Send(mock.Anything, mock.AnythingOfType("mail.Email")).Return(nil).Once()
 			},
 		},
 		{
@@ -299,7 +320,7 @@ func TestUserHandler_ResetPassword(t *testing.T) {
 			password: "newPassword123",
 			setupToken: func(t *testing.T) string {
 				var token string
-				mailer.EXPECT().Send(mock.AnythingOfType("mail.Email")).Run(func(email mail.Email) {
+				mailer.EXPECT().Send(mock.Anything, mock.AnythingOfType("mail.Email")).Run(func(_ context.Context, email mail.Email) {
 					url, err := url.Parse(email.Data["Link"].(string))
 					require.NoError(t, err)
 
@@ -395,3 +416,287 @@ func TestUserHandler_ResetPassword(t *testing.T) {
 		})
 	}
 }
+
+func TestUserHandler_VerifyEmail(t *testing.T) {
+	t.Parallel()
+	tx := testhelper.NewTestPostgresTx(t)
+	app := testhelper.NewTestApp(t, tx)
+
+	factory := testhelper.NewFactory(tx)
+	user := factory.InsertUser()
+
+	tests := []struct {
+		name           string
+		token          func() string
+		expectedStatus int
+		expectedBody   util.M
+	}{
+		{
+			name:           "missing token",
+			token:          func() string { return "" },
+			expectedStatus: 400,
+			expectedBody:   util.M{"errors": util.M{"token": []any{"is required"}}},
+		},
+		{
+			name:           "unknown token",
+			token:          uuid.NewString,
+			expectedStatus: 400,
+			expectedBody:   util.M{"error": "invalid or expired token"},
+		},
+		{
+			name: "password reset token",
+			token: func() string {
+				return factory.InsertUserToken(&domain.UserToken{UserID: user.ID}).Token.String()
+			},
+			expectedStatus: 400,
+			expectedBody:   util.M{"error": "invalid or expired token"},
+		},
+		{
+			name: "expired token",
+			token: func() string {
+				return factory.InsertUserToken(&domain.UserToken{
+					UserID:    user.ID,
+					Purpose:   domain.EmailVerificationToken,
+					ExpiresAt: time.Now().UTC().Add(-time.Hour),
+				}).Token.String()
+			},
+			expectedStatus: 400,
+			expectedBody:   util.M{"error": "invalid or expired token"},
+		},
+		{
+			name: "verify successfully",
+			token: func() string {
+				return factory.InsertUserToken(&domain.UserToken{
+					UserID:  user.ID,
+					Purpose: domain.EmailVerificationToken,
+				}).Token.String()
+			},
+			expectedStatus: 200,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			var respBody util.M
+
+			resp := app.Test(http.MethodPost, "/api/users/verify", util.M{"token": tt.token()})
+			app.UnmarshalBody(resp.Body, &respBody)
+
+			a.Equal(tt.expectedStatus, resp.StatusCode)
+			a.Equal(tt.expectedBody, respBody)
+		})
+	}
+
+	var verified domain.User
+	tx.First(&verified, "id = ?", user.ID)
+	assert.True(t, verified.IsVerified())
+}
+
+func TestUserHandler_ResendVerificationEmail(t *testing.T) {
+	t.Parallel()
+	tx := testhelper.NewTestPostgresTx(t)
+	factory := testhelper.NewFactory(tx)
+
+	t.Run("send a new verification email", func(t *testing.T) {
+		a := assert.New(t)
+		user := factory.InsertUser()
+		mailer := testhelper.NewMockMailer(t)
+		app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{
+			UserID:             user.ID,
+			Mailer:             mailer,
+			VerificationPolicy: config.VerificationBlock,
+		})
+
+		mailer.EXPECT().Send(mock.Anything, mock.MatchedBy(func(e mail.Email) bool {
+			return e.To.Email == user.Email && e.Template == mail.VerifyEmailTemplate
+		})).Return(nil).Once()
+
+		resp := app.Test(http.MethodPost, "/api/users/verify/resend")
+		a.Equal(200, resp.StatusCode)
+	})
+
+	t.Run("already verified", func(t *testing.T) {
+		a := assert.New(t)
+		now := time.Now().UTC()
+		user := factory.InsertUser(&domain.User{VerifiedAt: &now})
+		app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: user.ID})
+
+		var respBody util.M
+		resp := app.Test(http.MethodPost, "/api/users/verify/resend")
+		app.UnmarshalBody(resp.Body, &respBody)
+
+		a.Equal(409, resp.StatusCode)
+		a.Equal(util.M{"error": "email already verified"}, respBody)
+	})
+}
+
+func TestUserHandler_Me(t *testing.T) {
+	t.Parallel()
+	tx := testhelper.NewTestPostgresTx(t)
+	user := testhelper.NewFactory(tx).InsertUser()
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: user.ID, VerificationPolicy: config.VerificationBlock})
+
+	var respBody util.M
+	resp := app.Test(http.MethodGet, "/api/me")
+	app.UnmarshalBody(resp.Body, &respBody)
+
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, util.M{"id": user.ID.String(), "email": user.Email, "verified": false}, respBody)
+}
+
+func TestUserHandler_ChangePassword(t *testing.T) {
+	t.Parallel()
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+
+	password, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	user := f.InsertUser(&domain.User{HashPassword: string(password)})
+	otherSession := f.InsertSession(&domain.Session{UserID: user.ID})
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: user.ID})
+
+	tests := []struct {
+		name           string
+		body           util.M
+		expectedStatus int
+		expectedBody   util.M
+	}{
+		{
+			"missing fields",
+			util.M{},
+			400,
+			util.M{"errors": util.M{"current_password": []any{"is required"}, "new_password": []any{"is required"}}},
+		},
+		{
+			"new password too short",
+			util.M{"current_password": "password123", "new_password": "short"},
+			400,
+			util.M{"errors": util.M{"new_password": []any{"must contain at least 8 characters"}}},
+		},
+		{
+			"wrong current password",
+			util.M{"current_password": "wrong-password", "new_password": "newPassword123"},
+			400,
+			util.M{"error": "current password is incorrect"},
+		},
+		{
+			"change password",
+			util.M{"current_password": "password123", "new_password": "newPassword123"},
+			204,
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+			var respBody util.M
+
+			resp := app.Test(http.MethodPatch, "/api/me/password", tt.body)
+			app.UnmarshalBody(resp.Body, &respBody)
+
+			a.Equal(tt.expectedStatus, resp.StatusCode)
+			a.Equal(tt.expectedBody, respBody)
+		})
+	}
+
+	var updated domain.User
+	tx.First(&updated, "id = ?", user.ID)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(updated.HashPassword), []byte("newPassword123")))
+
+	// other devices are logged out, the current one is kept
+	var session domain.Session
+	tx.First(&session, "id = ?", otherSession.ID)
+	assert.NotNil(t, session.RevokedAt)
+	assert.Equal(t, 200, app.Test(http.MethodGet, "/api/me").StatusCode)
+}
+
+func TestUserHandler_ChangeEmail(t *testing.T) {
+	t.Parallel()
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+
+	now := time.Now().UTC()
+	password, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	user := f.InsertUser(&domain.User{HashPassword: string(password), VerifiedAt: &now})
+	taken := f.InsertUser()
+
+	mailer := testhelper.NewMockMailer(t)
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: user.ID, Mailer: mailer})
+
+	t.Run("invalid email", func(t *testing.T) {
+		var respBody util.M
+		resp := app.Test(http.MethodPatch, "/api/me/email", util.M{"email": "invalid", "password": "password123"})
+		app.UnmarshalBody(resp.Body, &respBody)
+
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, util.M{"errors": util.M{"email": []any{"must be valid"}}}, respBody)
+	})
+
+	t.Run("email already in use", func(t *testing.T) {
+		var respBody util.M
+		resp := app.Test(http.MethodPatch, "/api/me/email", util.M{"email": taken.Email, "password": "password123"})
+		app.UnmarshalBody(resp.Body, &respBody)
+
+		assert.Equal(t, 409, resp.StatusCode)
+		assert.Equal(t, util.M{"error": "email already in use"}, respBody)
+	})
+
+	t.Run("wrong password", func(t *testing.T) {
+		var respBody util.M
+		resp := app.Test(http.MethodPatch, "/api/me/email", util.M{"email": "new@example.com", "password": "wrong-password"})
+		app.UnmarshalBody(resp.Body, &respBody)
+
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, util.M{"error": "password is incorrect"}, respBody)
+	})
+
+	t.Run("change email and verify it again", func(t *testing.T) {
+		mailer.EXPECT().Send(mock.Anything, mock.MatchedBy(func(e mail.Email) bool {
+			return e.To.Email == "new@example.com" && e.Template == mail.VerifyEmailTemplate
+		})).Return(nil).Once()
+
+		var respBody util.M
+		resp := app.Test(http.MethodPatch, "/api/me/email", util.M{"email": "new@example.com", "password": "password123"})
+		app.UnmarshalBody(resp.Body, &respBody)
+
+		assert.Equal(t, 200, resp.StatusCode)
+		assert.Equal(t, util.M{"id": user.ID.String(), "email": "new@example.com", "verified": false}, respBody)
+	})
+}
+
+func TestUserHandler_Delete(t *testing.T) {
+	t.Parallel()
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+
+	password, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	user := f.InsertUser(&domain.User{HashPassword: string(password)})
+	goal := f.InsertGoal(&domain.Goal{UserID: user.ID})
+	f.InsertExpense(&domain.Expense{GoalID: goal.ID, UserID: user.ID})
+
+	mailer := testhelper.NewMockMailer(t)
+	app := testhelper.NewTestApp(t, tx, testhelper.TestAppOpts{UserID: user.ID, Mailer: mailer})
+
+	t.Run("wrong password", func(t *testing.T) {
+		var respBody util.M
+		resp := app.Test(http.MethodDelete, "/api/me", util.M{"password": "wrong-password"})
+		app.UnmarshalBody(resp.Body, &respBody)
+
+		assert.Equal(t, 400, resp.StatusCode)
+		assert.Equal(t, util.M{"error": "password is incorrect"}, respBody)
+	})
+
+	t.Run("delete account", func(t *testing.T) {
+		mailer.EXPECT().Send(mock.Anything, mock.MatchedBy(func(e mail.Email) bool {
+			return e.To.Email == user.Email && e.Template == mail.AccountDeletedTemplate
+		})).Return(nil).Once()
+
+		resp := app.Test(http.MethodDelete, "/api/me", util.M{"password": "password123"})
+		assert.Equal(t, 204, resp.StatusCode)
+
+		var count int64
+		tx.Model(&domain.User{}).Where("id = ?", user.ID).Count(&count)
+		assert.Zero(t, count)
+	})
+}
@@ -1,19 +1,33 @@
 package auth
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"time"
 
 	"github.com/go-chi/jwtauth/v5"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/joaopsramos/fincon/internal/config"
+	"github.com/joaopsramos/fincon/internal/errs"
 	"github.com/lestrrat-go/jwx/jwa"
 )
 
-func GenerateJWTToken(userID uuid.UUID, expiresIn time.Duration) string {
+const (
+	AccessTokenExpiresIn  = 15 * time.Minute
+	RefreshTokenExpiresIn = 30 * 24 * time.Hour
+
+	ChallengeTokenExpiresIn = 5 * time.Minute
+	challengeTokenType      = "2fa_challenge"
+)
+
+// GenerateJWTToken issues an access token for the user, "sid" identifies the session it belongs to
+func GenerateJWTToken(userID uuid.UUID, sessionID uuid.UUID, expiresIn time.Duration) string {
 	token := jwt.NewWithClaims(
 		jwt.SigningMethodHS256,
-		jwt.MapClaims{"sub": userID, "exp": time.Now().UTC().Add(expiresIn).Unix()},
+		jwt.MapClaims{"sub": userID, "sid": sessionID, "exp": time.Now().UTC().Add(expiresIn).Unix()},
 	)
 
 	tokenString, err := token.SignedString([]byte(config.Get().SecretKey))
@@ -24,6 +38,61 @@ func GenerateJWTToken(userID uuid.UUID, expiresIn time.Duration) string {
 	return tokenString
 }
 
+// GenerateRefreshToken returns a random opaque token, only its hash should be stored
+func GenerateRefreshToken() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
 func NewTokenAuth() *jwtauth.JWTAuth {
 	return jwtauth.New(jwa.HS256.String(), []byte(config.Get().SecretKey), nil)
 }
+
+// GenerateChallengeToken issues a short lived token proving the password step of a two-factor
+// login succeeded. It carries no session, so it can't be used as an access token.
+func GenerateChallengeToken(userID uuid.UUID) string {
+	token := jwt.NewWithClaims(
+		jwt.SigningMethodHS256,
+		jwt.MapClaims{"sub": userID, "typ": challengeTokenType, "exp": time.Now().UTC().Add(ChallengeTokenExpiresIn).Unix()},
+	)
+
+	tokenString, err := token.SignedString([]byte(config.Get().SecretKey))
+	if err != nil {
+		panic(err)
+	}
+
+	return tokenString
+}
+
+// ParseChallengeToken returns the user a challenge token was issued to
+func ParseChallengeToken(tokenString string) (uuid.UUID, error) {
+	var claims jwt.MapClaims
+
+	_, err := jwt.ParseWithClaims(tokenString, &claims, func(t *jwt.Token) (any, error) {
+		return []byte(config.Get().SecretKey), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
+		return uuid.Nil, errs.ErrInvalidToken
+	}
+
+	if typ, _ := claims["typ"].(string); typ != challengeTokenType {
+		return uuid.Nil, errs.ErrInvalidToken
+	}
+
+	sub, _ := claims["sub"].(string)
+	userID, err := uuid.Parse(sub)
+	if err != nil {
+		return uuid.Nil, errs.ErrInvalidToken
+	}
+
+	return userID, nil
+}
@@ -0,0 +1,101 @@
+package auth_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joaopsramos/fincon/internal/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+// base32 of the RFC 6238 SHA1 test key "12345678901234567890"
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		code, err := auth.TOTPCode(rfcSecret, auth.TOTPStep(time.Unix(tt.unix, 0)))
+		assert.NoError(t, err)
+		assert.Equal(t, tt.code, code, "at %d", tt.unix)
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	t.Parallel()
+	now := time.Unix(1234567890, 0)
+	step := auth.TOTPStep(now)
+
+	code := func(step int64) string {
+		code, err := auth.TOTPCode(rfcSecret, step)
+		assert.NoError(t, err)
+		return code
+	}
+
+	tests := []struct {
+		name     string
+		code     string
+		wantStep int64
+		wantOk   bool
+	}{
+		{"current step", code(step), step, true},
+		{"previous step", code(step - 1), step - 1, true},
+		{"next step", code(step + 1), step + 1, true},
+		{"too old", code(step - 2), 0, false},
+		{"too far ahead", code(step + 2), 0, false},
+		{"surrounding spaces", " " + code(step) + " ", step, true},
+		{"wrong length", "12345", 0, false},
+		{"wrong code", "000000", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStep, ok := auth.ValidateTOTP(rfcSecret, tt.code, now)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.wantStep, gotStep)
+		})
+	}
+
+	_, ok := auth.ValidateTOTP("not base32!", "123456", now)
+	assert.False(t, ok)
+}
+
+func TestTOTPURI(t *testing.T) {
+	t.Parallel()
+
+	uri := auth.TOTPURI(rfcSecret, "user@example.com")
+
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Fincon:user@example.com?"))
+	assert.Contains(t, uri, "secret="+rfcSecret)
+	assert.Contains(t, uri, "issuer=Fincon")
+	assert.Contains(t, uri, "digits=6")
+	assert.Contains(t, uri, "period=30")
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	codes := auth.GenerateRecoveryCodes(10)
+	a.Len(codes, 10)
+
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		a.Regexp(`^[a-z2-9]{5}-[a-z2-9]{5}$`, code)
+		a.Equal(code, auth.NormalizeRecoveryCode(strings.ToUpper(strings.ReplaceAll(code, "-", " "))))
+		a.False(seen[code])
+		seen[code] = true
+	}
+}
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/joaopsramos/fincon/internal/config"
+	"github.com/joaopsramos/fincon/internal/errs"
+)
+
+const unsubscribePurpose = "digest_unsubscribe:"
+
+// GenerateUnsubscribeToken returns a token that unsubscribes the user from digest emails.
+// It doesn't expire, so links in old emails keep working.
+func GenerateUnsubscribeToken(userID uuid.UUID) string {
+	return base64.RawURLEncoding.EncodeToString(userID[:]) + "." + unsubscribeSignature(userID)
+}
+
+// ParseUnsubscribeToken returns the user of a token generated by GenerateUnsubscribeToken
+func ParseUnsubscribeToken(token string) (uuid.UUID, error) {
+	encodedID, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return uuid.Nil, errs.ErrInvalidToken
+	}
+
+	rawID, err := base64.RawURLEncoding.DecodeString(encodedID)
+	if err != nil {
+		return uuid.Nil, errs.ErrInvalidToken
+	}
+
+	userID, err := uuid.FromBytes(rawID)
+	if err != nil {
+		return uuid.Nil, errs.ErrInvalidToken
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(unsubscribeSignature(userID))) {
+		return uuid.Nil, errs.ErrInvalidToken
+	}
+
+	return userID, nil
+}
+
+func unsubscribeSignature(userID uuid.UUID) string {
+	mac := hmac.New(sha256.New, []byte(config.Get().SecretKey))
+	mac.Write([]byte(unsubscribePurpose + userID.String()))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
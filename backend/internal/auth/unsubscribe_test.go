@@ -0,0 +1,42 @@
+package auth_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/joaopsramos/fincon/internal/auth"
+	"github.com/joaopsramos/fincon/internal/errs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnsubscribeToken(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	userID := uuid.New()
+	token := auth.GenerateUnsubscribeToken(userID)
+
+	got, err := auth.ParseUnsubscribeToken(token)
+	a.NoError(err)
+	a.Equal(userID, got)
+
+	encodedID, signature, _ := strings.Cut(token, ".")
+	otherID, _, _ := strings.Cut(auth.GenerateUnsubscribeToken(uuid.New()), ".")
+
+	invalid := []string{
+		"",
+		"invalid",
+		encodedID,
+		encodedID + ".",
+		encodedID + "." + signature[1:],
+		// signature of another user
+		otherID + "." + signature,
+		"!!." + signature,
+	}
+
+	for _, token := range invalid {
+		_, err := auth.ParseUnsubscribeToken(token)
+		a.ErrorIs(err, errs.ErrInvalidToken, token)
+	}
+}
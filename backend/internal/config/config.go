@@ -16,6 +16,9 @@ type Config struct {
 	WebURL    string `env:"APP_WEB_URL,required"`
 	SecretKey string `env:"SECRET_KEY,required"`
 
+	// Restricts what accounts can do before confirming their email: off, read_only or block
+	EmailVerificationPolicy VerificationPolicy `env:"EMAIL_VERIFICATION_POLICY" envDefault:"off"`
+
 	// Honeybadger
 	HoneybadgerAPIKey string `env:"HONEYBADGER_API_KEY"`
 
@@ -26,6 +29,16 @@ type Config struct {
 
 	AWSAccessKeyID     string `env:"AWS_ACCESS_KEY_ID"`
 	AWSSecretAccessKey string `env:"AWS_SECRET_ACCESS_KEY"`
+	AWSRegion          string `env:"AWS_REGION" envDefault:"us-east-1"`
+	// Overrides the SES API URL, e.g. to point it to a local SES compatible server
+	SESEndpoint string `env:"SES_ENDPOINT"`
+
+	SMTPHost     string `env:"SMTP_HOST" envDefault:"localhost"`
+	SMTPPort     int    `env:"SMTP_PORT" envDefault:"1025"`
+	SMTPUsername string `env:"SMTP_USERNAME"`
+	SMTPPassword string `env:"SMTP_PASSWORD"`
+	// One of none, starttls or tls (implicit TLS, usually on port 465)
+	SMTPSecurity string `env:"SMTP_SECURITY" envDefault:"none"`
 
 	// Database Configuration
 	Database struct {
@@ -37,6 +50,17 @@ type Config struct {
 	}
 }
 
+type VerificationPolicy string
+
+const (
+	// VerificationOff lets unverified accounts use the app normally
+	VerificationOff VerificationPolicy = "off"
+	// VerificationReadOnly lets unverified accounts read but not change their data
+	VerificationReadOnly VerificationPolicy = "read_only"
+	// VerificationBlock denies unverified accounts any access to their data
+	VerificationBlock VerificationPolicy = "block"
+)
+
 var (
 	cfg  = &Config{}
 	once sync.Once
@@ -57,6 +81,12 @@ func Load(rootDir string) {
 		if err := env.Parse(&cfg.Database); err != nil {
 			log.Fatalf("failed to parse database config: %v", err)
 		}
+
+		security, err := ParseSMTPSecurity(cfg.SMTPSecurity)
+		if err != nil {
+			log.Fatalf("failed to parse config: %v", err)
+		}
+		cfg.SMTPSecurity = string(security)
 	})
 }
 
@@ -1,6 +1,10 @@
 package config
 
 import (
+	"crypto/tls"
+	"fmt"
+	"strings"
+
 	"github.com/joaopsramos/fincon/internal/types"
 )
 
@@ -9,17 +13,61 @@ type Driver string
 const (
 	MailPit Driver = "mailpit"
 	SES     Driver = "ses"
+	SMTP    Driver = "smtp"
+)
+
+type SMTPSecurity string
+
+const (
+	SMTPSecurityNone     SMTPSecurity = "none"
+	SMTPSecurityStartTLS SMTPSecurity = "starttls"
+	SMTPSecurityTLS      SMTPSecurity = "tls"
 )
 
+// ParseSMTPSecurity validates the security of an SMTP connection, ignoring case.
+// Empty means none.
+func ParseSMTPSecurity(value string) (SMTPSecurity, error) {
+	security := SMTPSecurity(strings.ToLower(strings.TrimSpace(value)))
+
+	switch security {
+	case "":
+		return SMTPSecurityNone, nil
+	case SMTPSecurityNone, SMTPSecurityStartTLS, SMTPSecurityTLS:
+		return security, nil
+	default:
+		return "", fmt.Errorf("invalid smtp security %q, must be one of: none, starttls, tls", value)
+	}
+}
+
 type MailConfig struct {
 	Driver   Driver
 	Defaults MailDefaults
+	SES      SESConfig
+	SMTP     SMTPConfig
 }
 
 type MailDefaults struct {
 	From types.MailContact
 }
 
+type SESConfig struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	Region          string
+	// Endpoint defaults to the regional SES endpoint when empty
+	Endpoint string
+}
+
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	Security SMTPSecurity
+	// TLS overrides the TLS configuration used by STARTTLS and implicit TLS
+	TLS *tls.Config
+}
+
 func NewMailConfig() *MailConfig {
 	cfg := Get()
 
@@ -28,5 +76,18 @@ func NewMailConfig() *MailConfig {
 		Defaults: MailDefaults{
 			From: types.MailContact{Name: cfg.MailFromName, Email: cfg.MailFromEmail},
 		},
+		SES: SESConfig{
+			AccessKeyID:     cfg.AWSAccessKeyID,
+			SecretAccessKey: cfg.AWSSecretAccessKey,
+			Region:          cfg.AWSRegion,
+			Endpoint:        cfg.SESEndpoint,
+		},
+		SMTP: SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			Security: SMTPSecurity(cfg.SMTPSecurity),
+		},
 	}
 }
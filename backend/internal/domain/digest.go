@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DigestFrequency string
+
+const (
+	DigestNone    DigestFrequency = "none"
+	DigestWeekly  DigestFrequency = "weekly"
+	DigestMonthly DigestFrequency = "monthly"
+)
+
+func DigestFrequencies() []string {
+	return []string{string(DigestNone), string(DigestWeekly), string(DigestMonthly)}
+}
+
+// DigestPreference is the opt-in of a user to receive budget digests by email
+type DigestPreference struct {
+	UserID    uuid.UUID       `gorm:"type:uuid;primaryKey"`
+	Frequency DigestFrequency `gorm:"not null;default:none;index"`
+	// LastPeriod is the start of the last period a digest was sent for
+	LastPeriod *time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+type DigestPreferenceDTO struct {
+	Frequency DigestFrequency `json:"frequency"`
+}
+
+func (p *DigestPreference) ToDTO() DigestPreferenceDTO {
+	return DigestPreferenceDTO{Frequency: p.Frequency}
+}
+
+type DigestRepo interface {
+	// Get returns the preference of the user, defaulting to DigestNone when there is none
+	Get(ctx context.Context, userID uuid.UUID) (*DigestPreference, error)
+	Save(ctx context.Context, p *DigestPreference) error
+	// AllDue returns the preferences with the frequency not sent for the period starting at
+	// periodStart yet, along with their users
+	AllDue(ctx context.Context, frequency DigestFrequency, periodStart time.Time) ([]DigestPreference, error)
+	MarkSent(ctx context.Context, userID uuid.UUID, periodStart time.Time) error
+}
@@ -16,19 +16,28 @@ type Expense struct {
 	UserID uuid.UUID `gorm:"type:uuid"`
 	GoalID uint
 
+	// Set when the expense was materialized from a recurring expense template
+	RecurringExpenseID *uint `gorm:"index"`
+	// Member who added the expense to a household budget
+	CreatedByID *uuid.UUID `gorm:"type:uuid"`
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 
-	User User `gorm:"foreignKey:UserID"`
-	Goal Goal
+	User      User  `gorm:"foreignKey:UserID"`
+	CreatedBy *User `gorm:"foreignKey:CreatedByID;constraint:OnDelete:SET NULL"`
+	Goal      Goal
+	Tags      []Tag `gorm:"many2many:expense_tags;constraint:OnDelete:CASCADE"`
 }
 
 type ExpenseDTO struct {
-	ID     uint      `json:"id"`
-	Name   string    `json:"name"`
-	Value  float64   `json:"value"`
-	Date   time.Time `json:"date"`
-	GoalID uint      `json:"goal_id"`
+	ID        uint       `json:"id"`
+	Name      string     `json:"name"`
+	Value     float64    `json:"value"`
+	Date      time.Time  `json:"date"`
+	GoalID    uint       `json:"goal_id"`
+	Tags      []TagDTO   `json:"tags,omitempty"`
+	CreatedBy *uuid.UUID `json:"created_by,omitempty"`
 }
 
 type MonthlyGoalSpending struct {
@@ -37,6 +46,42 @@ type MonthlyGoalSpending struct {
 	Spent int64
 }
 
+type ExpenseSort string
+
+const (
+	SortDateDesc  ExpenseSort = "date_desc"
+	SortDateAsc   ExpenseSort = "date_asc"
+	SortValueDesc ExpenseSort = "value_desc"
+	SortValueAsc  ExpenseSort = "value_asc"
+)
+
+func ExpenseSorts() []string {
+	return []string{string(SortDateDesc), string(SortDateAsc), string(SortValueDesc), string(SortValueAsc)}
+}
+
+// ExpenseCursor identifies the last expense of a page, the next page starts right after it
+type ExpenseCursor struct {
+	Sort  ExpenseSort `json:"s"`
+	ID    uint        `json:"i"`
+	Date  time.Time   `json:"d"`
+	Value int64       `json:"v"`
+}
+
+// ExpenseFilter narrows down a search of expenses, zero values are ignored.
+// From is inclusive and To is exclusive.
+type ExpenseFilter struct {
+	From     time.Time
+	To       time.Time
+	GoalIDs  []uint
+	TagIDs   []uint
+	MinValue int64
+	MaxValue int64
+	Query    string
+	Sort     ExpenseSort
+	After    *ExpenseCursor
+	Limit    int
+}
+
 func (e *Expense) ToDTO() ExpenseDTO {
 	return ExpenseDTO{
 		ID:     e.ID,
@@ -44,6 +89,9 @@ func (e *Expense) ToDTO() ExpenseDTO {
 		Value:  util.MoneyAmountToFloat(e.Value),
 		Date:   e.Date,
 		GoalID: e.GoalID,
+		Tags:   util.Map(e.Tags, func(t Tag) TagDTO { return t.ToDTO() }),
+
+		CreatedBy: e.CreatedByID,
 	}
 }
 
@@ -52,8 +100,14 @@ type ExpenseRepo interface {
 	Create(ctx context.Context, e *Expense) error
 	CreateMany(ctx context.Context, e []Expense) error
 	Update(ctx context.Context, e *Expense) error
+	ReplaceTags(ctx context.Context, e *Expense, tags []Tag) error
 	Delete(ctx context.Context, id uint, userID uuid.UUID) error
+	// AllInBatches calls fn with every expense of the user, ordered by date, in batches of at most size expenses
+	AllInBatches(ctx context.Context, userID uuid.UUID, size int, fn func([]Expense) error) error
 	AllByGoalID(ctx context.Context, goalID uint, year int, month time.Month, userID uuid.UUID) ([]Expense, error)
+	AllBetween(ctx context.Context, from time.Time, to time.Time, userID uuid.UUID) ([]Expense, error)
+	SumByGoalBetween(ctx context.Context, goalID uint, from time.Time, to time.Time, userID uuid.UUID) (int64, error)
+	Search(ctx context.Context, filter ExpenseFilter, userID uuid.UUID) ([]Expense, error)
 	FindMatchingNames(ctx context.Context, name string, userID uuid.UUID) ([]string, error)
 	GetMonthlyGoalSpendings(ctx context.Context, date time.Time, userID uuid.UUID) ([]MonthlyGoalSpending, error)
 }
@@ -2,10 +2,14 @@ package domain
 
 import (
 	"context"
+	"slices"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// GoalName is the name of a goal, users can name their goals freely, the constants
+// below are only the names of the goals every user starts with
 type GoalName string
 
 const (
@@ -17,30 +21,133 @@ const (
 	Knowledge            GoalName = "Knowledge"
 )
 
+// RolloverPolicy tells what happens to the balance of a goal at the end of a month, a
+// surplus is the part of the limit that wasn't spent and a deficit what was spent over it
+type RolloverPolicy string
+
+const (
+	RolloverNone    RolloverPolicy = "none"
+	RolloverSurplus RolloverPolicy = "surplus"
+	RolloverDeficit RolloverPolicy = "deficit"
+	RolloverBoth    RolloverPolicy = "both"
+)
+
+// DefaultRolloverPolicy only carries overspending, which is how goals always worked
+const DefaultRolloverPolicy = RolloverDeficit
+
+func RolloverPolicies() []string {
+	return []string{string(RolloverNone), string(RolloverSurplus), string(RolloverDeficit), string(RolloverBoth)}
+}
+
+// Carry returns the part of the balance of a month that goes to the next one, balance
+// is the limit plus what was carried into the month minus what was spent in it
+func (p RolloverPolicy) Carry(balance int64) int64 {
+	switch {
+	case balance > 0 && (p == RolloverSurplus || p == RolloverBoth):
+		return balance
+	case balance < 0 && (p == RolloverDeficit || p == RolloverBoth):
+		return balance
+	default:
+		return 0
+	}
+}
+
 type Goal struct {
 	ID         uint `gorm:"primaryKey;autoIncrement"`
 	Name       GoalName
 	Percentage uint
+	Color      string
+	Position   int
+	ArchivedAt *time.Time
 	UserID     uuid.UUID
+	// AlertThresholds are the percentages of the goal limit that trigger a notification,
+	// nil means DefaultAlertThresholds and an empty list disables alerts
+	AlertThresholds []int `gorm:"type:jsonb;serializer:json"`
+	// EmailAlerts sends the notifications of the goal by email as well
+	EmailAlerts bool
+	// RolloverPolicy is applied to every month in the summary, so changing it changes past
+	// months as well
+	RolloverPolicy RolloverPolicy `gorm:"not null;default:deficit"`
 
 	User     User `gorm:"foreignKey:UserID"`
 	Expenses []Expense
 }
 
 type GoalDTO struct {
-	ID         uint     `json:"id"`
-	Name       GoalName `json:"name"`
-	Percentage uint     `json:"percentage"`
+	ID              uint           `json:"id"`
+	Name            GoalName       `json:"name"`
+	Percentage      uint           `json:"percentage"`
+	Color           string         `json:"color"`
+	Position        int            `json:"position"`
+	ArchivedAt      *time.Time     `json:"archived_at"`
+	AlertThresholds []int          `json:"alert_thresholds"`
+	EmailAlerts     bool           `json:"email_alerts"`
+	RolloverPolicy  RolloverPolicy `json:"rollover_policy"`
 }
 
+// DefaultAlertThresholds notify when a goal is close to its limit and when it reaches it
+var DefaultAlertThresholds = []int{80, 100}
+
 func (g *Goal) ToDTO() GoalDTO {
 	return GoalDTO{
-		ID:         g.ID,
-		Name:       g.Name,
-		Percentage: g.Percentage,
+		ID:              g.ID,
+		Name:            g.Name,
+		Percentage:      g.Percentage,
+		Color:           g.Color,
+		Position:        g.Position,
+		ArchivedAt:      g.ArchivedAt,
+		AlertThresholds: g.Thresholds(),
+		EmailAlerts:     g.EmailAlerts,
+		RolloverPolicy:  g.Rollover(),
 	}
 }
 
+func (g *Goal) IsArchived() bool {
+	return g.ArchivedAt != nil
+}
+
+// Thresholds returns the alert thresholds of the goal in ascending order
+func (g *Goal) Thresholds() []int {
+	if g.AlertThresholds == nil {
+		return slices.Clone(DefaultAlertThresholds)
+	}
+
+	thresholds := slices.Clone(g.AlertThresholds)
+	slices.Sort(thresholds)
+
+	return thresholds
+}
+
+// Rollover returns the rollover policy of the goal, DefaultRolloverPolicy when not set
+func (g *Goal) Rollover() RolloverPolicy {
+	if g.RolloverPolicy == "" {
+		return DefaultRolloverPolicy
+	}
+
+	return g.RolloverPolicy
+}
+
+// GoalColors is the palette used for goals created without a color
+var GoalColors = []string{"#ef4444", "#f97316", "#eab308", "#22c55e", "#06b6d4", "#3b82f6", "#8b5cf6", "#ec4899"}
+
+// DefaultGoals returns the goals every user starts with, in display order
+func DefaultGoals() []Goal {
+	percentages := DefaultGoalPercentages()
+	names := []GoalName{FixedCosts, Comfort, Goals, Pleasures, FinancialInvestments, Knowledge}
+
+	goals := make([]Goal, len(names))
+	for i, name := range names {
+		goals[i] = Goal{
+			Name:       name,
+			Percentage: percentages[name],
+			Color:      GoalColors[i%len(GoalColors)],
+			Position:   i,
+		}
+	}
+
+	return goals
+}
+
 func DefaultGoalPercentages() map[GoalName]uint {
 	return map[GoalName]uint{
 		FixedCosts:           40,
@@ -52,9 +159,114 @@ func DefaultGoalPercentages() map[GoalName]uint {
 	}
 }
 
+// GoalAllocation is the percentage of a goal from the month of EffectiveFrom until the next
+// allocation, so changing the percentages doesn't change the budget of past months
+type GoalAllocation struct {
+	ID            uint      `gorm:"primaryKey;autoIncrement"`
+	GoalID        uint      `gorm:"uniqueIndex:idx_goal_allocations_goal_id_effective_from,priority:1"`
+	EffectiveFrom time.Time `gorm:"type:date;uniqueIndex:idx_goal_allocations_goal_id_effective_from,priority:2"`
+	Percentage    uint
+	UserID        uuid.UUID `gorm:"type:uuid;index"`
+
+	Goal Goal `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// GoalAllocations is the allocation history of each goal, indexed by goal id and sorted by EffectiveFrom
+type GoalAllocations map[uint][]GoalAllocation
+
+func NewGoalAllocations(allocations []GoalAllocation) GoalAllocations {
+	ga := make(GoalAllocations)
+	return ga.With(allocations...)
+}
+
+// With returns a copy of the history with the given allocations, replacing the ones
+// of the same goal and month
+func (ga GoalAllocations) With(allocations ...GoalAllocation) GoalAllocations {
+	result := make(GoalAllocations, len(ga))
+	for goalID, history := range ga {
+		result[goalID] = slices.Clone(history)
+	}
+
+	for _, a := range allocations {
+		history := result[a.GoalID]
+
+		i, found := slices.BinarySearchFunc(history, a.EffectiveFrom, func(e GoalAllocation, t time.Time) int {
+			return e.EffectiveFrom.Compare(t)
+		})
+
+		if found {
+			history[i] = a
+		} else {
+			history = slices.Insert(history, i, a)
+		}
+
+		result[a.GoalID] = history
+	}
+
+	return result
+}
+
+// PercentageFor returns the percentage of the goal in the month of the given date. Months
+// before the first allocation use the first one, goals without allocations use their percentage.
+func (ga GoalAllocations) PercentageFor(goal Goal, date time.Time) uint {
+	history := ga[goal.ID]
+	if len(history) == 0 {
+		return goal.Percentage
+	}
+
+	month := monthStart(date)
+
+	percentage := history[0].Percentage
+	for _, a := range history[1:] {
+		if a.EffectiveFrom.After(month) {
+			break
+		}
+
+		percentage = a.Percentage
+	}
+
+	return percentage
+}
+
+// HasAllocationAt reports whether the goal has an allocation starting exactly in the month of the given date
+func (ga GoalAllocations) HasAllocationAt(goalID uint, date time.Time) bool {
+	month := monthStart(date)
+
+	return slices.ContainsFunc(ga[goalID], func(a GoalAllocation) bool { return a.EffectiveFrom.Equal(month) })
+}
+
+// Merge returns the allocation history of target after receiving the percentages of removed
+func (ga GoalAllocations) Merge(removed Goal, target Goal) []GoalAllocation {
+	var months []time.Time
+	for _, a := range append(slices.Clone(ga[removed.ID]), ga[target.ID]...) {
+		months = append(months, a.EffectiveFrom)
+	}
+
+	slices.SortFunc(months, time.Time.Compare)
+	months = slices.CompactFunc(months, time.Time.Equal)
+
+	merged := make([]GoalAllocation, len(months))
+	for i, m := range months {
+		merged[i] = GoalAllocation{
+			GoalID:        target.ID,
+			EffectiveFrom: m,
+			Percentage:    ga.PercentageFor(removed, m) + ga.PercentageFor(target, m),
+			UserID:        target.UserID,
+		}
+	}
+
+	return merged
+}
+
 type GoalRepo interface {
+	// All returns the active goals, use AllWithArchived to include the archived ones
 	All(ctx context.Context, userID uuid.UUID) []Goal
+	AllWithArchived(ctx context.Context, userID uuid.UUID) []Goal
 	Get(ctx context.Context, id uint, userID uuid.UUID) (*Goal, error)
 	Create(ctx context.Context, goals ...Goal) error
-	UpdateAll(ctx context.Context, goals []Goal) error
+	Update(ctx context.Context, goal *Goal) error
+	// UpdateAll saves the percentages of the goals and their allocations at once
+	UpdateAll(ctx context.Context, goals []Goal, allocations []GoalAllocation) error
+	Delete(ctx context.Context, goal *Goal, reassignTo *Goal) error
+	AllAllocations(ctx context.Context, userID uuid.UUID) ([]GoalAllocation, error)
 }
@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type HouseholdRole string
+
+const (
+	// RoleOwner manages the household, its budget data is the one shared with the members
+	RoleOwner HouseholdRole = "owner"
+	// RoleEditor reads and changes the budget
+	RoleEditor HouseholdRole = "editor"
+	// RoleViewer only reads the budget
+	RoleViewer HouseholdRole = "viewer"
+)
+
+func HouseholdRoles() []string {
+	return []string{string(RoleOwner), string(RoleEditor), string(RoleViewer)}
+}
+
+// InvitableRoles are the roles a member can be given, a household only has one owner
+func InvitableRoles() []string {
+	return []string{string(RoleEditor), string(RoleViewer)}
+}
+
+func (r HouseholdRole) CanWrite() bool {
+	return slices.Contains([]HouseholdRole{RoleOwner, RoleEditor}, r)
+}
+
+// Household shares the budget of its owner (goals, salary, incomes and expenses) with other users.
+// Budget data stays scoped by the owner's user id, so leaving or deleting a household keeps it intact.
+type Household struct {
+	ID      uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Name    string
+	OwnerID uuid.UUID `gorm:"type:uuid;uniqueIndex"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Owner User `gorm:"foreignKey:OwnerID"`
+}
+
+type HouseholdMember struct {
+	ID          uint          `gorm:"primaryKey;autoIncrement"`
+	HouseholdID uuid.UUID     `gorm:"type:uuid;uniqueIndex:idx_household_members_household_id_user_id,priority:1"`
+	UserID      uuid.UUID     `gorm:"type:uuid;uniqueIndex:idx_household_members_household_id_user_id,priority:2;index"`
+	Role        HouseholdRole `gorm:"not null"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Household Household `gorm:"constraint:OnDelete:CASCADE"`
+	User      User      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+type HouseholdInvitation struct {
+	ID          uint          `gorm:"primaryKey;autoIncrement"`
+	HouseholdID uuid.UUID     `gorm:"type:uuid;index"`
+	Email       string        `gorm:"type:citext"`
+	Role        HouseholdRole `gorm:"not null"`
+	Token       uuid.UUID     `gorm:"type:uuid;uniqueIndex;default:gen_random_uuid()"`
+	InvitedByID uuid.UUID     `gorm:"type:uuid"`
+	ExpiresAt   time.Time
+	AcceptedAt  *time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Household Household `gorm:"constraint:OnDelete:CASCADE"`
+	InvitedBy User      `gorm:"foreignKey:InvitedByID;constraint:OnDelete:CASCADE"`
+}
+
+// Membership is a household as seen by one of its members
+type Membership struct {
+	HouseholdID uuid.UUID
+	Name        string
+	OwnerID     uuid.UUID
+	Role        HouseholdRole
+}
+
+type HouseholdDTO struct {
+	ID      uuid.UUID     `json:"id"`
+	Name    string        `json:"name"`
+	OwnerID uuid.UUID     `json:"owner_id"`
+	Role    HouseholdRole `json:"role"`
+}
+
+type HouseholdMemberDTO struct {
+	UserID uuid.UUID     `json:"user_id"`
+	Email  string        `json:"email"`
+	Role   HouseholdRole `json:"role"`
+}
+
+type HouseholdInvitationDTO struct {
+	ID        uint          `json:"id"`
+	Email     string        `json:"email"`
+	Role      HouseholdRole `json:"role"`
+	ExpiresAt time.Time     `json:"expires_at"`
+}
+
+func (m *Membership) ToDTO() HouseholdDTO {
+	return HouseholdDTO{
+		ID:      m.HouseholdID,
+		Name:    m.Name,
+		OwnerID: m.OwnerID,
+		Role:    m.Role,
+	}
+}
+
+func (m *HouseholdMember) ToDTO() HouseholdMemberDTO {
+	return HouseholdMemberDTO{UserID: m.UserID, Email: m.User.Email, Role: m.Role}
+}
+
+func (i *HouseholdInvitation) ToDTO() HouseholdInvitationDTO {
+	return HouseholdInvitationDTO{ID: i.ID, Email: i.Email, Role: i.Role, ExpiresAt: i.ExpiresAt}
+}
+
+type HouseholdRepo interface {
+	// Create stores the household along with its owner membership
+	Create(ctx context.Context, h *Household) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetByOwner(ctx context.Context, ownerID uuid.UUID) (*Household, error)
+	GetMembership(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*Membership, error)
+	AllMemberships(ctx context.Context, userID uuid.UUID) ([]Membership, error)
+	Members(ctx context.Context, id uuid.UUID) ([]HouseholdMember, error)
+	UpdateMemberRole(ctx context.Context, id uuid.UUID, userID uuid.UUID, role HouseholdRole) error
+	RemoveMember(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+	CreateInvitation(ctx context.Context, i *HouseholdInvitation) error
+	GetInvitationByToken(ctx context.Context, token string) (*HouseholdInvitation, error)
+	// AcceptInvitation marks the invitation as accepted and adds the user as a member
+	AcceptInvitation(ctx context.Context, i *HouseholdInvitation, userID uuid.UUID) error
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joaopsramos/fincon/internal/util"
+)
+
+// Income is a source of money besides the salary, e.g. freelance work, rent or a bonus.
+// Recurring incomes count every month from StartDate until EndDate, one-off incomes
+// only count in the month of StartDate. Amounts override Amount in single months.
+type Income struct {
+	ID        uint `gorm:"primaryKey;autoIncrement"`
+	Name      string
+	Amount    int64
+	Recurring bool
+	StartDate time.Time  `gorm:"type:timestamp without time zone"`
+	EndDate   *time.Time `gorm:"type:timestamp without time zone"`
+	UserID    uuid.UUID  `gorm:"type:uuid"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	User    User `gorm:"foreignKey:UserID"`
+	Amounts []IncomeAmount
+}
+
+// IncomeAmount is what an income actually paid in a month, when it differs from its usual amount
+type IncomeAmount struct {
+	IncomeID uint      `gorm:"primaryKey;autoIncrement:false"`
+	Month    time.Time `gorm:"type:date;primaryKey"`
+	Amount   int64
+
+	Income Income `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+type IncomeDTO struct {
+	ID        uint              `json:"id"`
+	Name      string            `json:"name"`
+	Amount    float64           `json:"amount"`
+	Recurring bool              `json:"recurring"`
+	StartDate time.Time         `json:"start_date"`
+	EndDate   *time.Time        `json:"end_date"`
+	Amounts   []IncomeAmountDTO `json:"amounts"`
+}
+
+type IncomeAmountDTO struct {
+	Month  string  `json:"month"`
+	Amount float64 `json:"amount"`
+}
+
+func (i *Income) ToDTO() IncomeDTO {
+	return IncomeDTO{
+		ID:        i.ID,
+		Name:      i.Name,
+		Amount:    util.MoneyAmountToFloat(i.Amount),
+		Recurring: i.Recurring,
+		StartDate: i.StartDate,
+		EndDate:   i.EndDate,
+		Amounts: util.Map(i.Amounts, func(a IncomeAmount) IncomeAmountDTO {
+			return IncomeAmountDTO{Month: a.Month.Format(util.ApiMonthLayout), Amount: util.MoneyAmountToFloat(a.Amount)}
+		}),
+	}
+}
+
+// OccursIn reports whether the income is received in the month of the given date.
+func (i *Income) OccursIn(date time.Time) bool {
+	month := monthStart(date)
+	start := monthStart(i.StartDate)
+
+	if !i.Recurring {
+		return month.Equal(start)
+	}
+
+	return !month.Before(start) && (i.EndDate == nil || !month.After(monthStart(*i.EndDate)))
+}
+
+// AmountFor returns how much the income adds to the month of the given date, the amount
+// set for that month if there is one or its usual amount otherwise.
+func (i *Income) AmountFor(date time.Time) int64 {
+	if !i.OccursIn(date) {
+		return 0
+	}
+
+	month := monthStart(date)
+	for _, a := range i.Amounts {
+		if monthStart(a.Month).Equal(month) {
+			return a.Amount
+		}
+	}
+
+	return i.Amount
+}
+
+// IncomeForMonth returns the sum of the incomes received in the month of the given date.
+func IncomeForMonth(incomes []Income, date time.Time) int64 {
+	var total int64
+	for _, i := range incomes {
+		total += i.AmountFor(date)
+	}
+
+	return total
+}
+
+type IncomeRepo interface {
+	All(ctx context.Context, userID uuid.UUID) ([]Income, error)
+	Get(ctx context.Context, id uint, userID uuid.UUID) (*Income, error)
+	Create(ctx context.Context, i *Income) error
+	Update(ctx context.Context, i *Income) error
+	Delete(ctx context.Context, id uint, userID uuid.UUID) error
+	// SetAmount creates or replaces the amount of the income in a month
+	SetAmount(ctx context.Context, a *IncomeAmount) error
+	DeleteAmount(ctx context.Context, incomeID uint, month time.Time) error
+}
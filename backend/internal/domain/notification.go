@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joaopsramos/fincon/internal/util"
+)
+
+type NotificationKind string
+
+const GoalThresholdNotification NotificationKind = "goal_threshold"
+
+// Notification tells the user a goal crossed one of its alert thresholds in a month.
+// Each threshold fires once per goal and month.
+type Notification struct {
+	ID        uint             `gorm:"primaryKey;autoIncrement"`
+	UserID    uuid.UUID        `gorm:"type:uuid;index"`
+	Kind      NotificationKind `gorm:"not null;default:goal_threshold"`
+	GoalID    uint             `gorm:"uniqueIndex:idx_notifications_goal_threshold_period,priority:1"`
+	Threshold int              `gorm:"uniqueIndex:idx_notifications_goal_threshold_period,priority:2"`
+	Period    time.Time        `gorm:"type:date;uniqueIndex:idx_notifications_goal_threshold_period,priority:3"`
+	// Spent and Limit are the amounts of the goal when the threshold was crossed
+	Spent  int64
+	Limit  int64
+	ReadAt *time.Time
+
+	CreatedAt time.Time
+
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Goal Goal `gorm:"foreignKey:GoalID;constraint:OnDelete:CASCADE"`
+}
+
+type NotificationDTO struct {
+	ID        uint             `json:"id"`
+	Kind      NotificationKind `json:"kind"`
+	GoalID    uint             `json:"goal_id"`
+	GoalName  GoalName         `json:"goal_name"`
+	Threshold int              `json:"threshold"`
+	Period    string           `json:"period"`
+	Spent     float64          `json:"spent"`
+	Limit     float64          `json:"limit"`
+	Read      bool             `json:"read"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+func (n *Notification) ToDTO() NotificationDTO {
+	return NotificationDTO{
+		ID:        n.ID,
+		Kind:      n.Kind,
+		GoalID:    n.GoalID,
+		GoalName:  n.Goal.Name,
+		Threshold: n.Threshold,
+		Period:    n.Period.Format(util.ApiMonthLayout),
+		Spent:     util.MoneyAmountToFloat(n.Spent),
+		Limit:     util.MoneyAmountToFloat(n.Limit),
+		Read:      n.ReadAt != nil,
+		CreatedAt: n.CreatedAt,
+	}
+}
+
+type NotificationRepo interface {
+	// Create stores the notification unless the threshold already fired for the goal
+	// in the period, reporting whether it was created
+	Create(ctx context.Context, n *Notification) (bool, error)
+	All(ctx context.Context, unreadOnly bool, userID uuid.UUID) ([]Notification, error)
+	MarkRead(ctx context.Context, id uint, userID uuid.UUID) error
+	MarkAllRead(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type OutboxStatus string
+
+const (
+	OutboxPending OutboxStatus = "pending"
+	OutboxSent    OutboxStatus = "sent"
+	// OutboxDead are emails that failed too many times and won't be retried
+	OutboxDead OutboxStatus = "dead"
+)
+
+// OutboxEmail is an email waiting to be delivered by the mail worker
+type OutboxEmail struct {
+	ID        uint `gorm:"primaryKey"`
+	ToName    string
+	ToEmail   string
+	FromName  string
+	FromEmail string
+	Subject   string
+	Template  string
+	Data      OutboxData `gorm:"type:jsonb"`
+
+	Status        OutboxStatus `gorm:"default:pending;index:idx_outbox_emails_due,priority:1"`
+	Attempts      int
+	NextAttemptAt time.Time `gorm:"index:idx_outbox_emails_due,priority:2"`
+	LastError     string
+	SentAt        *time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// OutboxData is the template data of an email. Numbers are decoded as json.Number, which
+// templates print just like the original value, instead of as float64, and structs come
+// back as maps, whose fields templates access the same way.
+type OutboxData map[string]any
+
+func (d OutboxData) Value() (driver.Value, error) {
+	return json.Marshal(d)
+}
+
+func (d *OutboxData) Scan(value any) error {
+	var data []byte
+
+	switch v := value.(type) {
+	case nil:
+		*d = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported outbox data type %T", value)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	return decoder.Decode(d)
+}
+
+type OutboxRepo interface {
+	Enqueue(ctx context.Context, e *OutboxEmail) error
+	// ClaimDue locks up to limit pending emails due at now, postponing them by lease
+	// so other workers skip them while they are being sent
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]OutboxEmail, error)
+	MarkSent(ctx context.Context, id uint) error
+	// MarkFailed records a failed attempt, scheduling a retry at nextAttemptAt or moving
+	// the email to the dead letter when nextAttemptAt is nil
+	MarkFailed(ctx context.Context, id uint, attempts int, nextAttemptAt *time.Time, lastErr string) error
+}
@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joaopsramos/fincon/internal/util"
+)
+
+type RecurringCadence string
+
+const (
+	Monthly   RecurringCadence = "monthly"
+	Quarterly RecurringCadence = "quarterly"
+	Yearly    RecurringCadence = "yearly"
+)
+
+type RecurringExpense struct {
+	ID         uint `gorm:"primaryKey;autoIncrement"`
+	Name       string
+	Value      int64
+	DayOfMonth int
+	Cadence    RecurringCadence
+	StartDate  time.Time  `gorm:"type:timestamp without time zone"`
+	EndDate    *time.Time `gorm:"type:timestamp without time zone"`
+	UserID     uuid.UUID  `gorm:"type:uuid"`
+	GoalID     uint
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	User User `gorm:"foreignKey:UserID"`
+	Goal Goal
+}
+
+// RecurringOccurrence records that a template was materialized for a month. It is kept
+// when the expense is deleted, so the occurrence is not created again.
+type RecurringOccurrence struct {
+	RecurringExpenseID uint      `gorm:"primaryKey;autoIncrement:false"`
+	Month              time.Time `gorm:"type:date;primaryKey"`
+
+	CreatedAt time.Time
+
+	RecurringExpense RecurringExpense `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+type RecurringExpenseDTO struct {
+	ID         uint             `json:"id"`
+	Name       string           `json:"name"`
+	Value      float64          `json:"value"`
+	DayOfMonth int              `json:"day_of_month"`
+	Cadence    RecurringCadence `json:"cadence"`
+	StartDate  time.Time        `json:"start_date"`
+	EndDate    *time.Time       `json:"end_date"`
+	GoalID     uint             `json:"goal_id"`
+}
+
+func RecurringCadences() []string {
+	return []string{string(Monthly), string(Quarterly), string(Yearly)}
+}
+
+// Months returns how many months apart two occurrences of the cadence are.
+func (c RecurringCadence) Months() int {
+	switch c {
+	case Quarterly:
+		return 3
+	case Yearly:
+		return 12
+	default:
+		return 1
+	}
+}
+
+func (r *RecurringExpense) ToDTO() RecurringExpenseDTO {
+	return RecurringExpenseDTO{
+		ID:         r.ID,
+		Name:       r.Name,
+		Value:      util.MoneyAmountToFloat(r.Value),
+		DayOfMonth: r.DayOfMonth,
+		Cadence:    r.Cadence,
+		StartDate:  r.StartDate,
+		EndDate:    r.EndDate,
+		GoalID:     r.GoalID,
+	}
+}
+
+// OccursIn reports whether the template produces an expense in the month of the given date.
+// In the first month the expense would be dated before the start date is skipped.
+func (r *RecurringExpense) OccursIn(date time.Time) bool {
+	month := monthStart(date)
+	start := monthStart(r.StartDate)
+
+	if month.Before(start) {
+		return false
+	}
+
+	if r.EndDate != nil && month.After(monthStart(*r.EndDate)) {
+		return false
+	}
+
+	if month.Equal(start) && r.dateIn(month).Before(dayStart(r.StartDate)) {
+		return false
+	}
+
+	monthDiff := (month.Year()-start.Year())*12 + int(month.Month()) - int(start.Month())
+
+	return monthDiff%r.Cadence.Months() == 0
+}
+
+// ExpenseFor builds the concrete expense for the month of the given date. Days that
+// don't exist in that month (e.g. 31 in April) fall on the last day of the month.
+func (r *RecurringExpense) ExpenseFor(date time.Time) Expense {
+	id := r.ID
+
+	return Expense{
+		Name:               r.Name,
+		Value:              r.Value,
+		Date:               r.dateIn(date),
+		UserID:             r.UserID,
+		GoalID:             r.GoalID,
+		RecurringExpenseID: &id,
+	}
+}
+
+// MonthsUntil returns the months the template produces an expense in, from its start
+// up to the month of the given date
+func (r *RecurringExpense) MonthsUntil(date time.Time) []time.Time {
+	var months []time.Time
+	for month := monthStart(r.StartDate); !month.After(date); month = month.AddDate(0, r.Cadence.Months(), 0) {
+		if r.EndDate != nil && month.After(monthStart(*r.EndDate)) {
+			break
+		}
+
+		if r.OccursIn(month) {
+			months = append(months, month)
+		}
+	}
+
+	return months
+}
+
+// dateIn returns the day of the month of the given date the expense falls on
+func (r *RecurringExpense) dateIn(date time.Time) time.Time {
+	month := monthStart(date)
+	lastDay := month.AddDate(0, 1, -1).Day()
+
+	return month.AddDate(0, 0, min(r.DayOfMonth, lastDay)-1)
+}
+
+func monthStart(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func dayStart(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+type RecurringExpenseRepo interface {
+	All(ctx context.Context, userID uuid.UUID) ([]RecurringExpense, error)
+	Get(ctx context.Context, id uint, userID uuid.UUID) (*RecurringExpense, error)
+	Create(ctx context.Context, r *RecurringExpense) error
+	Update(ctx context.Context, r *RecurringExpense) error
+	Delete(ctx context.Context, id uint, userID uuid.UUID) error
+	// AllStartedBy returns the templates of every user that started by the month of the given date
+	AllStartedBy(ctx context.Context, date time.Time) ([]RecurringExpense, error)
+	// Materialize creates the expenses of the template up to the month of the given date,
+	// skipping the months that already have an occurrence. It returns how many were created.
+	Materialize(ctx context.Context, r *RecurringExpense, until time.Time) (int, error)
+	// MaterializeMonth creates the expense of the template in the month of the given date,
+	// unless it doesn't occur in it or the month already has an occurrence. It reports
+	// whether the expense was created.
+	MaterializeMonth(ctx context.Context, r *RecurringExpense, month time.Time) (bool, error)
+}
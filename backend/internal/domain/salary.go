@@ -2,15 +2,20 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/joaopsramos/fincon/internal/util"
 )
 
+// Salary is a version of the user's salary, it applies from the month of EffectiveFrom
+// until the month before the next version. A raise creates a new version instead of
+// overwriting the previous one, so past months keep being computed with the old amount.
 type Salary struct {
-	ID     uint `gorm:"primaryKey;autoIncrement"`
-	Amount int64
-	UserID uuid.UUID `gorm:"type:uuid"`
+	ID            uint `gorm:"primaryKey;autoIncrement"`
+	Amount        int64
+	EffectiveFrom time.Time `gorm:"type:date;not null;default:date_trunc('month', now());uniqueIndex:idx_salaries_user_id_effective_from,priority:2"`
+	UserID        uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_salaries_user_id_effective_from,priority:1"`
 
 	User User `gorm:"foreignKey:UserID"`
 }
@@ -19,12 +24,47 @@ type SalaryDTO struct {
 	Amount float64 `json:"amount"`
 }
 
+type SalaryVersionDTO struct {
+	Amount        float64   `json:"amount"`
+	EffectiveFrom time.Time `json:"effective_from"`
+}
+
 func (s *Salary) ToDTO() SalaryDTO {
 	return SalaryDTO{Amount: util.MoneyAmountToFloat(s.Amount)}
 }
 
+func (s *Salary) ToVersionDTO() SalaryVersionDTO {
+	return SalaryVersionDTO{
+		Amount:        util.MoneyAmountToFloat(s.Amount),
+		EffectiveFrom: s.EffectiveFrom,
+	}
+}
+
+// SalaryForMonth returns the amount effective in the month of the given date. History must be
+// sorted by EffectiveFrom, months before the first version use the first one.
+func SalaryForMonth(history []Salary, date time.Time) int64 {
+	if len(history) == 0 {
+		return 0
+	}
+
+	month := monthStart(date)
+
+	amount := history[0].Amount
+	for _, s := range history[1:] {
+		if s.EffectiveFrom.After(month) {
+			break
+		}
+
+		amount = s.Amount
+	}
+
+	return amount
+}
+
 type SalaryRepo interface {
+	// Get returns the salary effective in the current month
 	Get(ctx context.Context, userID uuid.UUID) (*Salary, error)
+	History(ctx context.Context, userID uuid.UUID) ([]Salary, error)
 	Create(ctx context.Context, salary *Salary) error
 	Update(ctx context.Context, salary *Salary) error
 }
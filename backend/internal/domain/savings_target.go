@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joaopsramos/fincon/internal/util"
+)
+
+// SavingsTarget is an amount to be saved by a deadline, e.g. 10,000 for a trip by December.
+// The expenses of its goal dated from StartDate on are the contributions to it.
+type SavingsTarget struct {
+	ID           uint `gorm:"primaryKey;autoIncrement"`
+	Name         string
+	TargetAmount int64
+	StartDate    time.Time `gorm:"type:date"`
+	Deadline     time.Time `gorm:"type:date"`
+	GoalID       uint      `gorm:"index"`
+	UserID       uuid.UUID `gorm:"type:uuid;index"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Goal Goal `gorm:"constraint:OnDelete:CASCADE"`
+	User User `gorm:"foreignKey:UserID"`
+}
+
+type SavingsTargetDTO struct {
+	ID           uint      `json:"id"`
+	Name         string    `json:"name"`
+	TargetAmount float64   `json:"target_amount"`
+	StartDate    time.Time `json:"start_date"`
+	Deadline     time.Time `json:"deadline"`
+	GoalID       uint      `json:"goal_id"`
+}
+
+func (t *SavingsTarget) ToDTO() SavingsTargetDTO {
+	return SavingsTargetDTO{
+		ID:           t.ID,
+		Name:         t.Name,
+		TargetAmount: util.MoneyAmountToFloat(t.TargetAmount),
+		StartDate:    t.StartDate,
+		Deadline:     t.Deadline,
+		GoalID:       t.GoalID,
+	}
+}
+
+type SavingsProgress struct {
+	Saved     int64
+	Remaining int64
+	// MonthlyAverage is how much was saved per month since the start, counting the current one
+	MonthlyAverage int64
+	// RequiredMonthly is how much must be saved per month, counting the current one, to
+	// reach the target by the deadline. After the deadline it's everything that remains.
+	RequiredMonthly int64
+	// ProjectedCompletion is the last day of the month the target is reached at the
+	// current pace, nil when it's already reached or nothing was saved yet
+	ProjectedCompletion *time.Time
+}
+
+type SavingsProgressDTO struct {
+	Target              SavingsTargetDTO `json:"target"`
+	Saved               float64          `json:"saved"`
+	Remaining           float64          `json:"remaining"`
+	Percentage          float64          `json:"percentage"`
+	Completed           bool             `json:"completed"`
+	MonthlyAverage      float64          `json:"monthly_average"`
+	RequiredMonthly     float64          `json:"required_monthly"`
+	ProjectedCompletion *time.Time       `json:"projected_completion"`
+	OnTrack             bool             `json:"on_track"`
+}
+
+// Progress computes how far the target is given how much was saved up to now
+func (t *SavingsTarget) Progress(saved int64, now time.Time) SavingsProgress {
+	p := SavingsProgress{Saved: saved, Remaining: max(t.TargetAmount-saved, 0)}
+
+	current := monthStart(now)
+	elapsed := monthsBetween(monthStart(t.StartDate), current) + 1
+	if elapsed > 0 {
+		p.MonthlyAverage = saved / int64(elapsed)
+	}
+
+	if p.Remaining == 0 {
+		return p
+	}
+
+	left := monthsBetween(current, monthStart(t.Deadline)) + 1
+	p.RequiredMonthly = p.Remaining
+	if left > 1 {
+		// Round up so the last month doesn't fall short
+		p.RequiredMonthly = (p.Remaining + int64(left) - 1) / int64(left)
+	}
+
+	if p.MonthlyAverage > 0 {
+		months := (p.Remaining + p.MonthlyAverage - 1) / p.MonthlyAverage
+		projected := current.AddDate(0, int(months)+1, -1)
+		p.ProjectedCompletion = &projected
+	}
+
+	return p
+}
+
+func (t *SavingsTarget) ToProgressDTO(p SavingsProgress) SavingsProgressDTO {
+	dto := SavingsProgressDTO{
+		Target:              t.ToDTO(),
+		Saved:               util.MoneyAmountToFloat(p.Saved),
+		Remaining:           util.MoneyAmountToFloat(p.Remaining),
+		Completed:           p.Remaining == 0,
+		MonthlyAverage:      util.MoneyAmountToFloat(p.MonthlyAverage),
+		RequiredMonthly:     util.MoneyAmountToFloat(p.RequiredMonthly),
+		ProjectedCompletion: p.ProjectedCompletion,
+		OnTrack:             p.Remaining == 0 || (p.MonthlyAverage > 0 && p.MonthlyAverage >= p.RequiredMonthly),
+	}
+
+	if t.TargetAmount > 0 {
+		dto.Percentage = min(float64(p.Saved)*100/float64(t.TargetAmount), 100)
+	}
+
+	return dto
+}
+
+// monthsBetween returns how many months from the month of "from" to the month of "to",
+// negative when "to" comes first
+func monthsBetween(from time.Time, to time.Time) int {
+	return (to.Year()-from.Year())*12 + int(to.Month()) - int(from.Month())
+}
+
+type SavingsTargetRepo interface {
+	All(ctx context.Context, userID uuid.UUID) ([]SavingsTarget, error)
+	Get(ctx context.Context, id uint, userID uuid.UUID) (*SavingsTarget, error)
+	Create(ctx context.Context, t *SavingsTarget) error
+	Update(ctx context.Context, t *SavingsTarget) error
+	Delete(ctx context.Context, id uint, userID uuid.UUID) error
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session is a login in a device. Access tokens are short-lived and tied to a session,
+// which is kept alive by rotating refresh tokens. Only the SHA-256 of the tokens is stored.
+type Session struct {
+	ID               uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	UserID           uuid.UUID `gorm:"type:uuid;index"`
+	RefreshTokenHash string    `gorm:"uniqueIndex"`
+	// PreviousTokenHash is the refresh token replaced by the last rotation, receiving it
+	// again means the token leaked, so the session is revoked
+	PreviousTokenHash string `gorm:"index"`
+	UserAgent         string
+	ExpiresAt         time.Time
+	LastUsedAt        time.Time
+	RevokedAt         *time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+// IsActive reports whether the session can still be used
+func (s *Session) IsActive() bool {
+	return s.RevokedAt == nil && time.Now().UTC().Before(s.ExpiresAt)
+}
+
+type SessionRepo interface {
+	Create(ctx context.Context, s *Session) error
+	Get(ctx context.Context, id uuid.UUID) (*Session, error)
+	GetByRefreshTokenHash(ctx context.Context, hash string) (*Session, error)
+	GetByPreviousTokenHash(ctx context.Context, hash string) (*Session, error)
+	// Rotate replaces the refresh token of the session, failing if it was rotated concurrently
+	Rotate(ctx context.Context, s *Session, newHash string, expiresAt time.Time) error
+	Revoke(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+	RevokeAll(ctx context.Context, userID uuid.UUID) error
+	RevokeOthers(ctx context.Context, userID uuid.UUID, keepID uuid.UUID) error
+}
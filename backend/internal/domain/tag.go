@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tag labels expenses across goals, e.g. "Car" or "Trip to Lisbon"
+type Tag struct {
+	ID     uint      `gorm:"primaryKey;autoIncrement"`
+	Name   string    `gorm:"uniqueIndex:idx_tags_user_id_name,priority:2"`
+	UserID uuid.UUID `gorm:"type:uuid;uniqueIndex:idx_tags_user_id_name,priority:1"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	User User `gorm:"foreignKey:UserID"`
+}
+
+type TagDTO struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// TagMonthlyTotal is the sum of the expenses with a tag in a month
+type TagMonthlyTotal struct {
+	TagID uint
+	Name  string
+	Date  time.Time
+	Total int64
+}
+
+func (t *Tag) ToDTO() TagDTO {
+	return TagDTO{ID: t.ID, Name: t.Name}
+}
+
+type TagRepo interface {
+	All(ctx context.Context, userID uuid.UUID) ([]Tag, error)
+	Get(ctx context.Context, id uint, userID uuid.UUID) (*Tag, error)
+	GetMany(ctx context.Context, ids []uint, userID uuid.UUID) ([]Tag, error)
+	Create(ctx context.Context, t *Tag) error
+	Update(ctx context.Context, t *Tag) error
+	Delete(ctx context.Context, id uint, userID uuid.UUID) error
+	// Merge moves the expenses of source to target and deletes source
+	Merge(ctx context.Context, source *Tag, target *Tag) error
+	// MonthlyTotals returns the totals of each tag by month for expenses dated from "from" (inclusive) up to "to" (exclusive)
+	MonthlyTotals(ctx context.Context, from time.Time, to time.Time, userID uuid.UUID) ([]TagMonthlyTotal, error)
+}
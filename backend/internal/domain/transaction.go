@@ -0,0 +1,11 @@
+package domain
+
+import "context"
+
+// Transactor runs fn in a database transaction, repositories called with the ctx given
+// to fn take part in it. The transaction is rolled back when fn returns an error.
+type Transactor interface {
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+	// ReadOnlyTransaction is like Transaction, but every read in fn sees the same snapshot
+	ReadOnlyTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
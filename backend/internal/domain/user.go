@@ -11,15 +11,42 @@ type User struct {
 	ID           uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
 	Email        string    `gorm:"type:citext"`
 	HashPassword string
+	VerifiedAt   *time.Time
+
+	// TOTPSecret is set on enrollment and only enforced on login once TOTPEnabledAt is set
+	TOTPSecret    string
+	TOTPEnabledAt *time.Time
+	// TOTPLastStep is the time step of the last accepted code, so a code can't be replayed
+	TOTPLastStep int64
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
 
+// RecoveryCode is a single use code that replaces a TOTP code when the authenticator is lost
+type RecoveryCode struct {
+	ID       uint      `gorm:"primaryKey;autoIncrement"`
+	UserID   uuid.UUID `gorm:"type:uuid;index"`
+	CodeHash string
+	UsedAt   *time.Time
+
+	CreatedAt time.Time
+
+	User User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+type TokenPurpose string
+
+const (
+	PasswordResetToken     TokenPurpose = "password_reset"
+	EmailVerificationToken TokenPurpose = "email_verification"
+)
+
 type UserToken struct {
-	ID        uint      `gorm:"primaryKey;autoIncrement"`
-	UserID    uuid.UUID `gorm:"type:uuid"`
-	Token     uuid.UUID `gorm:"type:uuid;default:gen_random_uuid()"`
+	ID        uint         `gorm:"primaryKey;autoIncrement"`
+	UserID    uuid.UUID    `gorm:"type:uuid"`
+	Token     uuid.UUID    `gorm:"type:uuid;default:gen_random_uuid()"`
+	Purpose   TokenPurpose `gorm:"not null;default:password_reset"`
 	ExpiresAt time.Time
 	Used      bool
 
@@ -30,23 +57,43 @@ type UserToken struct {
 }
 
 type UserDTO struct {
-	ID    uuid.UUID `json:"id"`
-	Email string    `json:"email"`
+	ID       uuid.UUID `json:"id"`
+	Email    string    `json:"email"`
+	Verified bool      `json:"verified"`
+}
+
+func (u *User) IsVerified() bool {
+	return u.VerifiedAt != nil
+}
+
+func (u *User) TwoFactorEnabled() bool {
+	return u.TOTPEnabledAt != nil
 }
 
 func (u *User) ToDTO() UserDTO {
 	return UserDTO{
-		ID:    u.ID,
-		Email: u.Email,
+		ID:       u.ID,
+		Email:    u.Email,
+		Verified: u.IsVerified(),
 	}
 }
 
 type UserRepo interface {
 	Create(ctx context.Context, user *User, salary *Salary) error
+	All(ctx context.Context) ([]User, error)
 	Get(ctx context.Context, id uuid.UUID) (*User, error)
 	GetByEmail(ctx context.Context, email string) (*User, error)
 	UpdateUserPassword(ctx context.Context, userID uuid.UUID, hashedPassword string) error
+	UpdateEmail(ctx context.Context, userID uuid.UUID, email string) error
+	Delete(ctx context.Context, userID uuid.UUID) error
+	MarkAsVerified(ctx context.Context, userID uuid.UUID) error
 	CreateToken(ctx context.Context, token *UserToken) error
-	GetUserTokenByToken(ctx context.Context, token string) (*UserToken, error)
+	GetUserTokenByToken(ctx context.Context, token string, purpose TokenPurpose) (*UserToken, error)
 	MarkTokenAsUsed(ctx context.Context, tokenID uint) error
+	SetTOTPSecret(ctx context.Context, userID uuid.UUID, secret string) error
+	EnableTOTP(ctx context.Context, userID uuid.UUID, step int64, recoveryCodeHashes []string) error
+	DisableTOTP(ctx context.Context, userID uuid.UUID) error
+	UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error
+	CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int64, error)
 }
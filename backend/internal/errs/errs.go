@@ -8,6 +8,9 @@ import (
 var (
 	ErrInvalidCredentials = errors.New("invalid credentials")
 	ErrInvalidToken       = errors.New("invalid or expired token")
+	ErrAlreadyVerified    = errors.New("email already verified")
+	ErrInvalidCode        = errors.New("invalid code")
+	ErrForbidden          = errors.New("you don't have permission to do this")
 )
 
 type ErrNotFound struct {
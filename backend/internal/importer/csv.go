@@ -0,0 +1,174 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joaopsramos/fincon/internal/errs"
+	"github.com/joaopsramos/fincon/internal/service"
+)
+
+type CSVOptions struct {
+	// Columns can be referenced by header name or by zero based index
+	DateColumn  string
+	NameColumn  string
+	ValueColumn string
+
+	Delimiter        rune
+	HasHeader        bool
+	DateFormat       string
+	DecimalSeparator string
+	// DebitsPositive tells that the statement lists debits as positive values,
+	// by default debits are expected to be negative as in OFX files
+	DebitsPositive bool
+	GoalID         int
+}
+
+func DefaultCSVOptions() CSVOptions {
+	return CSVOptions{
+		DateColumn:       "date",
+		NameColumn:       "name",
+		ValueColumn:      "value",
+		Delimiter:        ',',
+		HasHeader:        true,
+		DateFormat:       "YYYY-MM-DD",
+		DecimalSeparator: ".",
+	}
+}
+
+// ParseCSV parses a bank statement in CSV format. Only debits are imported as expenses,
+// credits and zero rows are skipped.
+func ParseCSV(r io.Reader, opts CSVOptions) ([]service.CreateExpenseDTO, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = opts.Delimiter
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, errs.NewValidationErrorF("invalid csv file: %s", parseErr.Error())
+		}
+
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return []service.CreateExpenseDTO{}, nil
+	}
+
+	var header []string
+	if opts.HasHeader {
+		header, records = records[0], records[1:]
+	}
+
+	dateIdx, err := columnIndex(header, opts.DateColumn)
+	if err != nil {
+		return nil, err
+	}
+
+	nameIdx, err := columnIndex(header, opts.NameColumn)
+	if err != nil {
+		return nil, err
+	}
+
+	valueIdx, err := columnIndex(header, opts.ValueColumn)
+	if err != nil {
+		return nil, err
+	}
+
+	layout := DateLayout(opts.DateFormat)
+	lastIdx := max(dateIdx, nameIdx, valueIdx)
+	firstLine := 1
+	if opts.HasHeader {
+		firstLine = 2
+	}
+
+	dtos := make([]service.CreateExpenseDTO, 0, len(records))
+	for i, record := range records {
+		line := i + firstLine
+
+		if isBlank(record) {
+			continue
+		}
+
+		if len(record) <= lastIdx {
+			return nil, errs.NewValidationErrorF("line %d: expected at least %d columns", line, lastIdx+1)
+		}
+
+		date, err := time.Parse(layout, strings.TrimSpace(record[dateIdx]))
+		if err != nil {
+			return nil, errs.NewValidationErrorF("line %d: invalid date %q", line, record[dateIdx])
+		}
+
+		value, err := parseDecimal(record[valueIdx], opts.DecimalSeparator)
+		if err != nil {
+			return nil, errs.NewValidationErrorF("line %d: invalid value %q", line, record[valueIdx])
+		}
+
+		if opts.DebitsPositive {
+			value = -value
+		}
+
+		if value >= 0 {
+			continue
+		}
+
+		dtos = append(dtos, newExpenseDTO(record[nameIdx], math.Abs(value), date, opts.GoalID))
+	}
+
+	return dtos, nil
+}
+
+func columnIndex(header []string, column string) (int, error) {
+	column = strings.TrimSpace(column)
+
+	for i, h := range header {
+		if strings.EqualFold(strings.TrimSpace(h), column) {
+			return i, nil
+		}
+	}
+
+	if idx, err := strconv.Atoi(column); err == nil && idx >= 0 {
+		return idx, nil
+	}
+
+	return 0, errs.NewValidationErrorF("column %q not found", column)
+}
+
+func parseDecimal(value string, decimalSeparator string) (float64, error) {
+	// Drop currency symbols and spaces, e.g. "R$ 1.234,56"
+	value = strings.Map(func(r rune) rune {
+		if (r >= '0' && r <= '9') || strings.ContainsRune(".,-+", r) {
+			return r
+		}
+
+		return -1
+	}, value)
+
+	thousandsSeparator := ","
+	if decimalSeparator == "," {
+		thousandsSeparator = "."
+	}
+
+	value = strings.ReplaceAll(value, thousandsSeparator, "")
+	value = strings.Replace(value, decimalSeparator, ".", 1)
+
+	return strconv.ParseFloat(value, 64)
+}
+
+func isBlank(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+
+	return true
+}
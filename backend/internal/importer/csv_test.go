@@ -0,0 +1,131 @@
+package importer_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joaopsramos/fincon/internal/errs"
+	"github.com/joaopsramos/fincon/internal/importer"
+	"github.com/joaopsramos/fincon/internal/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCSV(t *testing.T) {
+	t.Parallel()
+
+	date := func(day int) time.Time { return time.Date(2025, 1, day, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name    string
+		content string
+		opts    func(o *importer.CSVOptions)
+		want    []service.CreateExpenseDTO
+		err     error
+	}{
+		{
+			"default options",
+			"date,name,value\n2025-01-05,Market,-120.50\n2025-01-06, Gas  station ,-80\n2025-01-07,Salary,5000\n",
+			nil,
+			[]service.CreateExpenseDTO{
+				{Name: "Market", Value: 120.5, Date: date(5), GoalID: 1},
+				{Name: "Gas station", Value: 80, Date: date(6), GoalID: 1},
+			},
+			nil,
+		},
+		{
+			"debits as positive values",
+			"date,name,value\n2025-01-05,Market,120.50\n2025-01-06,Refund,-30\n",
+			func(o *importer.CSVOptions) { o.DebitsPositive = true },
+			[]service.CreateExpenseDTO{
+				{Name: "Market", Value: 120.5, Date: date(5), GoalID: 1},
+			},
+			nil,
+		},
+		{
+			"custom columns, delimiter, date format and decimal separator",
+			"Data;Descrição;Valor\n05/01/2025;Mercado;R$ 1.234,56\n06/01/2025;Estorno;0,00\n\n",
+			func(o *importer.CSVOptions) {
+				o.DateColumn, o.NameColumn, o.ValueColumn = "data", "descrição", "valor"
+				o.Delimiter = ';'
+				o.DateFormat = "DD/MM/YYYY"
+				o.DecimalSeparator = ","
+				o.DebitsPositive = true
+			},
+			[]service.CreateExpenseDTO{
+				{Name: "Mercado", Value: 1234.56, Date: date(5), GoalID: 1},
+			},
+			nil,
+		},
+		{
+			"columns by index without header",
+			"Market,-10.00,2025-01-05\n",
+			func(o *importer.CSVOptions) {
+				o.HasHeader = false
+				o.NameColumn, o.ValueColumn, o.DateColumn = "0", "1", "2"
+			},
+			[]service.CreateExpenseDTO{
+				{Name: "Market", Value: 10, Date: date(5), GoalID: 1},
+			},
+			nil,
+		},
+		{
+			"unknown column",
+			"date,description,value\n",
+			nil,
+			nil,
+			errs.NewValidationError(`column "name" not found`),
+		},
+		{
+			"invalid date",
+			"date,name,value\n2025-01-05,Market,10\n05/01/2025,Market,10\n",
+			nil,
+			nil,
+			errs.NewValidationError(`line 3: invalid date "05/01/2025"`),
+		},
+		{
+			"invalid value",
+			"date,name,value\n2025-01-05,Market,ten\n",
+			nil,
+			nil,
+			errs.NewValidationError(`line 2: invalid value "ten"`),
+		},
+		{
+			"missing columns",
+			"date,name,value\n2025-01-05,Market\n",
+			nil,
+			nil,
+			errs.NewValidationError("line 2: expected at least 3 columns"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert := assert.New(t)
+
+			opts := importer.DefaultCSVOptions()
+			opts.GoalID = 1
+			if tt.opts != nil {
+				tt.opts(&opts)
+			}
+
+			dtos, err := importer.ParseCSV(strings.NewReader(tt.content), opts)
+			if tt.err != nil {
+				assert.Equal(tt.err, err)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal(tt.want, dtos)
+		})
+	}
+}
+
+func TestDateLayout(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	assert.Equal("2006-01-02", importer.DateLayout("YYYY-MM-DD"))
+	assert.Equal("02/01/06", importer.DateLayout("DD/MM/YY"))
+}
@@ -0,0 +1,37 @@
+package importer
+
+import (
+	"strings"
+	"time"
+
+	"github.com/joaopsramos/fincon/internal/service"
+)
+
+type Format string
+
+const (
+	CSV Format = "csv"
+	OFX Format = "ofx"
+)
+
+// dateFormatReplacer converts user friendly date formats (e.g. DD/MM/YYYY) to Go layouts
+var dateFormatReplacer = strings.NewReplacer(
+	"YYYY", "2006",
+	"YY", "06",
+	"MM", "01",
+	"DD", "02",
+)
+
+// DateLayout converts a user friendly date format like "DD/MM/YYYY" into a Go time layout.
+func DateLayout(format string) string {
+	return dateFormatReplacer.Replace(format)
+}
+
+func newExpenseDTO(name string, value float64, date time.Time, goalID int) service.CreateExpenseDTO {
+	return service.CreateExpenseDTO{
+		Name:   strings.Join(strings.Fields(name), " "),
+		Value:  value,
+		Date:   time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC),
+		GoalID: goalID,
+	}
+}
@@ -0,0 +1,98 @@
+package importer
+
+import (
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/joaopsramos/fincon/internal/errs"
+	"github.com/joaopsramos/fincon/internal/service"
+)
+
+var (
+	ofxTransactionStartRegex = regexp.MustCompile(`(?i)<STMTTRN>`)
+	ofxTransactionEndRegex   = regexp.MustCompile(`(?i)</STMTTRN>|</BANKTRANLIST>`)
+	ofxFieldRegex            = regexp.MustCompile(`(?i)<(\w+)>([^<\r\n]*)`)
+)
+
+// ParseOFX parses a bank statement in OFX format, both the SGML (1.x) and XML (2.x)
+// flavors. Only debits are imported, credits such as salary deposits are skipped.
+func ParseOFX(r io.Reader, goalID int) ([]service.CreateExpenseDTO, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	text := decodeOFX(content)
+	if !strings.Contains(strings.ToUpper(text), "<OFX>") {
+		return nil, errs.NewValidationError("invalid ofx file")
+	}
+
+	// SGML files don't close their tags, so each transaction goes until the next one starts
+	transactions := ofxTransactionStartRegex.Split(text, -1)[1:]
+
+	dtos := make([]service.CreateExpenseDTO, 0, len(transactions))
+	for i, t := range transactions {
+		if loc := ofxTransactionEndRegex.FindStringIndex(t); loc != nil {
+			t = t[:loc[0]]
+		}
+
+		fields := make(map[string]string)
+		for _, f := range ofxFieldRegex.FindAllStringSubmatch(t, -1) {
+			fields[strings.ToUpper(f[1])] = strings.TrimSpace(f[2])
+		}
+
+		date, err := parseOFXDate(fields["DTPOSTED"])
+		if err != nil {
+			return nil, errs.NewValidationErrorF("transaction %d: invalid date %q", i+1, fields["DTPOSTED"])
+		}
+
+		// Some banks use a comma as decimal separator
+		value, err := strconv.ParseFloat(strings.Replace(fields["TRNAMT"], ",", ".", 1), 64)
+		if err != nil {
+			return nil, errs.NewValidationErrorF("transaction %d: invalid amount %q", i+1, fields["TRNAMT"])
+		}
+
+		if value >= 0 {
+			continue
+		}
+
+		name := fields["NAME"]
+		if name == "" {
+			name = fields["MEMO"]
+		}
+
+		dtos = append(dtos, newExpenseDTO(name, math.Abs(value), date, goalID))
+	}
+
+	return dtos, nil
+}
+
+// decodeOFX returns the file content as UTF-8. Files that aren't valid UTF-8 are
+// assumed to be Latin-1 (CHARSET:1252), which is what most banks still emit.
+func decodeOFX(content []byte) string {
+	if utf8.Valid(content) {
+		return string(content)
+	}
+
+	runes := make([]rune, len(content))
+	for i, b := range content {
+		runes[i] = rune(b)
+	}
+
+	return string(runes)
+}
+
+// parseOFXDate parses dates like 20250115, 20250115120000 or 20250115120000.000[-3:BRT],
+// only the date part matters
+func parseOFXDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, errs.NewValidationError("invalid date")
+	}
+
+	return time.Parse("20060102", value[:8])
+}
@@ -0,0 +1,118 @@
+package importer_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joaopsramos/fincon/internal/errs"
+	"github.com/joaopsramos/fincon/internal/importer"
+	"github.com/joaopsramos/fincon/internal/service"
+	"github.com/stretchr/testify/assert"
+)
+
+const sgmlOFX = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+CHARSET:1252
+
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<BANKTRANLIST>
+<DTSTART>20250101
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20250105120000[-3:BRT]
+<TRNAMT>-120,50
+<FITID>1
+<MEMO>Market
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20250106
+<TRNAMT>5000.00
+<FITID>2
+<MEMO>Salary
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20250107
+<TRNAMT>-80.00
+<FITID>3
+<NAME>Gas   station
+<MEMO>Card 1234
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>
+`
+
+const xmlOFX = `<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="220"?>
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS><BANKTRANLIST>
+<STMTTRN><TRNTYPE>DEBIT</TRNTYPE><DTPOSTED>20250105</DTPOSTED><TRNAMT>-12.34</TRNAMT><NAME>Café</NAME></STMTTRN>
+</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>
+`
+
+func TestParseOFX(t *testing.T) {
+	t.Parallel()
+
+	date := func(day int) time.Time { return time.Date(2025, 1, day, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name    string
+		content []byte
+		want    []service.CreateExpenseDTO
+		err     error
+	}{
+		{
+			"sgml imports only debits",
+			[]byte(sgmlOFX),
+			[]service.CreateExpenseDTO{
+				{Name: "Market", Value: 120.5, Date: date(5), GoalID: 1},
+				{Name: "Gas station", Value: 80, Date: date(7), GoalID: 1},
+			},
+			nil,
+		},
+		{
+			"xml",
+			[]byte(xmlOFX),
+			[]service.CreateExpenseDTO{{Name: "Café", Value: 12.34, Date: date(5), GoalID: 1}},
+			nil,
+		},
+		{
+			"latin-1 content",
+			bytes.Replace([]byte(xmlOFX), []byte("Café"), []byte("Caf\xe9"), 1),
+			[]service.CreateExpenseDTO{{Name: "Café", Value: 12.34, Date: date(5), GoalID: 1}},
+			nil,
+		},
+		{
+			"not an ofx file",
+			[]byte("date,name,value\n"),
+			nil,
+			errs.NewValidationError("invalid ofx file"),
+		},
+		{
+			"invalid amount",
+			[]byte(strings.Replace(xmlOFX, "-12.34", "abc", 1)),
+			nil,
+			errs.NewValidationError(`transaction 1: invalid amount "abc"`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert := assert.New(t)
+
+			dtos, err := importer.ParseOFX(bytes.NewReader(tt.content), 1)
+			if tt.err != nil {
+				assert.Equal(tt.err, err)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal(tt.want, dtos)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package mail
 
 import (
 	"bytes"
+	"context"
 	"html/template"
 	"path/filepath"
 
@@ -16,9 +17,20 @@ type (
 )
 
 const (
-	ForgotPasswordTemplate EmailTemplate = "forgot_password"
+	ForgotPasswordTemplate      EmailTemplate = "forgot_password"
+	VerifyEmailTemplate         EmailTemplate = "verify_email"
+	AccountDeletedTemplate      EmailTemplate = "account_deleted"
+	HouseholdInvitationTemplate EmailTemplate = "household_invitation"
+	BudgetDigestTemplate        EmailTemplate = "budget_digest"
+	GoalAlertTemplate           EmailTemplate = "goal_alert"
 
-	ForgotPasswordSubject EmailSubject = "[Fincon] Recuperação de senha"
+	ForgotPasswordSubject      EmailSubject = "[Fincon] Recuperação de senha"
+	VerifyEmailSubject         EmailSubject = "[Fincon] Confirme seu e-mail"
+	AccountDeletedSubject      EmailSubject = "[Fincon] Sua conta foi excluída"
+	HouseholdInvitationSubject EmailSubject = "[Fincon] Você foi convidado para um orçamento compartilhado"
+	MonthlyDigestSubject       EmailSubject = "[Fincon] Seu resumo mensal"
+	WeeklyDigestSubject        EmailSubject = "[Fincon] Seu resumo semanal"
+	GoalAlertSubject           EmailSubject = "[Fincon] Alerta de orçamento"
 )
 
 type Email struct {
@@ -30,7 +42,7 @@ type Email struct {
 }
 
 type Mailer interface {
-	Send(email Email) error
+	Send(ctx context.Context, email Email) error
 }
 
 func NewMailer() Mailer {
@@ -40,8 +52,9 @@ func NewMailer() Mailer {
 	case config.MailPit:
 		return NewMailPit(mailConfig.Defaults)
 	case config.SES:
-		// TODO: implement
-		return &SES{}
+		return NewSES(mailConfig.SES, mailConfig.Defaults)
+	case config.SMTP:
+		return NewSMTP(mailConfig.SMTP, mailConfig.Defaults)
 	default:
 		panic("invalid mail driver")
 	}
@@ -65,3 +78,12 @@ func (m *Email) BuildBody() (string, error) {
 
 	return htmlBuffer.String(), nil
 }
+
+// sender falls back to the default sender when the email doesn't set one
+func (m *Email) sender(defaults config.MailDefaults) types.MailContact {
+	if m.From.Email == "" {
+		return defaults.From
+	}
+
+	return m.From
+}
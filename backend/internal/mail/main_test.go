@@ -0,0 +1,17 @@
+package mail_test
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	// Templates are resolved from the project root
+	if err := os.Chdir(filepath.Join("..", "..")); err != nil {
+		log.Fatal(err)
+	}
+
+	os.Exit(m.Run())
+}
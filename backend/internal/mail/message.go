@@ -0,0 +1,131 @@
+package mail
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	netmail "net/mail"
+	"net/textproto"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/joaopsramos/fincon/internal/types"
+)
+
+var (
+	htmlIgnoredBlockRe = regexp.MustCompile(`(?is)<(style|script|head)[^>]*>.*?</(style|script|head)>`)
+	htmlLinkRe         = regexp.MustCompile(`(?is)<a\s[^>]*href="([^"]*)"[^>]*>(.*?)</a>`)
+	htmlLineBreakRe    = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|h[1-6]|li|tr)>`)
+	htmlTagRe          = regexp.MustCompile(`(?s)<[^>]*>`)
+	blankLinesRe       = regexp.MustCompile(`\n{3,}`)
+)
+
+// buildMessage renders the email as a multipart/alternative message, with a plain
+// text version derived from the HTML body
+func buildMessage(email Email, from types.MailContact, now time.Time) ([]byte, error) {
+	body, err := email.BuildBody()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	headers := []struct{ key, value string }{
+		{"From", formatAddress(from)},
+		{"To", formatAddress(email.To)},
+		{"Subject", mime.QEncoding.Encode("utf-8", string(email.Subject))},
+		{"Date", now.Format(time.RFC1123Z)},
+		{"Message-ID", newMessageID(from.Email)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", fmt.Sprintf("multipart/alternative; boundary=%q", writer.Boundary())},
+	}
+
+	var message bytes.Buffer
+	for _, h := range headers {
+		fmt.Fprintf(&message, "%s: %s\r\n", h.key, h.value)
+	}
+	message.WriteString("\r\n")
+
+	if err := writePart(writer, "text/plain", htmlToText(body)); err != nil {
+		return nil, err
+	}
+
+	if err := writePart(writer, "text/html", body); err != nil {
+		return nil, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	message.Write(buf.Bytes())
+
+	return message.Bytes(), nil
+}
+
+func writePart(writer *multipart.Writer, contentType string, content string) error {
+	part, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {contentType + "; charset=UTF-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return err
+	}
+
+	qp := quotedprintable.NewWriter(part)
+	if _, err := qp.Write([]byte(content)); err != nil {
+		return err
+	}
+
+	return qp.Close()
+}
+
+// htmlToText strips the markup of an email body, keeping paragraphs and links readable
+func htmlToText(body string) string {
+	text := htmlIgnoredBlockRe.ReplaceAllString(body, "")
+	text = htmlLinkRe.ReplaceAllStringFunc(text, func(link string) string {
+		m := htmlLinkRe.FindStringSubmatch(link)
+		return strings.Join(strings.Fields(m[2]), " ") + ": " + m[1]
+	})
+	text = htmlLineBreakRe.ReplaceAllString(text, "\n")
+	text = htmlTagRe.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.Join(strings.Fields(line), " ")
+	}
+
+	text = strings.Join(lines, "\n")
+	text = blankLinesRe.ReplaceAllString(text, "\n\n")
+
+	return strings.TrimSpace(text) + "\n"
+}
+
+// formatAddress builds a "Name <email>" address, encoding non ASCII names as RFC 2047 requires
+func formatAddress(contact types.MailContact) string {
+	if contact.Name == "" {
+		return contact.Email
+	}
+
+	return (&netmail.Address{Name: contact.Name, Address: contact.Email}).String()
+}
+
+func newMessageID(sender string) string {
+	_, domain, ok := strings.Cut(sender, "@")
+	if !ok || domain == "" {
+		domain = "localhost"
+	}
+
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(b), domain)
+}
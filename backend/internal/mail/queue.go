@@ -0,0 +1,161 @@
+package mail
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/types"
+)
+
+const (
+	DefaultMaxAttempts  = 8
+	DefaultPollInterval = 5 * time.Second
+
+	backoffBase = 30 * time.Second
+	backoffMax  = 2 * time.Hour
+	// claimLease is how long a claimed email is hidden from other workers while it's sent
+	claimLease = 5 * time.Minute
+	batchSize  = 20
+)
+
+// QueuedMailer stores emails in the outbox instead of sending them, the Worker delivers
+// them in background
+type QueuedMailer struct {
+	outbox domain.OutboxRepo
+}
+
+func NewQueuedMailer(outbox domain.OutboxRepo) Mailer {
+	return &QueuedMailer{outbox: outbox}
+}
+
+// Send enqueues the email, within the transaction of ctx when there is one, so the email
+// is only delivered if the changes it's about are committed
+func (m *QueuedMailer) Send(ctx context.Context, email Email) error {
+	return m.outbox.Enqueue(ctx, &domain.OutboxEmail{
+		ToName:    email.To.Name,
+		ToEmail:   email.To.Email,
+		FromName:  email.From.Name,
+		FromEmail: email.From.Email,
+		Subject:   string(email.Subject),
+		Template:  string(email.Template),
+		Data:      email.Data,
+		Status:    domain.OutboxPending,
+	})
+}
+
+type WorkerOpts struct {
+	MaxAttempts  int
+	PollInterval time.Duration
+}
+
+// Worker delivers the emails of the outbox, retrying failures with exponential backoff
+// until MaxAttempts, when they are moved to the dead letter
+type Worker struct {
+	outbox domain.OutboxRepo
+	mailer Mailer
+	logger *slog.Logger
+	opts   WorkerOpts
+	now    func() time.Time
+}
+
+func NewWorker(outbox domain.OutboxRepo, mailer Mailer, logger *slog.Logger, opts WorkerOpts) *Worker {
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = DefaultMaxAttempts
+	}
+
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &Worker{outbox: outbox, mailer: mailer, logger: logger, opts: opts, now: time.Now}
+}
+
+// Run processes the outbox until ctx is cancelled
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := w.ProcessBatch(ctx)
+			if err != nil {
+				w.logger.Error("Failed to process the mail outbox", "error", err)
+			}
+
+			// Keep going while the batches are full, there may be more emails due
+			if err != nil || n < batchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessBatch sends the emails that are due, returning how many were claimed
+func (w *Worker) ProcessBatch(ctx context.Context) (int, error) {
+	emails, err := w.outbox.ClaimDue(ctx, w.now().UTC(), claimLease, batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, e := range emails {
+		if err := w.deliver(ctx, e); err != nil {
+			return len(emails), err
+		}
+	}
+
+	return len(emails), nil
+}
+
+func (w *Worker) deliver(ctx context.Context, e domain.OutboxEmail) error {
+	sendErr := w.mailer.Send(ctx, Email{
+		To:       types.MailContact{Name: e.ToName, Email: e.ToEmail},
+		From:     types.MailContact{Name: e.FromName, Email: e.FromEmail},
+		Subject:  EmailSubject(e.Subject),
+		Template: EmailTemplate(e.Template),
+		Data:     e.Data,
+	})
+
+	if sendErr == nil {
+		return w.outbox.MarkSent(ctx, e.ID)
+	}
+
+	attempts := e.Attempts + 1
+	if attempts >= w.opts.MaxAttempts {
+		w.logger.Error("Giving up on email", "id", e.ID, "template", e.Template, "attempts", attempts, "error", sendErr)
+		return w.outbox.MarkFailed(ctx, e.ID, attempts, nil, sendErr.Error())
+	}
+
+	next := w.now().UTC().Add(Backoff(attempts))
+	w.logger.Warn("Failed to send email, retrying", "id", e.ID, "attempts", attempts, "next_attempt_at", next, "error", sendErr)
+
+	return w.outbox.MarkFailed(ctx, e.ID, attempts, &next, sendErr.Error())
+}
+
+// Backoff returns how long to wait before retrying an email that failed the given number of times
+func Backoff(attempts int) time.Duration {
+	if attempts < 1 {
+		return backoffBase
+	}
+
+	delay := backoffBase
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= backoffMax {
+			return backoffMax
+		}
+	}
+
+	return delay
+}
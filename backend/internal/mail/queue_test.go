@@ -0,0 +1,197 @@
+package mail_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/mail"
+	"github.com/joaopsramos/fincon/internal/testhelper"
+	"github.com/joaopsramos/fincon/internal/types"
+	"github.com/joaopsramos/fincon/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueuedMailer_Send(t *testing.T) {
+	t.Parallel()
+	outbox := testhelper.NewMockOutboxRepo(t)
+
+	email := mail.Email{
+		To:       types.MailContact{Name: "João", Email: "joao@mail.com"},
+		Subject:  mail.VerifyEmailSubject,
+		Template: mail.VerifyEmailTemplate,
+		Data:     util.M{"Link": "http://link"},
+	}
+
+	outbox.EXPECT().Enqueue(mock.Anything, &domain.OutboxEmail{
+		ToName:   "João",
+		ToEmail:  "joao@mail.com",
+		Subject:  string(mail.VerifyEmailSubject),
+		Template: string(mail.VerifyEmailTemplate),
+		Data:     util.M{"Link": "http://link"},
+		Status:   domain.OutboxPending,
+	}).Return(nil).Once()
+
+	assert.NoError(t, mail.NewQueuedMailer(outbox).Send(context.Background(), email))
+}
+
+func TestWorker_ProcessBatch(t *testing.T) {
+	t.Parallel()
+
+	queued := domain.OutboxEmail{
+		ID:        1,
+		ToEmail:   "joao@mail.com",
+		FromName:  "Suporte",
+		FromEmail: "suporte@fincon.com",
+		Subject:   string(mail.VerifyEmailSubject),
+		Template:  string(mail.VerifyEmailTemplate),
+		Data:      util.M{"Link": "http://link"},
+	}
+
+	expectedEmail := mail.Email{
+		To:       types.MailContact{Email: "joao@mail.com"},
+		From:     types.MailContact{Name: "Suporte", Email: "suporte@fincon.com"},
+		Subject:  mail.VerifyEmailSubject,
+		Template: mail.VerifyEmailTemplate,
+		Data:     util.M{"Link": "http://link"},
+	}
+
+	t.Run("mark sent emails", func(t *testing.T) {
+		t.Parallel()
+		outbox := testhelper.NewMockOutboxRepo(t)
+		mailer := testhelper.NewMockMailer(t)
+
+		outbox.EXPECT().ClaimDue(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]domain.OutboxEmail{queued}, nil).Once()
+		mailer.EXPECT().Send(mock.Anything, expectedEmail).Return(nil).Once()
+		outbox.EXPECT().MarkSent(mock.Anything, uint(1)).Return(nil).Once()
+
+		n, err := mail.NewWorker(outbox, mailer, nil, mail.WorkerOpts{}).ProcessBatch(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("retry failed emails with backoff", func(t *testing.T) {
+		t.Parallel()
+		outbox := testhelper.NewMockOutboxRepo(t)
+		mailer := testhelper.NewMockMailer(t)
+
+		failed := queued
+		failed.Attempts = 2
+
+		outbox.EXPECT().ClaimDue(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]domain.OutboxEmail{failed}, nil).Once()
+		mailer.EXPECT().Send(mock.Anything, expectedEmail).Return(errors.New("connection refused")).Once()
+		outbox.EXPECT().MarkFailed(mock.Anything, uint(1), 3, mock.MatchedBy(func(next *time.Time) bool {
+			return next != nil && next.Sub(time.Now().UTC().Add(2*time.Minute)).Abs() < 5*time.Second
+		}), "connection refused").Return(nil).Once()
+
+		_, err := mail.NewWorker(outbox, mailer, nil, mail.WorkerOpts{}).ProcessBatch(context.Background())
+		assert.NoError(t, err)
+	})
+
+	t.Run("move emails to the dead letter after max attempts", func(t *testing.T) {
+		t.Parallel()
+		outbox := testhelper.NewMockOutboxRepo(t)
+		mailer := testhelper.NewMockMailer(t)
+
+		failed := queued
+		failed.Attempts = 2
+
+		outbox.EXPECT().ClaimDue(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]domain.OutboxEmail{failed}, nil).Once()
+		mailer.EXPECT().Send(mock.Anything, expectedEmail).Return(errors.New("mailbox unavailable")).Once()
+		outbox.EXPECT().MarkFailed(mock.Anything, uint(1), 3, (*time.Time)(nil), "mailbox unavailable").Return(nil).Once()
+
+		_, err := mail.NewWorker(outbox, mailer, nil, mail.WorkerOpts{MaxAttempts: 3}).ProcessBatch(context.Background())
+		assert.NoError(t, err)
+	})
+
+	t.Run("return claim errors", func(t *testing.T) {
+		t.Parallel()
+		outbox := testhelper.NewMockOutboxRepo(t)
+
+		outbox.EXPECT().ClaimDue(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db error")).Once()
+
+		n, err := mail.NewWorker(outbox, testhelper.NewMockMailer(t), nil, mail.WorkerOpts{}).ProcessBatch(context.Background())
+		assert.EqualError(t, err, "db error")
+		assert.Zero(t, n)
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{6, 16 * time.Minute},
+		{8, 64 * time.Minute},
+		{9, 2 * time.Hour},
+		{50, 2 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, mail.Backoff(tt.attempts), "attempts %d", tt.attempts)
+	}
+}
+
+func TestOutboxData_RendersLikeTheOriginal(t *testing.T) {
+	t.Parallel()
+
+	type goal struct {
+		Name      string
+		Spent     string
+		MustSpend string
+		Used      string
+		Over      bool
+	}
+
+	tests := []struct {
+		template mail.EmailTemplate
+		data     util.M
+	}{
+		{mail.VerifyEmailTemplate, util.M{"Link": "http://link"}},
+		{mail.GoalAlertTemplate, util.M{
+			"GoalName":  "Conforto",
+			"Threshold": 1_000_000,
+			"Period":    "janeiro de 2025",
+			"Spent":     "R$ 800,00",
+			"Limit":     "R$ 1.000,00",
+			"Link":      "http://link",
+		}},
+		{mail.BudgetDigestTemplate, util.M{
+			"Period":          "janeiro de 2025",
+			"Goals":           []goal{{"Conforto", "R$ 1.200,00", "R$ 1.000,00", "120%", true}, {"Metas", "R$ 10,00", "R$ 500,00", "2%", false}},
+			"Spent":           "R$ 1.210,00",
+			"Remaining":       "R$ 290,00",
+			"Link":            "http://link",
+			"UnsubscribeLink": "http://link/unsubscribe",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.template), func(t *testing.T) {
+			t.Parallel()
+
+			value, err := domain.OutboxData(tt.data).Value()
+			require.NoError(t, err)
+
+			var stored domain.OutboxData
+			require.NoError(t, stored.Scan(value))
+
+			want, err := (&mail.Email{Template: tt.template, Data: tt.data}).BuildBody()
+			require.NoError(t, err)
+
+			got, err := (&mail.Email{Template: tt.template, Data: stored}).BuildBody()
+			require.NoError(t, err)
+
+			assert.Equal(t, want, got)
+		})
+	}
+}
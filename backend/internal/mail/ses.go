@@ -1,7 +1,141 @@
 package mail
 
-type SES struct{}
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
 
-func (s *SES) Send(email Email) error {
-	return nil
+	"github.com/joaopsramos/fincon/internal/config"
+)
+
+const sesSendEmailPath = "/v2/email/outbound-emails"
+
+// SES sends emails through the Amazon SES v2 API
+type SES struct {
+	client   *http.Client
+	endpoint string
+	region   string
+	creds    AWSCredentials
+	defaults config.MailDefaults
+	now      func() time.Time
+}
+
+// SESError is returned when SES rejects an email
+type SESError struct {
+	StatusCode int
+	Type       string
+	Message    string
+}
+
+func (e SESError) Error() string {
+	return fmt.Sprintf("ses: %s (status %d): %s", e.Type, e.StatusCode, e.Message)
+}
+
+type sesContent struct {
+	Data    string `json:"Data"`
+	Charset string `json:"Charset"`
+}
+
+type sesSendEmailInput struct {
+	FromEmailAddress string `json:"FromEmailAddress"`
+	Destination      struct {
+		ToAddresses []string `json:"ToAddresses"`
+	} `json:"Destination"`
+	Content struct {
+		Simple struct {
+			Subject sesContent `json:"Subject"`
+			Body    struct {
+				Html sesContent `json:"Html"`
+			} `json:"Body"`
+		} `json:"Simple"`
+	} `json:"Content"`
+}
+
+func NewSES(cfg config.SESConfig, defaults config.MailDefaults) Mailer {
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://email.%s.amazonaws.com", cfg.Region)
+	}
+
+	return &SES{
+		client:   &http.Client{Timeout: 10 * time.Second},
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		region:   cfg.Region,
+		creds:    AWSCredentials{AccessKeyID: cfg.AccessKeyID, SecretAccessKey: cfg.SecretAccessKey},
+		defaults: defaults,
+		now:      time.Now,
+	}
+}
+
+func (s *SES) Send(ctx context.Context, email Email) error {
+	body, err := email.BuildBody()
+	if err != nil {
+		return err
+	}
+
+	var input sesSendEmailInput
+	input.FromEmailAddress = formatAddress(email.sender(s.defaults))
+	input.Destination.ToAddresses = []string{formatAddress(email.To)}
+	input.Content.Simple.Subject = sesContent{Data: string(email.Subject), Charset: "UTF-8"}
+	input.Content.Simple.Body.Html = sesContent{Data: body, Charset: "UTF-8"}
+
+	payload, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint+sesSendEmailPath, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	SignV4(req, payload, s.creds, "ses", s.region, s.now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("ses: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	return parseSESError(resp)
+}
+
+func parseSESError(resp *http.Response) error {
+	sesErr := SESError{StatusCode: resp.StatusCode, Type: resp.Header.Get("X-Amzn-Errortype")}
+
+	var body struct {
+		Message      string `json:"message"`
+		MessageUpper string `json:"Message"`
+	}
+
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err := json.Unmarshal(raw, &body); err == nil {
+		sesErr.Message = body.Message
+		if sesErr.Message == "" {
+			sesErr.Message = body.MessageUpper
+		}
+	}
+
+	if sesErr.Message == "" {
+		sesErr.Message = strings.TrimSpace(string(raw))
+	}
+
+	// The header may carry extra information after a colon, e.g. "MessageRejected:http://..."
+	sesErr.Type, _, _ = strings.Cut(sesErr.Type, ":")
+	if sesErr.Type == "" {
+		sesErr.Type = http.StatusText(resp.StatusCode)
+	}
+
+	return sesErr
 }
@@ -0,0 +1,120 @@
+package mail_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/joaopsramos/fincon/internal/config"
+	"github.com/joaopsramos/fincon/internal/mail"
+	"github.com/joaopsramos/fincon/internal/types"
+	"github.com/joaopsramos/fincon/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSES(t *testing.T, handler http.HandlerFunc) mail.Mailer {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return mail.NewSES(
+		config.SESConfig{AccessKeyID: "AKID", SecretAccessKey: "secret", Region: "sa-east-1", Endpoint: server.URL + "/"},
+		config.MailDefaults{From: types.MailContact{Name: "Equipe Fincon", Email: "noreply@fincon.com"}},
+	)
+}
+
+func TestSES_Send(t *testing.T) {
+	t.Parallel()
+
+	email := mail.Email{
+		To:       types.MailContact{Email: "user@example.com"},
+		Subject:  mail.ForgotPasswordSubject,
+		Template: mail.ForgotPasswordTemplate,
+		Data:     util.M{"Link": "http://localhost:3000/password/reset?token=abc"},
+	}
+
+	t.Run("send signed request", func(t *testing.T) {
+		a := assert.New(t)
+		var input map[string]any
+
+		ses := newTestSES(t, func(w http.ResponseWriter, r *http.Request) {
+			a.Equal(http.MethodPost, r.Method)
+			a.Equal("/v2/email/outbound-emails", r.URL.Path)
+			a.Equal("application/json", r.Header.Get("Content-Type"))
+			a.NotEmpty(r.Header.Get("X-Amz-Date"))
+
+			auth := r.Header.Get("Authorization")
+			a.True(strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/"), auth)
+			a.Contains(auth, "/sa-east-1/ses/aws4_request")
+			a.Contains(auth, "SignedHeaders=content-type;host;x-amz-date")
+
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(body, &input))
+
+			_, _ = w.Write([]byte(`{"MessageId":"message-id"}`))
+		})
+
+		a.NoError(ses.Send(context.Background(), email))
+
+		a.Equal(`"Equipe Fincon" <noreply@fincon.com>`, input["FromEmailAddress"])
+		a.Equal(map[string]any{"ToAddresses": []any{"user@example.com"}}, input["Destination"])
+
+		simple := input["Content"].(map[string]any)["Simple"].(map[string]any)
+		a.Equal(map[string]any{"Data": string(mail.ForgotPasswordSubject), "Charset": "UTF-8"}, simple["Subject"])
+
+		html := simple["Body"].(map[string]any)["Html"].(map[string]any)
+		a.Contains(html["Data"], "http://localhost:3000/password/reset?token=abc")
+	})
+
+	t.Run("use the sender of the email", func(t *testing.T) {
+		var input map[string]any
+
+		ses := newTestSES(t, func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewDecoder(r.Body).Decode(&input)
+		})
+
+		withSender := email
+		withSender.From = types.MailContact{Name: "João", Email: "joao@fincon.com"}
+
+		assert.NoError(t, ses.Send(context.Background(), withSender))
+		assert.Equal(t, "=?utf-8?q?Jo=C3=A3o?= <joao@fincon.com>", input["FromEmailAddress"])
+	})
+
+	t.Run("return ses errors", func(t *testing.T) {
+		ses := newTestSES(t, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Amzn-ErrorType", "MessageRejected:http://internal.amazon.com/coral/com.amazonaws.sesv2/")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"message":"Email address is not verified."}`))
+		})
+
+		err := ses.Send(context.Background(), email)
+
+		var sesErr mail.SESError
+		require.ErrorAs(t, err, &sesErr)
+		assert.Equal(t, mail.SESError{StatusCode: 400, Type: "MessageRejected", Message: "Email address is not verified."}, sesErr)
+	})
+
+	t.Run("return errors without body", func(t *testing.T) {
+		ses := newTestSES(t, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		})
+
+		err := ses.Send(context.Background(), email)
+		assert.EqualError(t, err, "ses: Internal Server Error (status 500): ")
+	})
+
+	t.Run("unknown template", func(t *testing.T) {
+		ses := newTestSES(t, func(w http.ResponseWriter, r *http.Request) {
+			t.Error("no request should be made")
+		})
+
+		invalid := email
+		invalid.Template = "unknown"
+		assert.Error(t, ses.Send(context.Background(), invalid))
+	})
+}
@@ -0,0 +1,141 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/joaopsramos/fincon/internal/config"
+)
+
+const smtpTimeout = 10 * time.Second
+
+// SMTP sends emails through any SMTP server, optionally over STARTTLS or implicit TLS
+type SMTP struct {
+	cfg      config.SMTPConfig
+	defaults config.MailDefaults
+	now      func() time.Time
+}
+
+// NewSMTP panics when the security isn't valid, so a typo never sends credentials in plain text
+func NewSMTP(cfg config.SMTPConfig, defaults config.MailDefaults) Mailer {
+	security, err := config.ParseSMTPSecurity(string(cfg.Security))
+	if err != nil {
+		panic(err)
+	}
+	cfg.Security = security
+
+	return &SMTP{cfg: cfg, defaults: defaults, now: time.Now}
+}
+
+// NewMailPit returns an SMTP mailer pointing to a local MailPit instance
+func NewMailPit(defaults config.MailDefaults) Mailer {
+	return NewSMTP(config.SMTPConfig{Host: "localhost", Port: 1025}, defaults)
+}
+
+func (s *SMTP) Send(ctx context.Context, email Email) error {
+	from := email.sender(s.defaults)
+
+	message, err := buildMessage(email, from, s.now())
+	if err != nil {
+		return err
+	}
+
+	client, err := s.dial(ctx)
+	if err != nil {
+		return fmt.Errorf("smtp: %w", err)
+	}
+	defer client.Close()
+
+	if err := s.deliver(client, from.Email, email.To.Email, message); err != nil {
+		return fmt.Errorf("smtp: %w", err)
+	}
+
+	return nil
+}
+
+func (s *SMTP) dial(ctx context.Context) (*smtp.Client, error) {
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+	dialer := &net.Dialer{Timeout: smtpTimeout}
+
+	var (
+		conn net.Conn
+		err  error
+	)
+
+	if s.cfg.Security == config.SMTPSecurityTLS {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: s.tlsConfig()}
+		conn, err = tlsDialer.DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	_ = conn.SetDeadline(time.Now().Add(smtpTimeout))
+
+	client, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func (s *SMTP) deliver(client *smtp.Client, from, to string, message []byte) error {
+	if s.cfg.Security == config.SMTPSecurityStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return errors.New("server does not support STARTTLS")
+		}
+
+		if err := client.StartTLS(s.tlsConfig()); err != nil {
+			return err
+		}
+	}
+
+	if s.cfg.Username != "" {
+		auth := smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(message); err != nil {
+		return err
+	}
+
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+func (s *SMTP) tlsConfig() *tls.Config {
+	if s.cfg.TLS != nil {
+		return s.cfg.TLS
+	}
+
+	return &tls.Config{ServerName: s.cfg.Host, MinVersion: tls.VersionTLS12}
+}
@@ -0,0 +1,386 @@
+package mail_test
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math/big"
+	"mime"
+	"mime/multipart"
+	"net"
+	netmail "net/mail"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/joaopsramos/fincon/internal/config"
+	"github.com/joaopsramos/fincon/internal/mail"
+	"github.com/joaopsramos/fincon/internal/types"
+	"github.com/joaopsramos/fincon/internal/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type receivedMail struct {
+	from     string
+	to       []string
+	data     string
+	tls      bool
+	authUser string
+}
+
+// smtpServer is a minimal in-process SMTP server supporting STARTTLS, implicit TLS and AUTH PLAIN
+type smtpServer struct {
+	listener  net.Listener
+	tlsConfig *tls.Config
+	implicit  bool
+	username  string
+	password  string
+
+	mu       sync.Mutex
+	received []receivedMail
+}
+
+func newSMTPServer(t *testing.T, tlsConfig *tls.Config, implicit bool, username, password string) *smtpServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	if implicit {
+		listener = tls.NewListener(listener, tlsConfig)
+	}
+
+	s := &smtpServer{listener: listener, tlsConfig: tlsConfig, implicit: implicit, username: username, password: password}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go s.serve(conn)
+		}
+	}()
+
+	return s
+}
+
+func (s *smtpServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *smtpServer) messages() []receivedMail {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]receivedMail(nil), s.received...)
+}
+
+func (s *smtpServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = io.WriteString(conn, line+"\r\n") }
+	current := receivedMail{tls: s.implicit}
+
+	reply("220 localhost ESMTP test")
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		verb, arg, _ := strings.Cut(line, " ")
+
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			reply("250-localhost")
+			if s.tlsConfig != nil && !current.tls {
+				reply("250-STARTTLS")
+			}
+			if s.username != "" {
+				reply("250-AUTH PLAIN")
+			}
+			reply("250 HELP")
+		case "STARTTLS":
+			reply("220 ready to start TLS")
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn = tlsConn
+			r = bufio.NewReader(conn)
+			current.tls = true
+		case "AUTH":
+			_, encoded, _ := strings.Cut(arg, " ")
+			decoded, _ := base64.StdEncoding.DecodeString(encoded)
+			parts := strings.Split(string(decoded), "\x00")
+			if len(parts) != 3 || parts[1] != s.username || parts[2] != s.password {
+				reply("535 authentication failed")
+				continue
+			}
+			current.authUser = parts[1]
+			reply("235 authenticated")
+		case "MAIL":
+			current.from = strings.Trim(strings.TrimPrefix(arg, "FROM:"), "<>")
+			reply("250 ok")
+		case "RCPT":
+			if s.username != "" && current.authUser == "" {
+				reply("530 authentication required")
+				continue
+			}
+			current.to = append(current.to, strings.Trim(strings.TrimPrefix(arg, "TO:"), "<>"))
+			reply("250 ok")
+		case "DATA":
+			reply("354 end data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(l, "."))
+			}
+			current.data = data.String()
+
+			s.mu.Lock()
+			s.received = append(s.received, current)
+			s.mu.Unlock()
+
+			reply("250 queued")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+func newTestCertificate(t *testing.T) (*tls.Config, *x509.CertPool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+
+	return &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}}, pool
+}
+
+func TestSMTP_Send(t *testing.T) {
+	t.Parallel()
+
+	serverTLS, roots := newTestCertificate(t)
+	clientTLS := &tls.Config{RootCAs: roots, ServerName: "127.0.0.1"}
+	defaults := config.MailDefaults{From: types.MailContact{Name: "Equipe Fincon", Email: "noreply@fincon.com"}}
+
+	email := mail.Email{
+		To:       types.MailContact{Name: "João", Email: "joao@example.com"},
+		Subject:  mail.ForgotPasswordSubject,
+		Template: mail.ForgotPasswordTemplate,
+		Data:     util.M{"Link": "http://localhost:3000/password/reset?token=abc"},
+	}
+
+	tests := []struct {
+		name     string
+		server   func(t *testing.T) *smtpServer
+		cfg      config.SMTPConfig
+		wantTLS  bool
+		wantAuth string
+	}{
+		{
+			name:   "plain connection",
+			server: func(t *testing.T) *smtpServer { return newSMTPServer(t, nil, false, "", "") },
+			cfg:    config.SMTPConfig{Security: config.SMTPSecurityNone},
+		},
+		{
+			name:     "starttls with auth",
+			server:   func(t *testing.T) *smtpServer { return newSMTPServer(t, serverTLS, false, "user", "secret") },
+			cfg:      config.SMTPConfig{Security: config.SMTPSecurityStartTLS, Username: "user", Password: "secret", TLS: clientTLS},
+			wantTLS:  true,
+			wantAuth: "user",
+		},
+		{
+			name:     "security is case insensitive",
+			server:   func(t *testing.T) *smtpServer { return newSMTPServer(t, serverTLS, false, "user", "secret") },
+			cfg:      config.SMTPConfig{Security: "STARTTLS", Username: "user", Password: "secret", TLS: clientTLS},
+			wantTLS:  true,
+			wantAuth: "user",
+		},
+		{
+			name:     "implicit tls with auth",
+			server:   func(t *testing.T) *smtpServer { return newSMTPServer(t, serverTLS, true, "user", "secret") },
+			cfg:      config.SMTPConfig{Security: config.SMTPSecurityTLS, Username: "user", Password: "secret", TLS: clientTLS},
+			wantTLS:  true,
+			wantAuth: "user",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			a := assert.New(t)
+
+			server := tt.server(t)
+			tt.cfg.Host = "127.0.0.1"
+			tt.cfg.Port = server.port()
+
+			require.NoError(t, mail.NewSMTP(tt.cfg, defaults).Send(context.Background(), email))
+
+			received := server.messages()
+			require.Len(t, received, 1)
+			a.Equal("noreply@fincon.com", received[0].from)
+			a.Equal([]string{"joao@example.com"}, received[0].to)
+			a.Equal(tt.wantTLS, received[0].tls)
+			a.Equal(tt.wantAuth, received[0].authUser)
+
+			msg, err := netmail.ReadMessage(strings.NewReader(received[0].data))
+			require.NoError(t, err)
+
+			a.Equal(`"Equipe Fincon" <noreply@fincon.com>`, msg.Header.Get("From"))
+			a.Equal("=?utf-8?q?Jo=C3=A3o?= <joao@example.com>", msg.Header.Get("To"))
+			a.Equal("1.0", msg.Header.Get("MIME-Version"))
+			a.Regexp(`^<[0-9a-f]{32}@fincon\.com>$`, msg.Header.Get("Message-ID"))
+
+			subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+			a.NoError(err)
+			a.Equal(string(mail.ForgotPasswordSubject), subject)
+
+			date, err := msg.Header.Date()
+			a.NoError(err)
+			a.WithinDuration(time.Now(), date, time.Minute)
+
+			mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+			require.NoError(t, err)
+			a.Equal("multipart/alternative", mediaType)
+
+			reader := multipart.NewReader(msg.Body, params["boundary"])
+
+			text, err := reader.NextPart()
+			require.NoError(t, err)
+			a.Equal("text/plain; charset=UTF-8", text.Header.Get("Content-Type"))
+			textBody, _ := io.ReadAll(text)
+			a.Contains(string(textBody), "Trocar minha senha: http://localhost:3000/password/reset?token=abc")
+			a.NotContains(string(textBody), "<")
+			a.NotContains(string(textBody), "font-size")
+
+			html, err := reader.NextPart()
+			require.NoError(t, err)
+			a.Equal("text/html; charset=UTF-8", html.Header.Get("Content-Type"))
+			htmlBody, _ := io.ReadAll(html)
+			a.Contains(string(htmlBody), `<a href="http://localhost:3000/password/reset?token=abc">`)
+
+			_, err = reader.NextPart()
+			a.ErrorIs(err, io.EOF)
+		})
+	}
+
+	t.Run("use the sender of the email", func(t *testing.T) {
+		t.Parallel()
+		a := assert.New(t)
+		server := newSMTPServer(t, nil, false, "", "")
+
+		withSender := email
+		withSender.From = types.MailContact{Name: "Suporte", Email: "suporte@fincon.com"}
+
+		cfg := config.SMTPConfig{Host: "127.0.0.1", Port: server.port()}
+		require.NoError(t, mail.NewSMTP(cfg, defaults).Send(context.Background(), withSender))
+
+		received := server.messages()
+		require.Len(t, received, 1)
+		a.Equal("suporte@fincon.com", received[0].from)
+
+		msg, err := netmail.ReadMessage(strings.NewReader(received[0].data))
+		require.NoError(t, err)
+		a.Equal(`"Suporte" <suporte@fincon.com>`, msg.Header.Get("From"))
+	})
+
+	t.Run("fail with invalid credentials", func(t *testing.T) {
+		t.Parallel()
+		server := newSMTPServer(t, serverTLS, false, "user", "secret")
+
+		cfg := config.SMTPConfig{
+			Host:     "127.0.0.1",
+			Port:     server.port(),
+			Security: config.SMTPSecurityStartTLS,
+			Username: "user",
+			Password: "wrong",
+			TLS:      clientTLS,
+		}
+
+		err := mail.NewSMTP(cfg, defaults).Send(context.Background(), email)
+		var smtpErr *textproto.Error
+		require.ErrorAs(t, err, &smtpErr)
+		assert.Equal(t, 535, smtpErr.Code)
+		assert.Empty(t, server.messages())
+	})
+
+	t.Run("fail when starttls is not supported", func(t *testing.T) {
+		t.Parallel()
+		server := newSMTPServer(t, nil, false, "", "")
+
+		cfg := config.SMTPConfig{Host: "127.0.0.1", Port: server.port(), Security: config.SMTPSecurityStartTLS}
+
+		err := mail.NewSMTP(cfg, defaults).Send(context.Background(), email)
+		assert.EqualError(t, err, "smtp: server does not support STARTTLS")
+		assert.Empty(t, server.messages())
+	})
+
+	t.Run("fail when the server is unreachable", func(t *testing.T) {
+		t.Parallel()
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		port := listener.Addr().(*net.TCPAddr).Port
+		listener.Close()
+
+		cfg := config.SMTPConfig{Host: "127.0.0.1", Port: port}
+		err = mail.NewSMTP(cfg, defaults).Send(context.Background(), email)
+		assert.ErrorContains(t, err, "smtp: dial tcp 127.0.0.1:"+strconv.Itoa(port))
+	})
+
+	t.Run("panic with an unknown security", func(t *testing.T) {
+		t.Parallel()
+
+		for _, security := range []config.SMTPSecurity{"ssl", "start-tls"} {
+			assert.PanicsWithError(t, fmt.Sprintf("invalid smtp security %q, must be one of: none, starttls, tls", security), func() {
+				mail.NewSMTP(config.SMTPConfig{Security: security}, defaults)
+			})
+		}
+	})
+}
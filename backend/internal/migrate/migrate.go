@@ -0,0 +1,373 @@
+package migrate
+
+import (
+	"cmp"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultTable = "schema_migrations"
+	// Dir is where the migrations live, relative to the backend root, used when creating new ones
+	Dir = "internal/migrate/migrations"
+
+	// lockID identifies the advisory lock held while migrating, so concurrent deploys
+	// don't apply the same migration twice
+	lockID = 4_217_730_105
+
+	// noTransaction on the first line of a migration runs it outside of a transaction,
+	// which statements like CREATE INDEX CONCURRENTLY require
+	noTransaction = "-- migrate:no-transaction"
+)
+
+var (
+	//go:embed migrations/*.sql
+	embedded embed.FS
+
+	fileRe = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+	nameRe = regexp.MustCompile(`[^a-z0-9]+`)
+
+	ErrInvalidSteps = errors.New("steps must be greater than zero")
+)
+
+// Migrations returns the migrations embedded in the binary
+func Migrations() fs.FS {
+	sub, err := fs.Sub(embedded, "migrations")
+	if err != nil {
+		panic(err)
+	}
+
+	return sub
+}
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+func (m Migration) String() string {
+	return fmt.Sprintf("%04d_%s", m.Version, m.Name)
+}
+
+type MigrationStatus struct {
+	Migration
+	// AppliedAt is nil while the migration is pending
+	AppliedAt *time.Time
+}
+
+// Load reads the migrations of fsys, which must be pairs of numbered up and down
+// files like 0001_baseline.up.sql and 0001_baseline.down.sql, sorted by version
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+
+		match := fileRe.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q", entry.Name())
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("invalid migration version in %q", entry.Name())
+		}
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has more than one name: %s and %s", version, m.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if strings.TrimSpace(m.Up) == "" || strings.TrimSpace(m.Down) == "" {
+			return nil, fmt.Errorf("migration %s must have non empty up and down files", m)
+		}
+
+		migrations = append(migrations, *m)
+	}
+
+	slices.SortFunc(migrations, func(a, b Migration) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+
+	return migrations, nil
+}
+
+// Create writes up and down files to dir for a migration numbered after the latest
+// one, returning their paths. The files only hold a placeholder comment to be replaced.
+func Create(dir string, name string) (string, string, error) {
+	name = strings.Trim(nameRe.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return "", "", errors.New("migration name can't be empty")
+	}
+
+	migrations, err := Load(os.DirFS(dir))
+	if err != nil {
+		return "", "", err
+	}
+
+	version := int64(1)
+	if len(migrations) > 0 {
+		version = migrations[len(migrations)-1].Version + 1
+	}
+
+	base := filepath.Join(dir, Migration{Version: version, Name: name}.String())
+	up, down := base+".up.sql", base+".down.sql"
+
+	files := map[string]string{
+		up:   "-- Write the statements that apply the migration here\n",
+		down: "-- Write the statements that undo the migration here\n",
+	}
+
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return "", "", err
+		}
+	}
+
+	return up, down, nil
+}
+
+type Opts struct {
+	// Table keeps track of the applied migrations, defaults to DefaultTable
+	Table  string
+	Logger *slog.Logger
+}
+
+type Migrator struct {
+	db     *gorm.DB
+	fsys   fs.FS
+	table  string
+	logger *slog.Logger
+}
+
+func New(db *gorm.DB, fsys fs.FS, opts Opts) *Migrator {
+	if opts.Table == "" {
+		opts.Table = DefaultTable
+	}
+
+	if opts.Logger == nil {
+		opts.Logger = slog.Default()
+	}
+
+	return &Migrator{db: db, fsys: fsys, table: opts.Table, logger: opts.Logger}
+}
+
+// Up applies every pending migration in order, returning the ones applied
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var applied []Migration
+
+	err := m.withLock(ctx, func(conn *gorm.DB, migrations []Migration, done map[int64]time.Time) error {
+		for _, migration := range migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+
+			m.logger.Info("Applying migration", "migration", migration.String())
+
+			err := m.run(conn, migration.Up, func(tx *gorm.DB) error {
+				return tx.Exec(
+					fmt.Sprintf("INSERT INTO %s (version, name) VALUES (?, ?)", m.table),
+					migration.Version,
+					migration.Name,
+				).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migration %s: %w", migration, err)
+			}
+
+			applied = append(applied, migration)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down rolls back the last steps applied migrations, returning the ones rolled back
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if steps <= 0 {
+		return nil, ErrInvalidSteps
+	}
+
+	var rolledBack []Migration
+
+	err := m.withLock(ctx, func(conn *gorm.DB, migrations []Migration, done map[int64]time.Time) error {
+		versions := make([]int64, 0, len(done))
+		for version := range done {
+			versions = append(versions, version)
+		}
+		slices.Sort(versions)
+		slices.Reverse(versions)
+
+		for _, version := range versions[:min(steps, len(versions))] {
+			idx := slices.IndexFunc(migrations, func(m Migration) bool { return m.Version == version })
+			if idx == -1 {
+				return fmt.Errorf("migration %d is applied but its files are missing", version)
+			}
+			migration := migrations[idx]
+
+			m.logger.Info("Rolling back migration", "migration", migration.String())
+
+			err := m.run(conn, migration.Down, func(tx *gorm.DB) error {
+				return tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE version = ?", m.table), migration.Version).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migration %s: %w", migration, err)
+			}
+
+			rolledBack = append(rolledBack, migration)
+		}
+
+		return nil
+	})
+
+	return rolledBack, err
+}
+
+// Status lists every known migration and when it was applied. It only reads, so it
+// neither waits for a running migration nor creates the migrations table.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	migrations, err := Load(m.fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := m.db.WithContext(ctx)
+
+	var exists bool
+	if err := conn.Raw("SELECT to_regclass(?) IS NOT NULL", m.table).Scan(&exists).Error; err != nil {
+		return nil, err
+	}
+
+	done := make(map[int64]time.Time)
+	if exists {
+		if done, err = m.applied(conn); err != nil {
+			return nil, err
+		}
+	}
+
+	statuses := make([]MigrationStatus, len(migrations))
+	for i, migration := range migrations {
+		statuses[i] = MigrationStatus{Migration: migration}
+		if appliedAt, ok := done[migration.Version]; ok {
+			statuses[i].AppliedAt = &appliedAt
+		}
+	}
+
+	return statuses, nil
+}
+
+// withLock runs fn on a single connection holding the migrations advisory lock, with
+// the known migrations and the applied versions
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *gorm.DB, migrations []Migration, done map[int64]time.Time) error) error {
+	migrations, err := Load(m.fsys)
+	if err != nil {
+		return err
+	}
+
+	// Session level advisory locks belong to a connection, so everything must run on the same one
+	return m.db.WithContext(ctx).Connection(func(conn *gorm.DB) (err error) {
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", lockID).Error; err != nil {
+			return err
+		}
+
+		defer func() {
+			err = errors.Join(err, conn.Exec("SELECT pg_advisory_unlock(?)", lockID).Error)
+		}()
+
+		err = conn.Exec(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			version bigint PRIMARY KEY,
+			name text NOT NULL,
+			applied_at timestamptz NOT NULL DEFAULT now()
+		)`, m.table)).Error
+		if err != nil {
+			return err
+		}
+
+		done, err := m.applied(conn)
+		if err != nil {
+			return err
+		}
+
+		return fn(conn, migrations, done)
+	})
+}
+
+// applied returns when each applied version was applied
+func (m *Migrator) applied(conn *gorm.DB) (map[int64]time.Time, error) {
+	var rows []struct {
+		Version   int64
+		AppliedAt time.Time
+	}
+
+	if err := conn.Raw(fmt.Sprintf("SELECT version, applied_at FROM %s", m.table)).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	done := make(map[int64]time.Time, len(rows))
+	for _, r := range rows {
+		done[r.Version] = r.AppliedAt
+	}
+
+	return done, nil
+}
+
+// run executes the sql of a migration and records it with track, both in the same
+// transaction unless the migration opts out of it
+func (m *Migrator) run(conn *gorm.DB, sql string, track func(tx *gorm.DB) error) error {
+	if strings.HasPrefix(strings.TrimSpace(sql), noTransaction) {
+		if err := conn.Exec(sql).Error; err != nil {
+			return err
+		}
+
+		return track(conn)
+	}
+
+	return conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(sql).Error; err != nil {
+			return err
+		}
+
+		return track(tx)
+	})
+}
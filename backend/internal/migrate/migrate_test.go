@@ -0,0 +1,188 @@
+package migrate_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/joaopsramos/fincon/internal/config"
+	"github.com/joaopsramos/fincon/internal/migrate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func file(content string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(content)}
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		fsys    fstest.MapFS
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "sorted by version",
+			fsys: fstest.MapFS{
+				"0010_add_tags.up.sql":    file("up 10"),
+				"0010_add_tags.down.sql":  file("down 10"),
+				"0002_add_goals.up.sql":   file("up 2"),
+				"0002_add_goals.down.sql": file("down 2"),
+				"README.md":               file("ignored"),
+			},
+			want: []string{"0002_add_goals", "0010_add_tags"},
+		},
+		{
+			name:    "invalid name",
+			fsys:    fstest.MapFS{"add_goals.up.sql": file("up")},
+			wantErr: `invalid migration file name "add_goals.up.sql"`,
+		},
+		{
+			name:    "missing down",
+			fsys:    fstest.MapFS{"0001_add_goals.up.sql": file("up")},
+			wantErr: "migration 0001_add_goals must have non empty up and down files",
+		},
+		{
+			name: "conflicting names",
+			fsys: fstest.MapFS{
+				"0001_add_goals.up.sql":  file("up"),
+				"0001_add_tags.down.sql": file("down"),
+			},
+			wantErr: "migration 1 has more than one name: add_goals and add_tags",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := migrate.Load(tt.fsys)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+
+			var names []string
+			for _, m := range migrations {
+				names = append(names, m.String())
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
+
+func TestMigrations(t *testing.T) {
+	t.Parallel()
+
+	migrations, err := migrate.Load(migrate.Migrations())
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	assert.Equal(t, "0001_baseline", migrations[0].String())
+}
+
+func TestCreate(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+	dir := t.TempDir()
+
+	up, down, err := migrate.Create(dir, "Add goal colors")
+	require.NoError(t, err)
+	a.Equal(filepath.Join(dir, "0001_add_goal_colors.up.sql"), up)
+	a.Equal(filepath.Join(dir, "0001_add_goal_colors.down.sql"), down)
+
+	// the placeholders are valid migrations, so more can be created right away
+	migrations, err := migrate.Load(os.DirFS(dir))
+	require.NoError(t, err)
+	a.Len(migrations, 1)
+
+	up, _, err = migrate.Create(dir, "drop-colors")
+	require.NoError(t, err)
+	a.Equal(filepath.Join(dir, "0002_drop_colors.up.sql"), up)
+
+	_, _, err = migrate.Create(dir, " - ")
+	a.EqualError(err, "migration name can't be empty")
+}
+
+func TestMigrator(t *testing.T) {
+	a := assert.New(t)
+	ctx := context.Background()
+	db := config.NewPostgresConn(config.Get().PostgresDSN())
+
+	table := "schema_migrations_test"
+	t.Cleanup(func() {
+		db.Exec("DROP TABLE IF EXISTS migrate_test_items, " + table)
+	})
+
+	fsys := fstest.MapFS{
+		"0001_create_items.up.sql":   file("CREATE TABLE migrate_test_items (id bigserial PRIMARY KEY, name text);"),
+		"0001_create_items.down.sql": file("DROP TABLE migrate_test_items;"),
+		"0002_index_items.up.sql": file(
+			"-- migrate:no-transaction\nCREATE INDEX CONCURRENTLY idx_migrate_test_items_name ON migrate_test_items (name);",
+		),
+		"0002_index_items.down.sql": file("DROP INDEX idx_migrate_test_items_name;"),
+	}
+	migrator := migrate.New(db, fsys, migrate.Opts{Table: table})
+
+	hasTable := func() bool {
+		return db.Migrator().HasTable("migrate_test_items")
+	}
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	a.Nil(statuses[0].AppliedAt)
+	a.False(db.Migrator().HasTable(table))
+
+	applied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	a.Len(applied, 2)
+	a.True(hasTable())
+
+	applied, err = migrator.Up(ctx)
+	require.NoError(t, err)
+	a.Empty(applied)
+
+	statuses, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	a.NotNil(statuses[0].AppliedAt)
+	a.NotNil(statuses[1].AppliedAt)
+
+	rolledBack, err := migrator.Down(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, rolledBack, 1)
+	a.Equal("0002_index_items", rolledBack[0].String())
+	a.True(hasTable())
+
+	statuses, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	a.NotNil(statuses[0].AppliedAt)
+	a.Nil(statuses[1].AppliedAt)
+
+	rolledBack, err = migrator.Down(ctx, 5)
+	require.NoError(t, err)
+	a.Len(rolledBack, 1)
+	a.False(hasTable())
+
+	_, err = migrator.Down(ctx, 0)
+	a.ErrorIs(err, migrate.ErrInvalidSteps)
+
+	t.Run("a failed migration is not recorded", func(t *testing.T) {
+		fsys["0003_broken.up.sql"] = file("CREATE TABLE migrate_test_broken (id bigserial); SELECT * FROM missing_table;")
+		fsys["0003_broken.down.sql"] = file("SELECT 1;")
+
+		applied, err := migrator.Up(ctx)
+		assert.ErrorContains(t, err, "migration 0003_broken")
+		assert.Len(t, applied, 2)
+		assert.False(t, db.Migrator().HasTable("migrate_test_broken"))
+
+		statuses, err := migrator.Status(ctx)
+		require.NoError(t, err)
+		assert.Nil(t, statuses[2].AppliedAt)
+	})
+}
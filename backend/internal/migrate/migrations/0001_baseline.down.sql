@@ -0,0 +1,21 @@
+DROP TABLE IF EXISTS expense_tags;
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS digest_preferences;
+DROP TABLE IF EXISTS outbox_emails;
+DROP TABLE IF EXISTS household_invitations;
+DROP TABLE IF EXISTS household_members;
+DROP TABLE IF EXISTS households;
+DROP TABLE IF EXISTS recovery_codes;
+DROP TABLE IF EXISTS sessions;
+DROP TABLE IF EXISTS tags;
+DROP TABLE IF EXISTS incomes;
+DROP TABLE IF EXISTS recurring_expenses;
+DROP TABLE IF EXISTS user_tokens;
+DROP TABLE IF EXISTS expenses;
+DROP TABLE IF EXISTS salaries;
+DROP TABLE IF EXISTS goal_allocations;
+DROP TABLE IF EXISTS goals;
+DROP TABLE IF EXISTS users;
+
+DROP EXTENSION IF EXISTS citext;
+DROP EXTENSION IF EXISTS unaccent;
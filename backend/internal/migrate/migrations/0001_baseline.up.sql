@@ -0,0 +1,282 @@
+-- Baseline: the schema as it was created by GORM AutoMigrate. Every statement is
+-- idempotent so databases created before versioned migrations adopt it as is. Tables
+-- that existed before some of their columns also get the missing columns added.
+
+CREATE EXTENSION IF NOT EXISTS unaccent;
+CREATE EXTENSION IF NOT EXISTS citext;
+
+CREATE TABLE IF NOT EXISTS users (
+    id uuid DEFAULT gen_random_uuid(),
+    email citext,
+    hash_password text,
+    verified_at timestamptz,
+    totp_secret text,
+    totp_enabled_at timestamptz,
+    totp_last_step bigint,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id)
+);
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS verified_at timestamptz,
+    ADD COLUMN IF NOT EXISTS totp_secret text,
+    ADD COLUMN IF NOT EXISTS totp_enabled_at timestamptz,
+    ADD COLUMN IF NOT EXISTS totp_last_step bigint;
+
+CREATE TABLE IF NOT EXISTS goals (
+    id bigserial,
+    name text,
+    percentage bigint,
+    color text,
+    position bigint,
+    archived_at timestamptz,
+    user_id uuid,
+    alert_thresholds jsonb,
+    email_alerts boolean,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_goals_user FOREIGN KEY (user_id) REFERENCES users(id)
+);
+ALTER TABLE goals
+    ADD COLUMN IF NOT EXISTS color text,
+    ADD COLUMN IF NOT EXISTS position bigint,
+    ADD COLUMN IF NOT EXISTS archived_at timestamptz,
+    ADD COLUMN IF NOT EXISTS alert_thresholds jsonb,
+    ADD COLUMN IF NOT EXISTS email_alerts boolean;
+
+CREATE TABLE IF NOT EXISTS goal_allocations (
+    id bigserial,
+    goal_id bigint,
+    effective_from date,
+    percentage bigint,
+    user_id uuid,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_goal_allocations_goal FOREIGN KEY (goal_id) REFERENCES goals(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_goal_allocations_user_id ON goal_allocations (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_goal_allocations_goal_id_effective_from ON goal_allocations (goal_id, effective_from);
+
+CREATE TABLE IF NOT EXISTS salaries (
+    id bigserial,
+    amount bigint,
+    effective_from date NOT NULL DEFAULT date_trunc('month', now()),
+    user_id uuid,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_salaries_user FOREIGN KEY (user_id) REFERENCES users(id)
+);
+ALTER TABLE salaries
+    ADD COLUMN IF NOT EXISTS effective_from date NOT NULL DEFAULT date_trunc('month', now());
+CREATE UNIQUE INDEX IF NOT EXISTS idx_salaries_user_id_effective_from ON salaries (user_id, effective_from);
+
+CREATE TABLE IF NOT EXISTS expenses (
+    id bigserial,
+    name text,
+    value bigint,
+    date timestamp without time zone,
+    user_id uuid,
+    goal_id bigint,
+    recurring_expense_id bigint,
+    created_by_id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_expenses_user FOREIGN KEY (user_id) REFERENCES users(id),
+    CONSTRAINT fk_expenses_created_by FOREIGN KEY (created_by_id) REFERENCES users(id) ON DELETE SET NULL,
+    CONSTRAINT fk_goals_expenses FOREIGN KEY (goal_id) REFERENCES goals(id)
+);
+ALTER TABLE expenses
+    ADD COLUMN IF NOT EXISTS recurring_expense_id bigint,
+    ADD COLUMN IF NOT EXISTS created_by_id uuid;
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_expenses_created_by') THEN
+        ALTER TABLE expenses
+            ADD CONSTRAINT fk_expenses_created_by FOREIGN KEY (created_by_id) REFERENCES users(id) ON DELETE SET NULL;
+    END IF;
+END $$;
+CREATE INDEX IF NOT EXISTS idx_expenses_recurring_expense_id ON expenses (recurring_expense_id);
+
+CREATE TABLE IF NOT EXISTS user_tokens (
+    id bigserial,
+    user_id uuid,
+    token uuid DEFAULT gen_random_uuid(),
+    purpose text NOT NULL DEFAULT 'password_reset',
+    expires_at timestamptz,
+    used boolean,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_user_tokens_user FOREIGN KEY (user_id) REFERENCES users(id)
+);
+ALTER TABLE user_tokens
+    ADD COLUMN IF NOT EXISTS purpose text NOT NULL DEFAULT 'password_reset';
+
+CREATE TABLE IF NOT EXISTS recurring_expenses (
+    id bigserial,
+    name text,
+    value bigint,
+    day_of_month bigint,
+    cadence text,
+    start_date timestamp without time zone,
+    end_date timestamp without time zone,
+    user_id uuid,
+    goal_id bigint,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_recurring_expenses_user FOREIGN KEY (user_id) REFERENCES users(id),
+    CONSTRAINT fk_recurring_expenses_goal FOREIGN KEY (goal_id) REFERENCES goals(id)
+);
+
+CREATE TABLE IF NOT EXISTS incomes (
+    id bigserial,
+    name text,
+    amount bigint,
+    recurring boolean,
+    start_date timestamp without time zone,
+    end_date timestamp without time zone,
+    user_id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_incomes_user FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+CREATE TABLE IF NOT EXISTS tags (
+    id bigserial,
+    name text,
+    user_id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_tags_user FOREIGN KEY (user_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_user_id_name ON tags (user_id, name);
+
+CREATE TABLE IF NOT EXISTS sessions (
+    id uuid DEFAULT gen_random_uuid(),
+    user_id uuid,
+    refresh_token_hash text,
+    previous_token_hash text,
+    user_agent text,
+    expires_at timestamptz,
+    last_used_at timestamptz,
+    revoked_at timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_sessions_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_sessions_previous_token_hash ON sessions (previous_token_hash);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sessions_refresh_token_hash ON sessions (refresh_token_hash);
+CREATE INDEX IF NOT EXISTS idx_sessions_user_id ON sessions (user_id);
+
+CREATE TABLE IF NOT EXISTS recovery_codes (
+    id bigserial,
+    user_id uuid,
+    code_hash text,
+    used_at timestamptz,
+    created_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_recovery_codes_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_recovery_codes_user_id ON recovery_codes (user_id);
+
+CREATE TABLE IF NOT EXISTS households (
+    id uuid DEFAULT gen_random_uuid(),
+    name text,
+    owner_id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_households_owner FOREIGN KEY (owner_id) REFERENCES users(id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_households_owner_id ON households (owner_id);
+
+CREATE TABLE IF NOT EXISTS household_members (
+    id bigserial,
+    household_id uuid,
+    user_id uuid,
+    role text NOT NULL,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_household_members_household FOREIGN KEY (household_id) REFERENCES households(id) ON DELETE CASCADE,
+    CONSTRAINT fk_household_members_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_household_members_user_id ON household_members (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_household_members_household_id_user_id ON household_members (household_id, user_id);
+
+CREATE TABLE IF NOT EXISTS household_invitations (
+    id bigserial,
+    household_id uuid,
+    email citext,
+    role text NOT NULL,
+    token uuid DEFAULT gen_random_uuid(),
+    invited_by_id uuid,
+    expires_at timestamptz,
+    accepted_at timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_household_invitations_household FOREIGN KEY (household_id) REFERENCES households(id) ON DELETE CASCADE,
+    CONSTRAINT fk_household_invitations_invited_by FOREIGN KEY (invited_by_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_household_invitations_token ON household_invitations (token);
+CREATE INDEX IF NOT EXISTS idx_household_invitations_household_id ON household_invitations (household_id);
+
+CREATE TABLE IF NOT EXISTS outbox_emails (
+    id bigserial,
+    to_name text,
+    to_email text,
+    from_name text,
+    from_email text,
+    subject text,
+    template text,
+    data jsonb,
+    status text DEFAULT 'pending',
+    attempts bigint,
+    next_attempt_at timestamptz,
+    last_error text,
+    sent_at timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_outbox_emails_due ON outbox_emails (status, next_attempt_at);
+
+CREATE TABLE IF NOT EXISTS digest_preferences (
+    user_id uuid,
+    frequency text NOT NULL DEFAULT 'none',
+    last_period timestamptz,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (user_id),
+    CONSTRAINT fk_digest_preferences_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_digest_preferences_frequency ON digest_preferences (frequency);
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id bigserial,
+    user_id uuid,
+    kind text NOT NULL DEFAULT 'goal_threshold',
+    goal_id bigint,
+    threshold bigint,
+    period date,
+    spent bigint,
+    "limit" bigint,
+    read_at timestamptz,
+    created_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_notifications_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_notifications_goal FOREIGN KEY (goal_id) REFERENCES goals(id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notifications_goal_threshold_period ON notifications (goal_id, threshold, period);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications (user_id);
+
+CREATE TABLE IF NOT EXISTS expense_tags (
+    expense_id bigint,
+    tag_id bigint,
+    PRIMARY KEY (expense_id, tag_id),
+    CONSTRAINT fk_expense_tags_expense FOREIGN KEY (expense_id) REFERENCES expenses(id) ON DELETE CASCADE,
+    CONSTRAINT fk_expense_tags_tag FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+);
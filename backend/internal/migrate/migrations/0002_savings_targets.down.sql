@@ -0,0 +1 @@
+DROP TABLE savings_targets;
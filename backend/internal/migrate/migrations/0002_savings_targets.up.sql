@@ -0,0 +1,16 @@
+CREATE TABLE savings_targets (
+    id bigserial,
+    name text,
+    target_amount bigint,
+    start_date date,
+    deadline date,
+    goal_id bigint,
+    user_id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_savings_targets_goal FOREIGN KEY (goal_id) REFERENCES goals(id) ON DELETE CASCADE,
+    CONSTRAINT fk_savings_targets_user FOREIGN KEY (user_id) REFERENCES users(id)
+);
+CREATE INDEX idx_savings_targets_goal_id ON savings_targets (goal_id);
+CREATE INDEX idx_savings_targets_user_id ON savings_targets (user_id);
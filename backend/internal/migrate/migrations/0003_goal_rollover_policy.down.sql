@@ -0,0 +1 @@
+ALTER TABLE goals DROP COLUMN rollover_policy;
@@ -0,0 +1 @@
+ALTER TABLE goals ADD COLUMN rollover_policy text NOT NULL DEFAULT 'deficit';
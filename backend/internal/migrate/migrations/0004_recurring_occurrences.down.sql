@@ -0,0 +1 @@
+DROP TABLE recurring_occurrences;
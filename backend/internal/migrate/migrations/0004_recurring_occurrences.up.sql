@@ -0,0 +1,14 @@
+CREATE TABLE recurring_occurrences (
+    recurring_expense_id bigint,
+    month date,
+    created_at timestamptz,
+    PRIMARY KEY (recurring_expense_id, month),
+    CONSTRAINT fk_recurring_occurrences_recurring_expense FOREIGN KEY (recurring_expense_id) REFERENCES recurring_expenses(id) ON DELETE CASCADE
+);
+
+-- Months already materialized must not get another expense
+INSERT INTO recurring_occurrences (recurring_expense_id, month, created_at)
+SELECT recurring_expense_id, date_trunc('month', date)::date, min(created_at)
+FROM expenses
+WHERE recurring_expense_id IS NOT NULL
+GROUP BY recurring_expense_id, date_trunc('month', date)::date;
@@ -0,0 +1,7 @@
+CREATE TABLE income_amounts (
+    income_id bigint,
+    month date,
+    amount bigint,
+    PRIMARY KEY (income_id, month),
+    CONSTRAINT fk_income_amounts_income FOREIGN KEY (income_id) REFERENCES incomes(id) ON DELETE CASCADE
+);
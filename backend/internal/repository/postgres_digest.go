@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joaopsramos/fincon/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresDigestRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresDigest(db *gorm.DB) domain.DigestRepo {
+	return PostgresDigestRepository{db}
+}
+
+func (r PostgresDigestRepository) Get(ctx context.Context, userID uuid.UUID) (*domain.DigestPreference, error) {
+	var p domain.DigestPreference
+	if err := conn(ctx, r.db).Where("user_id = ?", userID).Take(&p).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &domain.DigestPreference{UserID: userID, Frequency: domain.DigestNone}, nil
+		}
+
+		return &domain.DigestPreference{}, err
+	}
+
+	return &p, nil
+}
+
+func (r PostgresDigestRepository) Save(ctx context.Context, p *domain.DigestPreference) error {
+	return conn(ctx, r.db).
+		Omit(clause.Associations).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"frequency", "updated_at"}),
+		}).
+		Create(p).Error
+}
+
+func (r PostgresDigestRepository) AllDue(ctx context.Context, frequency domain.DigestFrequency, periodStart time.Time) ([]domain.DigestPreference, error) {
+	var prefs []domain.DigestPreference
+
+	err := conn(ctx, r.db).
+		Preload("User").
+		Where("frequency = ? AND (last_period IS NULL OR last_period < ?)", frequency, periodStart).
+		Order("user_id").
+		Find(&prefs).Error
+
+	return prefs, err
+}
+
+func (r PostgresDigestRepository) MarkSent(ctx context.Context, userID uuid.UUID, periodStart time.Time) error {
+	return conn(ctx, r.db).
+		Model(&domain.DigestPreference{}).
+		Where("user_id = ?", userID).
+		Update("last_period", periodStart).Error
+}
@@ -2,12 +2,14 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/joaopsramos/fincon/internal/domain"
 	"github.com/joaopsramos/fincon/internal/errs"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PostgresExpenseRepository struct {
@@ -20,8 +22,7 @@ func NewPostgresExpense(db *gorm.DB) domain.ExpenseRepo {
 
 func (r PostgresExpenseRepository) FindMatchingNames(ctx context.Context, name string, userID uuid.UUID) ([]string, error) {
 	var names []string
-	result := r.db.
-		WithContext(ctx).
+	result := conn(ctx, r.db).
 		Model(&domain.Expense{}).
 		Where("user_id = ?", userID).
 		Where("unaccent(name) ILIKE unaccent(?)", "%"+name+"%").
@@ -33,7 +34,7 @@ func (r PostgresExpenseRepository) FindMatchingNames(ctx context.Context, name s
 func (r PostgresExpenseRepository) Get(ctx context.Context, id uint, userID uuid.UUID) (*domain.Expense, error) {
 	var e domain.Expense
 
-	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Take(&e, id).Error; err != nil {
+	if err := conn(ctx, r.db).Preload("Tags").Where("user_id = ?", userID).Take(&e, id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return &domain.Expense{}, errs.NewNotFound("expense")
 		}
@@ -45,7 +46,7 @@ func (r PostgresExpenseRepository) Get(ctx context.Context, id uint, userID uuid
 }
 
 func (r PostgresExpenseRepository) Create(ctx context.Context, e *domain.Expense) error {
-	if err := r.db.WithContext(ctx).Create(e).Error; err != nil {
+	if err := conn(ctx, r.db).Create(e).Error; err != nil {
 		return err
 	}
 
@@ -53,7 +54,7 @@ func (r PostgresExpenseRepository) Create(ctx context.Context, e *domain.Expense
 }
 
 func (r PostgresExpenseRepository) CreateMany(ctx context.Context, e []domain.Expense) error {
-	if err := r.db.WithContext(ctx).Create(e).Error; err != nil {
+	if err := conn(ctx, r.db).Create(e).Error; err != nil {
 		return err
 	}
 
@@ -61,7 +62,15 @@ func (r PostgresExpenseRepository) CreateMany(ctx context.Context, e []domain.Ex
 }
 
 func (r PostgresExpenseRepository) Update(ctx context.Context, e *domain.Expense) error {
-	if err := r.db.WithContext(ctx).Model(e).Updates(e).Error; err != nil {
+	if err := conn(ctx, r.db).Model(e).Omit(clause.Associations).Updates(e).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r PostgresExpenseRepository) ReplaceTags(ctx context.Context, e *domain.Expense, tags []domain.Tag) error {
+	if err := conn(ctx, r.db).Model(e).Association("Tags").Replace(tags); err != nil {
 		return err
 	}
 
@@ -69,7 +78,7 @@ func (r PostgresExpenseRepository) Update(ctx context.Context, e *domain.Expense
 }
 
 func (r PostgresExpenseRepository) Delete(ctx context.Context, id uint, userID uuid.UUID) error {
-	result := r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&domain.Expense{}, id)
+	result := conn(ctx, r.db).Where("user_id = ?", userID).Delete(&domain.Expense{}, id)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -81,11 +90,47 @@ func (r PostgresExpenseRepository) Delete(ctx context.Context, id uint, userID u
 	return nil
 }
 
+func (r PostgresExpenseRepository) AllInBatches(ctx context.Context, userID uuid.UUID, size int, fn func([]domain.Expense) error) error {
+	var last *domain.Expense
+
+	for {
+		query := conn(ctx, r.db).
+			Preload("Tags").
+			Where("user_id = ?", userID).
+			Order("date, id").
+			Limit(size)
+
+		if last != nil {
+			query = query.Where("(date, id) > (?, ?)", last.Date, last.ID)
+		}
+
+		var e []domain.Expense
+		if err := query.Find(&e).Error; err != nil {
+			return err
+		}
+
+		if len(e) == 0 {
+			return nil
+		}
+
+		if err := fn(e); err != nil {
+			return err
+		}
+
+		if len(e) < size {
+			return nil
+		}
+
+		last = &e[len(e)-1]
+	}
+}
+
 func (r PostgresExpenseRepository) AllByGoalID(ctx context.Context, goalID uint, year int, month time.Month, userID uuid.UUID) ([]domain.Expense, error) {
 	date := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
 
 	var e []domain.Expense
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
+		Preload("Tags").
 		Where("user_id = ?", userID).
 		Where("goal_id = ?", goalID).
 		Where("date_trunc('month', date) = date_trunc('month', ?::timestamp)", date).
@@ -95,9 +140,100 @@ func (r PostgresExpenseRepository) AllByGoalID(ctx context.Context, goalID uint,
 	return e, result.Error
 }
 
+// AllBetween returns the expenses dated from "from" (inclusive) up to "to" (exclusive)
+func (r PostgresExpenseRepository) AllBetween(ctx context.Context, from time.Time, to time.Time, userID uuid.UUID) ([]domain.Expense, error) {
+	var e []domain.Expense
+	result := conn(ctx, r.db).
+		Where("user_id = ?", userID).
+		Where("date >= ? AND date < ?", from, to).
+		Order("date, id").
+		Find(&e)
+
+	return e, result.Error
+}
+
+// SumByGoalBetween returns the total of the goal expenses dated from "from" (inclusive) up to "to" (exclusive)
+func (r PostgresExpenseRepository) SumByGoalBetween(ctx context.Context, goalID uint, from time.Time, to time.Time, userID uuid.UUID) (int64, error) {
+	var total int64
+	result := conn(ctx, r.db).
+		Model(&domain.Expense{}).
+		Select("COALESCE(SUM(value), 0)").
+		Where("user_id = ? AND goal_id = ?", userID, goalID).
+		Where("date >= ? AND date < ?", from, to).
+		Scan(&total)
+
+	return total, result.Error
+}
+
+// Search returns the expenses matching the filter using keyset pagination, ties are broken by id
+func (r PostgresExpenseRepository) Search(ctx context.Context, filter domain.ExpenseFilter, userID uuid.UUID) ([]domain.Expense, error) {
+	query := conn(ctx, r.db).Preload("Tags").Where("user_id = ?", userID)
+
+	if !filter.From.IsZero() {
+		query = query.Where("date >= ?", filter.From)
+	}
+
+	if !filter.To.IsZero() {
+		query = query.Where("date < ?", filter.To)
+	}
+
+	if len(filter.GoalIDs) > 0 {
+		query = query.Where("goal_id IN ?", filter.GoalIDs)
+	}
+
+	if len(filter.TagIDs) > 0 {
+		query = query.Where("id IN (SELECT expense_id FROM expense_tags WHERE tag_id IN ?)", filter.TagIDs)
+	}
+
+	if filter.MinValue > 0 {
+		query = query.Where("value >= ?", filter.MinValue)
+	}
+
+	if filter.MaxValue > 0 {
+		query = query.Where("value <= ?", filter.MaxValue)
+	}
+
+	if filter.Query != "" {
+		query = query.Where("unaccent(name) ILIKE unaccent(?)", "%"+filter.Query+"%")
+	}
+
+	column, direction := "date", "DESC"
+	switch filter.Sort {
+	case domain.SortDateAsc:
+		direction = "ASC"
+	case domain.SortValueDesc:
+		column = "value"
+	case domain.SortValueAsc:
+		column, direction = "value", "ASC"
+	}
+
+	if c := filter.After; c != nil {
+		operator := "<"
+		if direction == "ASC" {
+			operator = ">"
+		}
+
+		var value any = c.Date
+		if column == "value" {
+			value = c.Value
+		}
+
+		query = query.Where(fmt.Sprintf("(%s, id) %s (?, ?)", column, operator), value, c.ID)
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var e []domain.Expense
+	result := query.Order(fmt.Sprintf("%s %s, id %s", column, direction, direction)).Find(&e)
+
+	return e, result.Error
+}
+
 func (r PostgresExpenseRepository) GetMonthlyGoalSpendings(ctx context.Context, date time.Time, userID uuid.UUID) ([]domain.MonthlyGoalSpending, error) {
 	var monthlyGoalSpendings []domain.MonthlyGoalSpending
-	err := r.db.WithContext(ctx).Model(&domain.Goal{}).
+	err := conn(ctx, r.db).Model(&domain.Goal{}).
 		Joins("JOIN expenses ON goals.id = expenses.goal_id").
 		Where("date_trunc('month', expenses.date) <= date_trunc('month', ?::date)", date).
 		Where("goals.user_id = ?", userID).
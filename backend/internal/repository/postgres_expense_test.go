@@ -9,6 +9,7 @@ import (
 	"github.com/joaopsramos/fincon/internal/domain"
 	"github.com/joaopsramos/fincon/internal/repository"
 	"github.com/joaopsramos/fincon/internal/testhelper"
+	"github.com/joaopsramos/fincon/internal/util"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
 )
@@ -239,3 +240,125 @@ func TestPostgresExpense_AllByGoalID(t *testing.T) {
 		assert.Equal(actual[0].Name, "Expense 6")
 	})
 }
+
+func TestPostgresExpense_AllBetween(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+
+	user := f.InsertUser()
+	goal := f.InsertGoal(&domain.Goal{Name: domain.Comfort, UserID: user.ID})
+
+	from := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC)
+
+	f.InsertExpense(&domain.Expense{Name: "Before", Date: from.AddDate(0, 0, -1), GoalID: goal.ID, UserID: user.ID})
+	f.InsertExpense(&domain.Expense{Name: "Last", Date: to.AddDate(0, 0, -1), GoalID: goal.ID, UserID: user.ID})
+	f.InsertExpense(&domain.Expense{Name: "First", Date: from, GoalID: goal.ID, UserID: user.ID})
+	f.InsertExpense(&domain.Expense{Name: "After", Date: to, GoalID: goal.ID, UserID: user.ID})
+
+	otherUser := f.InsertUser()
+	otherGoal := f.InsertGoal(&domain.Goal{Name: domain.Comfort, UserID: otherUser.ID})
+	f.InsertExpense(&domain.Expense{Name: "Other", Date: from, GoalID: otherGoal.ID, UserID: otherUser.ID})
+
+	r := NewTestPostgresExpenseRepo(t, tx)
+
+	actual, err := r.AllBetween(context.Background(), from, to, user.ID)
+	assert.NoError(err)
+	assert.Len(actual, 2)
+	assert.Equal("First", actual[0].Name)
+	assert.Equal("Last", actual[1].Name)
+}
+
+func TestPostgresExpense_AllInBatches(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+
+	user := f.InsertUser()
+	goal := f.InsertGoal(&domain.Goal{Name: domain.Comfort, UserID: user.ID})
+	date := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	// inserted out of date order, so batching by id alone would skip or repeat expenses
+	f.InsertExpense(&domain.Expense{Name: "Third", Date: date.AddDate(0, 0, 2), GoalID: goal.ID, UserID: user.ID})
+	f.InsertExpense(&domain.Expense{Name: "First", Date: date, GoalID: goal.ID, UserID: user.ID})
+	f.InsertExpense(&domain.Expense{Name: "Second", Date: date, GoalID: goal.ID, UserID: user.ID})
+
+	otherUser := f.InsertUser()
+	otherGoal := f.InsertGoal(&domain.Goal{Name: domain.Comfort, UserID: otherUser.ID})
+	f.InsertExpense(&domain.Expense{Name: "Other", Date: date, GoalID: otherGoal.ID, UserID: otherUser.ID})
+
+	r := NewTestPostgresExpenseRepo(t, tx)
+
+	var batches [][]string
+	err := r.AllInBatches(context.Background(), user.ID, 2, func(expenses []domain.Expense) error {
+		batches = append(batches, util.Map(expenses, func(e domain.Expense) string { return e.Name }))
+		return nil
+	})
+	assert.NoError(err)
+	assert.Equal([][]string{{"First", "Second"}, {"Third"}}, batches)
+}
+
+func TestPostgresExpense_Search(t *testing.T) {
+	t.Parallel()
+
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+
+	user := f.InsertUser()
+	comfort := f.InsertGoal(&domain.Goal{Name: domain.Comfort, UserID: user.ID})
+	pleasures := f.InsertGoal(&domain.Goal{Name: domain.Pleasures, UserID: user.ID})
+
+	jan := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)
+	mar := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	rent := f.InsertExpense(&domain.Expense{Name: "Rent", Value: 1000 * 100, Date: jan, GoalID: comfort.ID, UserID: user.ID})
+	cafe := f.InsertExpense(&domain.Expense{Name: "Café", Value: 5 * 100, Date: feb, GoalID: pleasures.ID, UserID: user.ID})
+	cinema := f.InsertExpense(&domain.Expense{Name: "Cinema", Value: 20 * 100, Date: feb, GoalID: pleasures.ID, UserID: user.ID})
+	market := f.InsertExpense(&domain.Expense{Name: "Market", Value: 200 * 100, Date: mar, GoalID: comfort.ID, UserID: user.ID})
+
+	otherUser := f.InsertUser()
+	otherGoal := f.InsertGoal(&domain.Goal{Name: domain.Comfort, UserID: otherUser.ID})
+	f.InsertExpense(&domain.Expense{Name: "Other", Date: feb, GoalID: otherGoal.ID, UserID: otherUser.ID})
+
+	r := NewTestPostgresExpenseRepo(t, tx)
+
+	tests := []struct {
+		name   string
+		filter domain.ExpenseFilter
+		want   []uint
+	}{
+		{"defaults to newest first", domain.ExpenseFilter{}, []uint{market.ID, cinema.ID, cafe.ID, rent.ID}},
+		{"date ascending", domain.ExpenseFilter{Sort: domain.SortDateAsc}, []uint{rent.ID, cafe.ID, cinema.ID, market.ID}},
+		{"value descending", domain.ExpenseFilter{Sort: domain.SortValueDesc}, []uint{rent.ID, market.ID, cinema.ID, cafe.ID}},
+		{"value ascending", domain.ExpenseFilter{Sort: domain.SortValueAsc}, []uint{cafe.ID, cinema.ID, market.ID, rent.ID}},
+		{"date range", domain.ExpenseFilter{From: feb, To: mar}, []uint{cinema.ID, cafe.ID}},
+		{"goals", domain.ExpenseFilter{GoalIDs: []uint{comfort.ID}}, []uint{market.ID, rent.ID}},
+		{"value range", domain.ExpenseFilter{MinValue: 20 * 100, MaxValue: 200 * 100}, []uint{market.ID, cinema.ID}},
+		{"name ignoring accents and case", domain.ExpenseFilter{Query: "CAFE"}, []uint{cafe.ID}},
+		{"limit", domain.ExpenseFilter{Limit: 2}, []uint{market.ID, cinema.ID}},
+		{
+			"after a cursor breaking ties by id",
+			domain.ExpenseFilter{After: &domain.ExpenseCursor{ID: cinema.ID, Date: feb}},
+			[]uint{cafe.ID, rent.ID},
+		},
+		{
+			"after a value cursor",
+			domain.ExpenseFilter{Sort: domain.SortValueAsc, After: &domain.ExpenseCursor{ID: cinema.ID, Value: cinema.Value}},
+			[]uint{market.ID, rent.ID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expenses, err := r.Search(context.Background(), tt.filter, user.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, util.Map(expenses, func(e domain.Expense) uint { return e.ID }))
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/joaopsramos/fincon/internal/domain"
 	"github.com/joaopsramos/fincon/internal/errs"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PostgresGoalRepository struct {
@@ -19,7 +20,14 @@ func NewPostgresGoal(db *gorm.DB) domain.GoalRepo {
 
 func (r PostgresGoalRepository) All(ctx context.Context, userID uuid.UUID) []domain.Goal {
 	var g []domain.Goal
-	r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&g)
+	conn(ctx, r.db).Where("user_id = ? AND archived_at IS NULL", userID).Order("position, id").Find(&g)
+
+	return g
+}
+
+func (r PostgresGoalRepository) AllWithArchived(ctx context.Context, userID uuid.UUID) []domain.Goal {
+	var g []domain.Goal
+	conn(ctx, r.db).Where("user_id = ?", userID).Order("archived_at NULLS FIRST, position, id").Find(&g)
 
 	return g
 }
@@ -27,7 +35,7 @@ func (r PostgresGoalRepository) All(ctx context.Context, userID uuid.UUID) []dom
 func (r PostgresGoalRepository) Get(ctx context.Context, id uint, userID uuid.UUID) (*domain.Goal, error) {
 	goal := domain.Goal{ID: id}
 
-	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Take(&goal).Error; err != nil {
+	if err := conn(ctx, r.db).Where("user_id = ?", userID).Take(&goal).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return &domain.Goal{}, errs.NewNotFound("goal")
 		}
@@ -39,23 +47,119 @@ func (r PostgresGoalRepository) Get(ctx context.Context, id uint, userID uuid.UU
 }
 
 func (r PostgresGoalRepository) Create(ctx context.Context, goals ...domain.Goal) error {
-	if err := r.db.WithContext(ctx).Create(goals).Error; err != nil {
+	if err := conn(ctx, r.db).Create(goals).Error; err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (r PostgresGoalRepository) UpdateAll(ctx context.Context, goals []domain.Goal) error {
-	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+func (r PostgresGoalRepository) Update(ctx context.Context, goal *domain.Goal) error {
+	if err := conn(ctx, r.db).Omit(clause.Associations).Save(goal).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r PostgresGoalRepository) UpdateAll(ctx context.Context, goals []domain.Goal, allocations []domain.GoalAllocation) error {
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		for _, g := range goals {
-			if err := r.db.Save(&g).Error; err != nil {
+			if err := tx.Omit(clause.Associations).Save(&g).Error; err != nil {
 				return err
 			}
 		}
 
-		return nil
+		if len(allocations) == 0 {
+			return nil
+		}
+
+		return tx.Omit(clause.Associations).Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "goal_id"}, {Name: "effective_from"}},
+			DoUpdates: clause.AssignmentColumns([]string{"percentage"}),
+		}).Create(&allocations).Error
 	})
 
 	return err
 }
+
+func (r PostgresGoalRepository) AllAllocations(ctx context.Context, userID uuid.UUID) ([]domain.GoalAllocation, error) {
+	var allocations []domain.GoalAllocation
+
+	err := conn(ctx, r.db).Where("user_id = ?", userID).Order("goal_id, effective_from").Find(&allocations).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return allocations, nil
+}
+
+// Delete removes the goal moving its expenses, recurring expenses, savings targets and percentage
+// to reassignTo. When reassignTo is nil the goal must not have any of them.
+func (r PostgresGoalRepository) Delete(ctx context.Context, goal *domain.Goal, reassignTo *domain.Goal) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if reassignTo == nil {
+			dependents := []struct {
+				model any
+				err   error
+			}{
+				{&domain.Expense{}, errs.NewValidationError("goal has expenses, choose a goal to reassign them to")},
+				{&domain.RecurringExpense{}, errs.NewValidationError("goal has recurring expenses, choose a goal to reassign them to")},
+				{&domain.SavingsTarget{}, errs.NewValidationError("goal has savings targets, choose a goal to reassign them to")},
+			}
+
+			for _, d := range dependents {
+				var count int64
+				if err := tx.Model(d.model).Where("goal_id = ?", goal.ID).Count(&count).Error; err != nil {
+					return err
+				}
+
+				if count > 0 {
+					return d.err
+				}
+			}
+		} else {
+			for _, model := range []any{&domain.Expense{}, &domain.RecurringExpense{}, &domain.SavingsTarget{}} {
+				err := tx.Model(model).Where("goal_id = ?", goal.ID).Update("goal_id", reassignTo.ID).Error
+				if err != nil {
+					return err
+				}
+			}
+
+			if err := mergeAllocations(tx, goal, reassignTo); err != nil {
+				return err
+			}
+
+			err := tx.Model(reassignTo).Update("percentage", gorm.Expr("percentage + ?", goal.Percentage)).Error
+			if err != nil {
+				return err
+			}
+
+			reassignTo.Percentage += goal.Percentage
+		}
+
+		return tx.Where("user_id = ?", goal.UserID).Delete(&domain.Goal{}, goal.ID).Error
+	})
+}
+
+// mergeAllocations adds the percentages goal had in each month to the allocations of target
+func mergeAllocations(tx *gorm.DB, goal *domain.Goal, target *domain.Goal) error {
+	var allocations []domain.GoalAllocation
+
+	goalIDs := []uint{goal.ID, target.ID}
+	if err := tx.Where("goal_id IN ?", goalIDs).Order("effective_from").Find(&allocations).Error; err != nil {
+		return err
+	}
+
+	if len(allocations) == 0 {
+		return nil
+	}
+
+	merged := domain.NewGoalAllocations(allocations).Merge(*goal, *target)
+
+	if err := tx.Where("goal_id IN ?", goalIDs).Delete(&domain.GoalAllocation{}).Error; err != nil {
+		return err
+	}
+
+	return tx.Omit(clause.Associations).Create(&merged).Error
+}
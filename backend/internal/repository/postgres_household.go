@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/errs"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresHouseholdRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresHousehold(db *gorm.DB) domain.HouseholdRepo {
+	return PostgresHouseholdRepository{db}
+}
+
+func (r PostgresHouseholdRepository) Create(ctx context.Context, h *domain.Household) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(h).Error; err != nil {
+			return err
+		}
+
+		owner := domain.HouseholdMember{HouseholdID: h.ID, UserID: h.OwnerID, Role: domain.RoleOwner}
+		return tx.Omit(clause.Associations).Create(&owner).Error
+	})
+}
+
+func (r PostgresHouseholdRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("household_id = ?", id).Delete(&domain.HouseholdInvitation{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("household_id = ?", id).Delete(&domain.HouseholdMember{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Delete(&domain.Household{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return errs.NewNotFound("household")
+		}
+
+		return nil
+	})
+}
+
+func (r PostgresHouseholdRepository) GetByOwner(ctx context.Context, ownerID uuid.UUID) (*domain.Household, error) {
+	var h domain.Household
+	if err := conn(ctx, r.db).Where("owner_id = ?", ownerID).Take(&h).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &domain.Household{}, errs.NewNotFound("household")
+		}
+
+		return &domain.Household{}, err
+	}
+
+	return &h, nil
+}
+
+// GetMembership returns the household if the user is one of its members
+func (r PostgresHouseholdRepository) GetMembership(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.Membership, error) {
+	var m []domain.Membership
+	err := r.membershipsQuery(ctx).
+		Where("households.id = ? AND household_members.user_id = ?", id, userID).
+		Find(&m).Error
+	if err != nil {
+		return &domain.Membership{}, err
+	}
+
+	if len(m) == 0 {
+		return &domain.Membership{}, errs.NewNotFound("household")
+	}
+
+	return &m[0], nil
+}
+
+func (r PostgresHouseholdRepository) AllMemberships(ctx context.Context, userID uuid.UUID) ([]domain.Membership, error) {
+	var m []domain.Membership
+	err := r.membershipsQuery(ctx).
+		Where("household_members.user_id = ?", userID).
+		Order("households.name, households.id").
+		Find(&m).Error
+
+	return m, err
+}
+
+func (r PostgresHouseholdRepository) Members(ctx context.Context, id uuid.UUID) ([]domain.HouseholdMember, error) {
+	var m []domain.HouseholdMember
+	err := conn(ctx, r.db).
+		Joins("User").
+		Where("household_id = ?", id).
+		Order("household_members.id").
+		Find(&m).Error
+
+	return m, err
+}
+
+func (r PostgresHouseholdRepository) UpdateMemberRole(ctx context.Context, id uuid.UUID, userID uuid.UUID, role domain.HouseholdRole) error {
+	result := conn(ctx, r.db).
+		Model(&domain.HouseholdMember{}).
+		Where("household_id = ? AND user_id = ? AND role <> ?", id, userID, domain.RoleOwner).
+		Update("role", role)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.NewNotFound("member")
+	}
+
+	return nil
+}
+
+func (r PostgresHouseholdRepository) RemoveMember(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	result := conn(ctx, r.db).
+		Where("household_id = ? AND user_id = ? AND role <> ?", id, userID, domain.RoleOwner).
+		Delete(&domain.HouseholdMember{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.NewNotFound("member")
+	}
+
+	return nil
+}
+
+func (r PostgresHouseholdRepository) CreateInvitation(ctx context.Context, i *domain.HouseholdInvitation) error {
+	return conn(ctx, r.db).Omit(clause.Associations).Create(i).Error
+}
+
+func (r PostgresHouseholdRepository) GetInvitationByToken(ctx context.Context, token string) (*domain.HouseholdInvitation, error) {
+	var i domain.HouseholdInvitation
+	if err := conn(ctx, r.db).Joins("Household").Where("token = ?", token).Take(&i).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &domain.HouseholdInvitation{}, errs.NewNotFound("invitation")
+		}
+
+		return &domain.HouseholdInvitation{}, err
+	}
+
+	return &i, nil
+}
+
+func (r PostgresHouseholdRepository) AcceptInvitation(ctx context.Context, i *domain.HouseholdInvitation, userID uuid.UUID) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UTC()
+
+		// Guards against the same invitation being accepted twice concurrently
+		result := tx.Model(&domain.HouseholdInvitation{}).
+			Where("id = ? AND accepted_at IS NULL", i.ID).
+			Update("accepted_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return errs.ErrInvalidToken
+		}
+
+		member := domain.HouseholdMember{HouseholdID: i.HouseholdID, UserID: userID, Role: i.Role}
+		err := tx.Omit(clause.Associations).
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "household_id"}, {Name: "user_id"}},
+				DoUpdates: clause.Assignments(map[string]any{"role": i.Role, "updated_at": now}),
+				Where:     clause.Where{Exprs: []clause.Expression{clause.Neq{Column: "household_members.role", Value: domain.RoleOwner}}},
+			}).
+			Create(&member).Error
+		if err != nil {
+			return err
+		}
+
+		i.AcceptedAt = &now
+		return nil
+	})
+}
+
+func (r PostgresHouseholdRepository) membershipsQuery(ctx context.Context) *gorm.DB {
+	return conn(ctx, r.db).
+		Table("households").
+		Select("households.id AS household_id, households.name, households.owner_id, household_members.role").
+		Joins("JOIN household_members ON household_members.household_id = households.id")
+}
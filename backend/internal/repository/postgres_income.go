@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/errs"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresIncomeRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresIncome(db *gorm.DB) domain.IncomeRepo {
+	return PostgresIncomeRepository{db}
+}
+
+func (r PostgresIncomeRepository) All(ctx context.Context, userID uuid.UUID) ([]domain.Income, error) {
+	var i []domain.Income
+	result := conn(ctx, r.db).Preload("Amounts", orderByMonth).Where("user_id = ?", userID).Order("start_date, id").Find(&i)
+
+	return i, result.Error
+}
+
+func (r PostgresIncomeRepository) Get(ctx context.Context, id uint, userID uuid.UUID) (*domain.Income, error) {
+	var i domain.Income
+	if err := conn(ctx, r.db).Preload("Amounts", orderByMonth).Where("user_id = ?", userID).Take(&i, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &domain.Income{}, errs.NewNotFound("income")
+		}
+
+		return &domain.Income{}, err
+	}
+
+	return &i, nil
+}
+
+func (r PostgresIncomeRepository) Create(ctx context.Context, i *domain.Income) error {
+	if err := conn(ctx, r.db).Create(i).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r PostgresIncomeRepository) Update(ctx context.Context, i *domain.Income) error {
+	if err := conn(ctx, r.db).Omit(clause.Associations).Save(i).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r PostgresIncomeRepository) Delete(ctx context.Context, id uint, userID uuid.UUID) error {
+	result := conn(ctx, r.db).Where("user_id = ?", userID).Delete(&domain.Income{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.NewNotFound("income")
+	}
+
+	return nil
+}
+
+func (r PostgresIncomeRepository) SetAmount(ctx context.Context, a *domain.IncomeAmount) error {
+	return conn(ctx, r.db).
+		Clauses(clause.OnConflict{UpdateAll: true}).
+		Omit(clause.Associations).
+		Create(a).Error
+}
+
+func (r PostgresIncomeRepository) DeleteAmount(ctx context.Context, incomeID uint, month time.Time) error {
+	result := conn(ctx, r.db).Where("income_id = ? AND month = ?", incomeID, month).Delete(&domain.IncomeAmount{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.NewNotFound("income amount")
+	}
+
+	return nil
+}
+
+func orderByMonth(db *gorm.DB) *gorm.DB {
+	return db.Order("month")
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/errs"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresNotificationRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresNotification(db *gorm.DB) domain.NotificationRepo {
+	return PostgresNotificationRepository{db}
+}
+
+func (r PostgresNotificationRepository) Create(ctx context.Context, n *domain.Notification) (bool, error) {
+	result := conn(ctx, r.db).
+		Omit(clause.Associations).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(n)
+
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r PostgresNotificationRepository) All(ctx context.Context, unreadOnly bool, userID uuid.UUID) ([]domain.Notification, error) {
+	query := conn(ctx, r.db).Preload("Goal").Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var notifications []domain.Notification
+	err := query.Order("created_at DESC, id DESC").Find(&notifications).Error
+
+	return notifications, err
+}
+
+func (r PostgresNotificationRepository) MarkRead(ctx context.Context, id uint, userID uuid.UUID) error {
+	result := conn(ctx, r.db).
+		Model(&domain.Notification{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Update("read_at", gorm.Expr("COALESCE(read_at, ?)", time.Now().UTC()))
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.NewNotFound("notification")
+	}
+
+	return nil
+}
+
+func (r PostgresNotificationRepository) MarkAllRead(ctx context.Context, userID uuid.UUID) error {
+	return conn(ctx, r.db).
+		Model(&domain.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", time.Now().UTC()).Error
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/joaopsramos/fincon/internal/domain"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresOutboxRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresOutbox(db *gorm.DB) domain.OutboxRepo {
+	return PostgresOutboxRepository{db}
+}
+
+func (r PostgresOutboxRepository) Enqueue(ctx context.Context, e *domain.OutboxEmail) error {
+	if e.NextAttemptAt.IsZero() {
+		e.NextAttemptAt = time.Now().UTC()
+	}
+
+	return conn(ctx, r.db).Create(e).Error
+}
+
+func (r PostgresOutboxRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.OutboxEmail, error) {
+	var emails []domain.OutboxEmail
+
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", domain.OutboxPending, now).
+			Order("next_attempt_at, id").
+			Limit(limit).
+			Find(&emails).Error
+		if err != nil || len(emails) == 0 {
+			return err
+		}
+
+		ids := make([]uint, len(emails))
+		for i, e := range emails {
+			ids[i] = e.ID
+		}
+
+		return tx.Model(&domain.OutboxEmail{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+
+	return emails, err
+}
+
+func (r PostgresOutboxRepository) MarkSent(ctx context.Context, id uint) error {
+	return conn(ctx, r.db).
+		Model(&domain.OutboxEmail{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"status": domain.OutboxSent, "sent_at": time.Now().UTC(), "last_error": ""}).Error
+}
+
+func (r PostgresOutboxRepository) MarkFailed(ctx context.Context, id uint, attempts int, nextAttemptAt *time.Time, lastErr string) error {
+	updates := map[string]any{"attempts": attempts, "last_error": lastErr}
+
+	if nextAttemptAt == nil {
+		updates["status"] = domain.OutboxDead
+	} else {
+		updates["next_attempt_at"] = *nextAttemptAt
+	}
+
+	return conn(ctx, r.db).Model(&domain.OutboxEmail{}).Where("id = ?", id).Updates(updates).Error
+}
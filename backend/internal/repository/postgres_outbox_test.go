@@ -0,0 +1,106 @@
+package repository_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/repository"
+	"github.com/joaopsramos/fincon/internal/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresOutbox_ClaimDue(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	repo := repository.NewPostgresOutbox(tx)
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	due := domain.OutboxEmail{ToEmail: "due@mail.com", Template: "forgot_password", Data: domain.OutboxData{"Link": "http://link", "Threshold": 80}}
+	later := domain.OutboxEmail{ToEmail: "later@mail.com", NextAttemptAt: now.Add(time.Hour)}
+	dead := domain.OutboxEmail{ToEmail: "dead@mail.com", Status: domain.OutboxDead}
+
+	for _, e := range []*domain.OutboxEmail{&due, &later, &dead} {
+		require.NoError(t, repo.Enqueue(ctx, e))
+	}
+
+	claimed, err := repo.ClaimDue(ctx, now.Add(time.Second), time.Minute, 10)
+	a.NoError(err)
+	require.Len(t, claimed, 1)
+	a.Equal(due.ID, claimed[0].ID)
+	a.Equal(domain.OutboxData{"Link": "http://link", "Threshold": json.Number("80")}, claimed[0].Data)
+
+	// claimed emails are leased and not returned again until the lease expires
+	claimed, err = repo.ClaimDue(ctx, now.Add(time.Second), time.Minute, 10)
+	a.NoError(err)
+	a.Empty(claimed)
+
+	claimed, err = repo.ClaimDue(ctx, now.Add(2*time.Minute), time.Minute, 10)
+	a.NoError(err)
+	a.Len(claimed, 1)
+}
+
+func TestPostgresOutbox_Mark(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	repo := repository.NewPostgresOutbox(tx)
+	ctx := context.Background()
+
+	sent := domain.OutboxEmail{ToEmail: "sent@mail.com"}
+	retry := domain.OutboxEmail{ToEmail: "retry@mail.com"}
+	dead := domain.OutboxEmail{ToEmail: "dead@mail.com"}
+	for _, e := range []*domain.OutboxEmail{&sent, &retry, &dead} {
+		require.NoError(t, repo.Enqueue(ctx, e))
+	}
+
+	next := time.Now().UTC().Add(time.Hour)
+	a.NoError(repo.MarkSent(ctx, sent.ID))
+	a.NoError(repo.MarkFailed(ctx, retry.ID, 1, &next, "connection refused"))
+	a.NoError(repo.MarkFailed(ctx, dead.ID, 8, nil, "mailbox unavailable"))
+
+	tx.First(&sent, sent.ID)
+	a.Equal(domain.OutboxSent, sent.Status)
+	a.NotNil(sent.SentAt)
+
+	tx.First(&retry, retry.ID)
+	a.Equal(domain.OutboxPending, retry.Status)
+	a.Equal(1, retry.Attempts)
+	a.Equal("connection refused", retry.LastError)
+	a.WithinDuration(next, retry.NextAttemptAt, time.Second)
+
+	tx.First(&dead, dead.ID)
+	a.Equal(domain.OutboxDead, dead.Status)
+	a.Equal(8, dead.Attempts)
+	a.Equal("mailbox unavailable", dead.LastError)
+}
+
+func TestPostgresOutbox_EnqueueInTransaction(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	repo := repository.NewPostgresOutbox(tx)
+	transactor := repository.NewPostgresTransactor(tx)
+
+	rollback := errors.New("rollback")
+	err := transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		require.NoError(t, repo.Enqueue(ctx, &domain.OutboxEmail{ToEmail: "rolled-back@mail.com"}))
+		return rollback
+	})
+	a.ErrorIs(err, rollback)
+
+	err = transactor.Transaction(context.Background(), func(ctx context.Context) error {
+		return repo.Enqueue(ctx, &domain.OutboxEmail{ToEmail: "committed@mail.com"})
+	})
+	a.NoError(err)
+
+	var emails []string
+	tx.Model(&domain.OutboxEmail{}).Order("id").Pluck("to_email", &emails)
+	a.Equal([]string{"committed@mail.com"}, emails)
+}
@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/errs"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresRecurringExpenseRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresRecurringExpense(db *gorm.DB) domain.RecurringExpenseRepo {
+	return PostgresRecurringExpenseRepository{db}
+}
+
+func (r PostgresRecurringExpenseRepository) All(ctx context.Context, userID uuid.UUID) ([]domain.RecurringExpense, error) {
+	var re []domain.RecurringExpense
+	result := conn(ctx, r.db).Where("user_id = ?", userID).Order("day_of_month, id").Find(&re)
+
+	return re, result.Error
+}
+
+func (r PostgresRecurringExpenseRepository) Get(ctx context.Context, id uint, userID uuid.UUID) (*domain.RecurringExpense, error) {
+	var re domain.RecurringExpense
+	if err := conn(ctx, r.db).Where("user_id = ?", userID).Take(&re, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &domain.RecurringExpense{}, errs.NewNotFound("recurring expense")
+		}
+
+		return &domain.RecurringExpense{}, err
+	}
+
+	return &re, nil
+}
+
+func (r PostgresRecurringExpenseRepository) Create(ctx context.Context, re *domain.RecurringExpense) error {
+	if err := conn(ctx, r.db).Create(re).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r PostgresRecurringExpenseRepository) Update(ctx context.Context, re *domain.RecurringExpense) error {
+	if err := conn(ctx, r.db).Omit(clause.Associations).Save(re).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Delete removes the template but keeps the expenses it already materialized,
+// detaching them so they behave like any other expense.
+func (r PostgresRecurringExpenseRepository) Delete(ctx context.Context, id uint, userID uuid.UUID) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("user_id = ?", userID).Delete(&domain.RecurringExpense{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return errs.NewNotFound("recurring expense")
+		}
+
+		return tx.Model(&domain.Expense{}).
+			Where("user_id = ?", userID).
+			Where("recurring_expense_id = ?", id).
+			Update("recurring_expense_id", nil).Error
+	})
+}
+
+func (r PostgresRecurringExpenseRepository) AllStartedBy(ctx context.Context, date time.Time) ([]domain.RecurringExpense, error) {
+	var re []domain.RecurringExpense
+	result := conn(ctx, r.db).
+		Where("date_trunc('month', start_date) <= date_trunc('month', ?::timestamp)", date).
+		Order("id").
+		Find(&re)
+
+	return re, result.Error
+}
+
+// Materialize claims each month with an occurrence before creating its expense, the
+// primary key of the occurrences makes concurrent calls create each expense only once
+func (r PostgresRecurringExpenseRepository) Materialize(ctx context.Context, re *domain.RecurringExpense, until time.Time) (int, error) {
+	created := 0
+
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for _, month := range re.MonthsUntil(until) {
+			ok, err := materializeMonth(tx, re, month)
+			if err != nil {
+				return err
+			}
+
+			if ok {
+				created++
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return created, nil
+}
+
+func (r PostgresRecurringExpenseRepository) MaterializeMonth(ctx context.Context, re *domain.RecurringExpense, month time.Time) (bool, error) {
+	if !re.OccursIn(month) {
+		return false, nil
+	}
+
+	var created bool
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) (err error) {
+		created, err = materializeMonth(tx, re, month)
+		return err
+	})
+
+	return created, err
+}
+
+// materializeMonth claims the month in the occurrences and creates its expense, doing
+// nothing when the month was already claimed
+func materializeMonth(tx *gorm.DB, re *domain.RecurringExpense, date time.Time) (bool, error) {
+	month := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	result := tx.Clauses(clause.OnConflict{DoNothing: true}).Omit(clause.Associations).
+		Create(&domain.RecurringOccurrence{RecurringExpenseID: re.ID, Month: month})
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	expense := re.ExpenseFor(month)
+	if err := tx.Omit(clause.Associations).Create(&expense).Error; err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
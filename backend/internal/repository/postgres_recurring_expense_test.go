@@ -0,0 +1,138 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/errs"
+	"github.com/joaopsramos/fincon/internal/repository"
+	"github.com/joaopsramos/fincon/internal/testhelper"
+	"github.com/joaopsramos/fincon/internal/util"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func NewTestPostgresRecurringExpenseRepo(t *testing.T, tx *gorm.DB) domain.RecurringExpenseRepo {
+	t.Helper()
+	return repository.NewPostgresRecurringExpense(tx)
+}
+
+func TestPostgresRecurringExpense_AllStartedBy(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+
+	user := f.InsertUser()
+	goal := f.InsertGoal(&domain.Goal{Name: domain.FixedCosts, UserID: user.ID})
+
+	now := testhelper.MiddleOfMonth()
+	lastMonth := now.AddDate(0, -1, 0)
+	nextMonth := now.AddDate(0, 1, 0)
+
+	f.InsertRecurringExpense(&domain.RecurringExpense{Name: "Rent", StartDate: lastMonth, GoalID: goal.ID, UserID: user.ID})
+	f.InsertRecurringExpense(&domain.RecurringExpense{Name: "Future", StartDate: nextMonth, GoalID: goal.ID, UserID: user.ID})
+	f.InsertRecurringExpense(&domain.RecurringExpense{Name: "Ended", StartDate: lastMonth.AddDate(0, -1, 0), EndDate: &lastMonth, GoalID: goal.ID, UserID: user.ID})
+
+	otherUser := f.InsertUser()
+	otherGoal := f.InsertGoal(&domain.Goal{Name: domain.FixedCosts, UserID: otherUser.ID})
+	f.InsertRecurringExpense(&domain.RecurringExpense{Name: "Other", StartDate: now, GoalID: otherGoal.ID, UserID: otherUser.ID})
+
+	r := NewTestPostgresRecurringExpenseRepo(t, tx)
+
+	templates, err := r.AllStartedBy(context.Background(), now)
+	assert.NoError(err)
+	assert.Equal([]string{"Rent", "Ended", "Other"}, util.Map(templates, func(re domain.RecurringExpense) string { return re.Name }))
+}
+
+func TestPostgresRecurringExpense_Materialize(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+
+	user := f.InsertUser()
+	goal := f.InsertGoal(&domain.Goal{Name: domain.FixedCosts, UserID: user.ID})
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	gym := f.InsertRecurringExpense(&domain.RecurringExpense{Name: "Gym", DayOfMonth: 10, StartDate: start, EndDate: &end, GoalID: goal.ID, UserID: user.ID})
+
+	// February was materialized before the occurrences were recorded
+	f.InsertExpense(&domain.Expense{Name: "Gym", Date: time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC), GoalID: goal.ID, UserID: user.ID, RecurringExpenseID: &gym.ID})
+	assert.NoError(tx.Create(&domain.RecurringOccurrence{RecurringExpenseID: gym.ID, Month: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)}).Error)
+
+	r := NewTestPostgresRecurringExpenseRepo(t, tx)
+
+	// Only January and March, the template ended before May
+	created, err := r.Materialize(context.Background(), &gym, time.Date(2025, 5, 20, 0, 0, 0, 0, time.UTC))
+	assert.NoError(err)
+	assert.Equal(2, created)
+
+	created, err = r.Materialize(context.Background(), &gym, time.Date(2025, 5, 20, 0, 0, 0, 0, time.UTC))
+	assert.NoError(err)
+	assert.Zero(created)
+
+	var dates []time.Time
+	assert.NoError(tx.Model(&domain.Expense{}).Where("recurring_expense_id = ?", gym.ID).Order("date").Pluck("date", &dates).Error)
+	assert.Equal([]time.Time{
+		time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC),
+	}, dates)
+}
+
+func TestPostgresRecurringExpense_MaterializeMonth(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+
+	user := f.InsertUser()
+	goal := f.InsertGoal(&domain.Goal{Name: domain.FixedCosts, UserID: user.ID})
+
+	start := time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC)
+	gym := f.InsertRecurringExpense(&domain.RecurringExpense{Name: "Gym", DayOfMonth: 10, StartDate: start, GoalID: goal.ID, UserID: user.ID})
+
+	r := NewTestPostgresRecurringExpenseRepo(t, tx)
+
+	// January 10 is before the start date
+	created, err := r.MaterializeMonth(context.Background(), &gym, start)
+	assert.NoError(err)
+	assert.False(created)
+
+	created, err = r.MaterializeMonth(context.Background(), &gym, time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC))
+	assert.NoError(err)
+	assert.True(created)
+
+	created, err = r.MaterializeMonth(context.Background(), &gym, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+	assert.NoError(err)
+	assert.False(created)
+
+	var dates []time.Time
+	assert.NoError(tx.Model(&domain.Expense{}).Where("recurring_expense_id = ?", gym.ID).Pluck("date", &dates).Error)
+	assert.Equal([]time.Time{time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)}, dates)
+}
+
+func TestPostgresRecurringExpense_Delete(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+
+	user := f.InsertUser()
+	goal := f.InsertGoal(&domain.Goal{Name: domain.FixedCosts, UserID: user.ID})
+	rent := f.InsertRecurringExpense(&domain.RecurringExpense{Name: "Rent", GoalID: goal.ID, UserID: user.ID})
+	expense := f.InsertExpense(&domain.Expense{Name: "Rent", GoalID: goal.ID, UserID: user.ID, RecurringExpenseID: &rent.ID})
+
+	r := NewTestPostgresRecurringExpenseRepo(t, tx)
+
+	assert.Equal(errs.NewNotFound("recurring expense"), r.Delete(context.Background(), rent.ID, f.InsertUser().ID))
+	assert.NoError(r.Delete(context.Background(), rent.ID, user.ID))
+
+	var e domain.Expense
+	assert.NoError(tx.First(&e, expense.ID).Error)
+	assert.Nil(e.RecurringExpenseID)
+}
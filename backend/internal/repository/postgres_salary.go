@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/joaopsramos/fincon/internal/domain"
@@ -21,7 +22,19 @@ func NewPostgresSalary(db *gorm.DB) domain.SalaryRepo {
 func (r PostgresSalaryRepository) Get(ctx context.Context, userID uuid.UUID) (*domain.Salary, error) {
 	var s domain.Salary
 
-	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Take(&s).Error
+	now := time.Now().UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	err := conn(ctx, r.db).
+		Where("user_id = ? AND effective_from <= ?", userID, monthStart).
+		Order("effective_from DESC").
+		Take(&s).Error
+
+	// When there are only versions starting in the future, the first one is used
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = conn(ctx, r.db).Where("user_id = ?", userID).Order("effective_from").Take(&s).Error
+	}
+
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return &domain.Salary{}, errs.NewNotFound("salary")
 	} else if err != nil {
@@ -31,8 +44,15 @@ func (r PostgresSalaryRepository) Get(ctx context.Context, userID uuid.UUID) (*d
 	return &s, nil
 }
 
+func (r PostgresSalaryRepository) History(ctx context.Context, userID uuid.UUID) ([]domain.Salary, error) {
+	var s []domain.Salary
+	result := conn(ctx, r.db).Where("user_id = ?", userID).Order("effective_from").Find(&s)
+
+	return s, result.Error
+}
+
 func (r PostgresSalaryRepository) Create(ctx context.Context, s *domain.Salary) error {
-	if err := r.db.WithContext(ctx).Create(s).Error; err != nil {
+	if err := conn(ctx, r.db).Create(s).Error; err != nil {
 		return err
 	}
 
@@ -40,7 +60,7 @@ func (r PostgresSalaryRepository) Create(ctx context.Context, s *domain.Salary)
 }
 
 func (r PostgresSalaryRepository) Update(ctx context.Context, s *domain.Salary) error {
-	if err := r.db.WithContext(ctx).Model(s).Updates(*s).Error; err != nil {
+	if err := conn(ctx, r.db).Model(s).Updates(*s).Error; err != nil {
 		return err
 	}
 
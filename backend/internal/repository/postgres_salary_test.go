@@ -3,10 +3,12 @@ package repository_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/joaopsramos/fincon/internal/domain"
 	"github.com/joaopsramos/fincon/internal/repository"
 	"github.com/joaopsramos/fincon/internal/testhelper"
+	"github.com/joaopsramos/fincon/internal/util"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
 )
@@ -32,3 +34,33 @@ func TestPostgresSalary_Get(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, int64(200), salary.Amount)
 }
+
+func TestPostgresSalary_History(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+
+	now := time.Now().UTC()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	user := f.InsertUser()
+	f.InsertSalary(
+		&domain.Salary{Amount: 300, EffectiveFrom: monthStart.AddDate(0, 1, 0), UserID: user.ID},
+		&domain.Salary{Amount: 100, EffectiveFrom: monthStart.AddDate(0, -2, 0), UserID: user.ID},
+		&domain.Salary{Amount: 200, EffectiveFrom: monthStart, UserID: user.ID},
+	)
+	f.InsertSalary(&domain.Salary{Amount: 400, UserID: f.InsertUser().ID})
+
+	r := NewTestPostgresSalaryRepo(t, tx)
+
+	history, err := r.History(context.Background(), user.ID)
+	assert.NoError(err)
+	assert.Equal([]int64{100, 200, 300}, util.Map(history, func(s domain.Salary) int64 { return s.Amount }))
+
+	// the future version is not the current one yet
+	salary, err := r.Get(context.Background(), user.ID)
+	assert.NoError(err)
+	assert.Equal(int64(200), salary.Amount)
+}
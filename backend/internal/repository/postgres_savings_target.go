@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/errs"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresSavingsTargetRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresSavingsTarget(db *gorm.DB) domain.SavingsTargetRepo {
+	return PostgresSavingsTargetRepository{db}
+}
+
+func (r PostgresSavingsTargetRepository) All(ctx context.Context, userID uuid.UUID) ([]domain.SavingsTarget, error) {
+	var t []domain.SavingsTarget
+	result := conn(ctx, r.db).Where("user_id = ?", userID).Order("deadline, id").Find(&t)
+
+	return t, result.Error
+}
+
+func (r PostgresSavingsTargetRepository) Get(ctx context.Context, id uint, userID uuid.UUID) (*domain.SavingsTarget, error) {
+	var t domain.SavingsTarget
+	if err := conn(ctx, r.db).Where("user_id = ?", userID).Take(&t, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &domain.SavingsTarget{}, errs.NewNotFound("savings target")
+		}
+
+		return &domain.SavingsTarget{}, err
+	}
+
+	return &t, nil
+}
+
+func (r PostgresSavingsTargetRepository) Create(ctx context.Context, t *domain.SavingsTarget) error {
+	return conn(ctx, r.db).Omit(clause.Associations).Create(t).Error
+}
+
+func (r PostgresSavingsTargetRepository) Update(ctx context.Context, t *domain.SavingsTarget) error {
+	return conn(ctx, r.db).Omit(clause.Associations).Save(t).Error
+}
+
+func (r PostgresSavingsTargetRepository) Delete(ctx context.Context, id uint, userID uuid.UUID) error {
+	result := conn(ctx, r.db).Where("user_id = ?", userID).Delete(&domain.SavingsTarget{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.NewNotFound("savings target")
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/errs"
+	"gorm.io/gorm"
+)
+
+type PostgresSessionRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresSession(db *gorm.DB) domain.SessionRepo {
+	return PostgresSessionRepository{db}
+}
+
+func (r PostgresSessionRepository) Create(ctx context.Context, s *domain.Session) error {
+	return conn(ctx, r.db).Create(s).Error
+}
+
+func (r PostgresSessionRepository) Get(ctx context.Context, id uuid.UUID) (*domain.Session, error) {
+	return r.take(ctx, "id = ?", id)
+}
+
+func (r PostgresSessionRepository) GetByRefreshTokenHash(ctx context.Context, hash string) (*domain.Session, error) {
+	return r.take(ctx, "refresh_token_hash = ?", hash)
+}
+
+func (r PostgresSessionRepository) GetByPreviousTokenHash(ctx context.Context, hash string) (*domain.Session, error) {
+	return r.take(ctx, "previous_token_hash = ?", hash)
+}
+
+func (r PostgresSessionRepository) Rotate(ctx context.Context, s *domain.Session, newHash string, expiresAt time.Time) error {
+	now := time.Now().UTC()
+
+	result := conn(ctx, r.db).
+		Model(&domain.Session{}).
+		Where("id = ? AND refresh_token_hash = ?", s.ID, s.RefreshTokenHash).
+		Updates(map[string]any{
+			"refresh_token_hash":  newHash,
+			"previous_token_hash": s.RefreshTokenHash,
+			"expires_at":          expiresAt,
+			"last_used_at":        now,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.ErrInvalidToken
+	}
+
+	s.PreviousTokenHash = s.RefreshTokenHash
+	s.RefreshTokenHash = newHash
+	s.ExpiresAt = expiresAt
+	s.LastUsedAt = now
+
+	return nil
+}
+
+func (r PostgresSessionRepository) Revoke(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	result := conn(ctx, r.db).
+		Model(&domain.Session{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", time.Now().UTC())
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.NewNotFound("session")
+	}
+
+	return nil
+}
+
+func (r PostgresSessionRepository) RevokeAll(ctx context.Context, userID uuid.UUID) error {
+	return conn(ctx, r.db).
+		Model(&domain.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now().UTC()).Error
+}
+
+func (r PostgresSessionRepository) RevokeOthers(ctx context.Context, userID uuid.UUID, keepID uuid.UUID) error {
+	return conn(ctx, r.db).
+		Model(&domain.Session{}).
+		Where("user_id = ? AND id <> ? AND revoked_at IS NULL", userID, keepID).
+		Update("revoked_at", time.Now().UTC()).Error
+}
+
+func (r PostgresSessionRepository) take(ctx context.Context, query string, arg any) (*domain.Session, error) {
+	var s domain.Session
+	if err := conn(ctx, r.db).Where(query, arg).Take(&s).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &domain.Session{}, errs.NewNotFound("session")
+		}
+
+		return &domain.Session{}, err
+	}
+
+	return &s, nil
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/errs"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresTagRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresTag(db *gorm.DB) domain.TagRepo {
+	return PostgresTagRepository{db}
+}
+
+func (r PostgresTagRepository) All(ctx context.Context, userID uuid.UUID) ([]domain.Tag, error) {
+	var t []domain.Tag
+	result := conn(ctx, r.db).Where("user_id = ?", userID).Order("name, id").Find(&t)
+
+	return t, result.Error
+}
+
+func (r PostgresTagRepository) Get(ctx context.Context, id uint, userID uuid.UUID) (*domain.Tag, error) {
+	var t domain.Tag
+	if err := conn(ctx, r.db).Where("user_id = ?", userID).Take(&t, id).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &domain.Tag{}, errs.NewNotFound("tag")
+		}
+
+		return &domain.Tag{}, err
+	}
+
+	return &t, nil
+}
+
+// GetMany returns the tags with the given ids, failing when any of them doesn't exist
+func (r PostgresTagRepository) GetMany(ctx context.Context, ids []uint, userID uuid.UUID) ([]domain.Tag, error) {
+	var t []domain.Tag
+	if len(ids) == 0 {
+		return t, nil
+	}
+
+	if err := conn(ctx, r.db).Where("user_id = ? AND id IN ?", userID, ids).Order("name, id").Find(&t).Error; err != nil {
+		return nil, err
+	}
+
+	unique := make(map[uint]struct{}, len(ids))
+	for _, id := range ids {
+		unique[id] = struct{}{}
+	}
+
+	if len(t) != len(unique) {
+		return nil, errs.NewNotFound("tag")
+	}
+
+	return t, nil
+}
+
+func (r PostgresTagRepository) Create(ctx context.Context, t *domain.Tag) error {
+	if err := conn(ctx, r.db).Create(t).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r PostgresTagRepository) Update(ctx context.Context, t *domain.Tag) error {
+	if err := conn(ctx, r.db).Omit(clause.Associations).Save(t).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r PostgresTagRepository) Delete(ctx context.Context, id uint, userID uuid.UUID) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("user_id = ?", userID).Delete(&domain.Tag{}, id)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return errs.NewNotFound("tag")
+		}
+
+		return tx.Exec("DELETE FROM expense_tags WHERE tag_id = ?", id).Error
+	})
+}
+
+func (r PostgresTagRepository) Merge(ctx context.Context, source *domain.Tag, target *domain.Tag) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`
+			INSERT INTO expense_tags (expense_id, tag_id)
+			SELECT expense_id, ? FROM expense_tags WHERE tag_id = ?
+			ON CONFLICT DO NOTHING`, target.ID, source.ID).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Exec("DELETE FROM expense_tags WHERE tag_id = ?", source.ID).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("user_id = ?", source.UserID).Delete(&domain.Tag{}, source.ID).Error
+	})
+}
+
+func (r PostgresTagRepository) MonthlyTotals(ctx context.Context, from time.Time, to time.Time, userID uuid.UUID) ([]domain.TagMonthlyTotal, error) {
+	var totals []domain.TagMonthlyTotal
+	err := conn(ctx, r.db).Model(&domain.Tag{}).
+		Joins("JOIN expense_tags ON expense_tags.tag_id = tags.id").
+		Joins("JOIN expenses ON expenses.id = expense_tags.expense_id").
+		Where("tags.user_id = ?", userID).
+		Where("expenses.date >= ? AND expenses.date < ?", from, to).
+		Select("tags.id tag_id, tags.name, date_trunc('month', expenses.date) date, SUM(expenses.value) total").
+		Group("tags.id, date_trunc('month', expenses.date)").
+		Order("tags.name, tags.id, date").
+		Scan(&totals).Error
+	if err != nil {
+		return []domain.TagMonthlyTotal{}, err
+	}
+
+	return totals, nil
+}
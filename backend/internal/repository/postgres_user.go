@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/joaopsramos/fincon/internal/domain"
@@ -18,10 +19,9 @@ func NewPostgresUser(db *gorm.DB) domain.UserRepo {
 }
 
 func (r PostgresUserRepository) Create(ctx context.Context, user *domain.User, salary *domain.Salary) error {
-	defaultPercentages := domain.DefaultGoalPercentages()
-	goals := make([]domain.Goal, 0, len(defaultPercentages))
+	goals := domain.DefaultGoals()
 
-	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(user).Error; err != nil {
 			return err
 		}
@@ -33,8 +33,8 @@ func (r PostgresUserRepository) Create(ctx context.Context, user *domain.User, s
 			return err
 		}
 
-		for name, percentage := range defaultPercentages {
-			goals = append(goals, domain.Goal{Name: name, Percentage: percentage, UserID: user.ID})
+		for i := range goals {
+			goals[i].UserID = user.ID
 		}
 
 		txGoalRepo := NewPostgresGoal(tx)
@@ -48,15 +48,21 @@ func (r PostgresUserRepository) Create(ctx context.Context, user *domain.User, s
 	return err
 }
 
+func (r PostgresUserRepository) All(ctx context.Context) ([]domain.User, error) {
+	var users []domain.User
+	err := conn(ctx, r.db).Order("created_at, email").Find(&users).Error
+	return users, err
+}
+
 func (r PostgresUserRepository) Get(ctx context.Context, id uuid.UUID) (*domain.User, error) {
 	var user domain.User
-	result := r.db.Take(&user, id)
+	result := conn(ctx, r.db).Take(&user, id)
 	return &user, result.Error
 }
 
 func (r PostgresUserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	var user domain.User
-	if err := r.db.Where("email = ?", email).Take(&user).Error; err != nil {
+	if err := conn(ctx, r.db).Where("email = ?", email).Take(&user).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, errs.NewNotFound("user")
 		}
@@ -68,12 +74,12 @@ func (r PostgresUserRepository) GetByEmail(ctx context.Context, email string) (*
 }
 
 func (r PostgresUserRepository) CreateToken(ctx context.Context, token *domain.UserToken) error {
-	return r.db.WithContext(ctx).Create(token).Error
+	return conn(ctx, r.db).Create(token).Error
 }
 
-func (r PostgresUserRepository) GetUserTokenByToken(ctx context.Context, token string) (*domain.UserToken, error) {
+func (r PostgresUserRepository) GetUserTokenByToken(ctx context.Context, token string, purpose domain.TokenPurpose) (*domain.UserToken, error) {
 	var userToken domain.UserToken
-	err := r.db.Where("token = ?", token).Take(&userToken).Error
+	err := conn(ctx, r.db).Where("token = ? AND purpose = ?", token, purpose).Take(&userToken).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, errs.NewNotFound("token")
@@ -86,7 +92,7 @@ func (r PostgresUserRepository) GetUserTokenByToken(ctx context.Context, token s
 }
 
 func (r PostgresUserRepository) UpdateUserPassword(ctx context.Context, userID uuid.UUID, hashedPassword string) error {
-	result := r.db.WithContext(ctx).Model(&domain.User{}).Where("id = ?", userID).Update("hash_password", hashedPassword)
+	result := conn(ctx, r.db).Model(&domain.User{}).Where("id = ?", userID).Update("hash_password", hashedPassword)
 
 	if result.Error != nil {
 		return result.Error
@@ -99,8 +105,93 @@ func (r PostgresUserRepository) UpdateUserPassword(ctx context.Context, userID u
 	return nil
 }
 
+// UpdateEmail changes the user email, the new address must be verified again
+func (r PostgresUserRepository) UpdateEmail(ctx context.Context, userID uuid.UUID, email string) error {
+	result := conn(ctx, r.db).Model(&domain.User{}).Where("id = ?", userID).Updates(map[string]any{
+		"email":       email,
+		"verified_at": nil,
+	})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.NewNotFound("user")
+	}
+
+	return nil
+}
+
+// Delete removes the user and everything that belongs to them
+func (r PostgresUserRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec("DELETE FROM expense_tags WHERE expense_id IN (SELECT id FROM expenses WHERE user_id = ?)", userID).Error
+		if err != nil {
+			return err
+		}
+
+		// Households owned by the user go away, the ones they are a member of are left
+		err = tx.Exec("DELETE FROM household_invitations WHERE household_id IN (SELECT id FROM households WHERE owner_id = ?)", userID).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Exec("DELETE FROM household_members WHERE user_id = ? OR household_id IN (SELECT id FROM households WHERE owner_id = ?)", userID, userID).Error
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Where("owner_id = ?", userID).Delete(&domain.Household{}).Error; err != nil {
+			return err
+		}
+
+		// Order matters, rows must be deleted before the ones they reference
+		owned := []any{
+			&domain.Notification{},
+			&domain.SavingsTarget{},
+			&domain.Expense{},
+			&domain.RecurringExpense{},
+			&domain.GoalAllocation{},
+			&domain.Goal{},
+			&domain.Salary{},
+			&domain.Income{},
+			&domain.Tag{},
+			&domain.UserToken{},
+			&domain.Session{},
+			&domain.RecoveryCode{},
+			&domain.DigestPreference{},
+		}
+
+		for _, model := range owned {
+			if err := tx.Where("user_id = ?", userID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		result := tx.Delete(&domain.User{}, "id = ?", userID)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return errs.NewNotFound("user")
+		}
+
+		return nil
+	})
+}
+
+func (r PostgresUserRepository) MarkAsVerified(ctx context.Context, userID uuid.UUID) error {
+	result := conn(ctx, r.db).Model(&domain.User{}).
+		Where("id = ? AND verified_at IS NULL", userID).
+		Update("verified_at", time.Now().UTC())
+
+	return result.Error
+}
+
 func (r PostgresUserRepository) MarkTokenAsUsed(ctx context.Context, tokenID uint) error {
-	result := r.db.WithContext(ctx).Model(&domain.UserToken{}).Where("id = ?", tokenID).Update("used", true)
+	result := conn(ctx, r.db).Model(&domain.UserToken{}).Where("id = ?", tokenID).Update("used", true)
 
 	if result.Error != nil {
 		return result.Error
@@ -112,3 +203,107 @@ func (r PostgresUserRepository) MarkTokenAsUsed(ctx context.Context, tokenID uin
 
 	return nil
 }
+
+func (r PostgresUserRepository) SetTOTPSecret(ctx context.Context, userID uuid.UUID, secret string) error {
+	result := conn(ctx, r.db).Model(&domain.User{}).
+		Where("id = ? AND totp_enabled_at IS NULL", userID).
+		Update("totp_secret", secret)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.NewNotFound("user")
+	}
+
+	return nil
+}
+
+func (r PostgresUserRepository) EnableTOTP(ctx context.Context, userID uuid.UUID, step int64, recoveryCodeHashes []string) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&domain.User{}).Where("id = ?", userID).Updates(map[string]any{
+			"totp_enabled_at": time.Now().UTC(),
+			"totp_last_step":  step,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		return replaceRecoveryCodes(tx, userID, recoveryCodeHashes)
+	})
+}
+
+func (r PostgresUserRepository) DisableTOTP(ctx context.Context, userID uuid.UUID) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&domain.User{}).Where("id = ?", userID).Updates(map[string]any{
+			"totp_secret":     "",
+			"totp_enabled_at": nil,
+			"totp_last_step":  0,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		return replaceRecoveryCodes(tx, userID, nil)
+	})
+}
+
+// UseTOTPStep records the step of an accepted code, failing if it was already used
+func (r PostgresUserRepository) UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	result := conn(ctx, r.db).Model(&domain.User{}).
+		Where("id = ? AND totp_last_step < ?", userID, step).
+		Update("totp_last_step", step)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.ErrInvalidCode
+	}
+
+	return nil
+}
+
+func (r PostgresUserRepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error {
+	result := conn(ctx, r.db).Model(&domain.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now().UTC())
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.ErrInvalidCode
+	}
+
+	return nil
+}
+
+func (r PostgresUserRepository) CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int64, error) {
+	var count int64
+	err := conn(ctx, r.db).Model(&domain.RecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error
+
+	return count, err
+}
+
+func replaceRecoveryCodes(tx *gorm.DB, userID uuid.UUID, codeHashes []string) error {
+	if err := tx.Where("user_id = ?", userID).Delete(&domain.RecoveryCode{}).Error; err != nil {
+		return err
+	}
+
+	if len(codeHashes) == 0 {
+		return nil
+	}
+
+	codes := make([]domain.RecoveryCode, len(codeHashes))
+	for i, hash := range codeHashes {
+		codes[i] = domain.RecoveryCode{UserID: userID, CodeHash: hash}
+	}
+
+	return tx.Create(&codes).Error
+}
@@ -39,6 +39,26 @@ func TestPostgresUser_Create(t *testing.T) {
 	})
 }
 
+func TestPostgresUser_All(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	repo := repository.NewPostgresUser(tx)
+	f := testhelper.NewFactory(tx)
+
+	first := f.InsertUser()
+	second := f.InsertUser()
+
+	users, err := repo.All(context.Background())
+	assert.NoError(err)
+
+	var ids []uuid.UUID
+	for _, u := range users {
+		ids = append(ids, u.ID)
+	}
+	assert.Subset(ids, []uuid.UUID{first.ID, second.ID})
+}
+
 func TestPostgresUser_CreateToken(t *testing.T) {
 	t.Parallel()
 	assert := assert.New(t)
@@ -86,7 +106,7 @@ func TestPostgresUser_GetUserTokenByToken(t *testing.T) {
 			token := tt.setupToken(factory.InsertUser())
 
 			repo := repository.NewPostgresUser(tx)
-			gotToken, err := repo.GetUserTokenByToken(context.Background(), token.Token.String())
+			gotToken, err := repo.GetUserTokenByToken(context.Background(), token.Token.String(), domain.PasswordResetToken)
 
 			if tt.expectedErr != nil {
 				assert.Error(err)
@@ -203,3 +223,39 @@ func TestPostgresUser_MarkTokenAsUsed(t *testing.T) {
 		})
 	}
 }
+
+func TestPostgresUser_Delete(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	repo := repository.NewPostgresUser(tx)
+	f := testhelper.NewFactory(tx)
+
+	user := f.InsertUser()
+	goal := f.InsertGoal(&domain.Goal{UserID: user.ID})
+	tag := f.InsertTag(&domain.Tag{UserID: user.ID})
+	f.InsertExpense(&domain.Expense{GoalID: goal.ID, UserID: user.ID, Tags: []domain.Tag{tag}})
+	f.InsertRecurringExpense(&domain.RecurringExpense{GoalID: goal.ID, UserID: user.ID})
+	f.InsertSalary(&domain.Salary{UserID: user.ID})
+	f.InsertIncome(&domain.Income{UserID: user.ID})
+	f.InsertUserToken(&domain.UserToken{UserID: user.ID})
+	f.InsertSession(&domain.Session{UserID: user.ID})
+
+	other := f.InsertUser()
+	otherGoal := f.InsertGoal(&domain.Goal{UserID: other.ID})
+	f.InsertExpense(&domain.Expense{GoalID: otherGoal.ID, UserID: other.ID})
+
+	a.NoError(repo.Delete(context.Background(), user.ID))
+
+	for _, model := range []any{&domain.Expense{}, &domain.RecurringExpense{}, &domain.Goal{}, &domain.Salary{}, &domain.Income{}, &domain.Tag{}, &domain.UserToken{}, &domain.Session{}} {
+		var count int64
+		tx.Model(model).Where("user_id = ?", user.ID).Count(&count)
+		a.Zero(count, "%T", model)
+	}
+
+	var count int64
+	tx.Model(&domain.Expense{}).Where("user_id = ?", other.ID).Count(&count)
+	a.Equal(int64(1), count)
+
+	a.ErrorIs(repo.Delete(context.Background(), user.ID), errs.ErrNotFound{})
+}
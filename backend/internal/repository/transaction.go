@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/joaopsramos/fincon/internal/domain"
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+type PostgresTransactor struct {
+	db *gorm.DB
+}
+
+func NewPostgresTransactor(db *gorm.DB) domain.Transactor {
+	return PostgresTransactor{db}
+}
+
+// Transaction nests in the transaction of ctx when there is one, using a savepoint
+func (t PostgresTransactor) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return conn(ctx, t.db).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// ReadOnlyTransaction uses a repeatable read transaction, when nested in the transaction
+// of ctx it runs in a savepoint and keeps the isolation of the outer transaction
+func (t PostgresTransactor) ReadOnlyTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	opts := &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+
+	return conn(ctx, t.db).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	}, opts)
+}
+
+// conn returns the transaction started by a Transactor in ctx, or db when there is none
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+
+	return db.WithContext(ctx)
+}
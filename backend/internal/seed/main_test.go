@@ -0,0 +1,14 @@
+package seed_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/joaopsramos/fincon/internal/testhelper"
+)
+
+func TestMain(m *testing.M) {
+	testhelper.Setup()
+
+	os.Exit(m.Run())
+}
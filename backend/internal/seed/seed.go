@@ -0,0 +1,331 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/brianvoe/gofakeit/v7"
+	"github.com/google/uuid"
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/errs"
+	"github.com/joaopsramos/fincon/internal/repository"
+	"github.com/joaopsramos/fincon/internal/testhelper"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	// DemoPassword is the password of every seeded user
+	DemoPassword = "password"
+
+	DefaultSeed   = 1
+	DefaultUsers  = 3
+	DefaultMonths = 12
+)
+
+type Opts struct {
+	// Seed makes the generated data the same on every run, given the same Now
+	Seed   uint64
+	Users  int
+	Months int
+	// Now is the last month with expenses, defaults to the current time
+	Now time.Time
+}
+
+type Result struct {
+	Users    []domain.User
+	Expenses int
+}
+
+// Email is the address of the nth seeded user, starting at 1
+func Email(n int) string {
+	return fmt.Sprintf("demo%d@fincon.dev", n)
+}
+
+// expenseTemplate is a kind of expense a goal usually has, share is the part of the
+// goal limit it takes in a regular month
+type expenseTemplate struct {
+	name  string
+	share float64
+	// fixed expenses happen every month with about the same value
+	fixed bool
+}
+
+var templates = map[domain.GoalName][]expenseTemplate{
+	domain.FixedCosts: {
+		{"Rent", 0.55, true},
+		{"Electricity", 0.08, true},
+		{"Water", 0.03, true},
+		{"Internet", 0.04, true},
+		{"Groceries", 0.22, false},
+		{"Pharmacy", 0.04, false},
+	},
+	domain.Comfort: {
+		{"Streaming services", 0.1, true},
+		{"Gym", 0.15, true},
+		{"Uber", 0.2, false},
+		{"Food delivery", 0.3, false},
+		{"Cleaning service", 0.15, false},
+	},
+	domain.Goals: {
+		{"Travel fund", 0.6, true},
+		{"New car fund", 0.3, false},
+	},
+	domain.Pleasures: {
+		{"Restaurant", 0.35, false},
+		{"Cinema", 0.1, false},
+		{"Bar with friends", 0.25, false},
+		{"Concert tickets", 0.2, false},
+	},
+	domain.FinancialInvestments: {
+		{"Treasury bonds", 0.5, true},
+		{"Index fund", 0.4, true},
+	},
+	domain.Knowledge: {
+		{"Books", 0.4, false},
+		{"Online course", 0.5, false},
+	},
+}
+
+// installmentTemplates are purchases split in monthly installments
+var installmentTemplates = []struct {
+	goal         domain.GoalName
+	name         string
+	value        int64
+	installments int
+}{
+	{domain.Comfort, "Smartphone", 4_800_00, 10},
+	{domain.Goals, "Plane tickets", 3_200_00, 4},
+	{domain.Knowledge, "Laptop", 7_200_00, 12},
+}
+
+var tagNames = []string{"Essentials", "Family", "Work", "Health"}
+
+type seeder struct {
+	f     *testhelper.Factory
+	faker *gofakeit.Faker
+	now   time.Time
+	opts  Opts
+
+	expenses int
+}
+
+// Run fills the database with demo users that have salaries, goals and months of
+// plausible expenses, including installments and months where goals go over their
+// limit. Users seeded before with the same emails are deleted first.
+func Run(ctx context.Context, db *gorm.DB, opts Opts) (*Result, error) {
+	if opts.Users <= 0 {
+		opts.Users = DefaultUsers
+	}
+
+	if opts.Months <= 0 {
+		opts.Months = DefaultMonths
+	}
+
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+
+	hashPassword, err := bcrypt.GenerateFromPassword([]byte(DemoPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+
+	err = db.WithContext(ctx).Transaction(func(tx *gorm.DB) (err error) {
+		// The factory panics on insert errors
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("seed: %v", r)
+			}
+		}()
+
+		userRepo := repository.NewPostgresUser(tx)
+		f := testhelper.NewSeededFactory(tx, opts.Seed)
+		s := &seeder{f: f, faker: f.Faker(), now: opts.Now.UTC(), opts: opts}
+
+		for n := 1; n <= opts.Users; n++ {
+			existing, err := userRepo.GetByEmail(ctx, Email(n))
+			if err == nil {
+				err = userRepo.Delete(ctx, existing.ID)
+			}
+
+			if err != nil && !errors.Is(err, errs.ErrNotFound{}) {
+				return err
+			}
+
+			result.Users = append(result.Users, s.user(n, string(hashPassword)))
+		}
+
+		result.Expenses = s.expenses
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *seeder) user(n int, hashPassword string) domain.User {
+	verifiedAt := s.monthStart(s.opts.Months - 1)
+	user := s.f.InsertUser(&domain.User{
+		ID:           uuid.MustParse(s.faker.UUID()),
+		Email:        Email(n),
+		HashPassword: hashPassword,
+		VerifiedAt:   &verifiedAt,
+	})
+
+	salaries := s.salaries(user.ID)
+
+	goals := domain.DefaultGoals()
+	for i := range goals {
+		goals[i].UserID = user.ID
+		goals[i] = s.f.InsertGoal(&goals[i])
+	}
+
+	tags := make([]domain.Tag, len(tagNames))
+	for i, name := range tagNames {
+		tags[i] = s.f.InsertTag(&domain.Tag{Name: name, UserID: user.ID})
+	}
+
+	// A couple of months where the user went over budget, which is carried to the next month
+	overspent := map[int]domain.GoalName{
+		s.faker.IntRange(1, s.opts.Months-1): domain.Pleasures,
+		s.faker.IntRange(1, s.opts.Months-1): domain.Comfort,
+	}
+
+	for monthsAgo := s.opts.Months - 1; monthsAgo >= 0; monthsAgo-- {
+		salary := salaryAt(salaries, s.monthStart(monthsAgo))
+
+		for _, goal := range goals {
+			limit := salary * int64(goal.Percentage) / 100
+
+			usage := s.faker.Float64Range(0.65, 0.98)
+			if overspent[monthsAgo] == goal.Name {
+				usage = s.faker.Float64Range(1.25, 1.6)
+			}
+
+			s.monthExpenses(user.ID, goal, tags, monthsAgo, limit, usage)
+		}
+	}
+
+	for _, t := range installmentTemplates {
+		goal := goals[goalIndex(goals, t.goal)]
+		s.installments(user.ID, goal, t.name, t.value, t.installments, s.faker.IntRange(0, s.opts.Months-1))
+	}
+
+	s.f.InsertIncome(&domain.Income{
+		Name:      "Freelance project",
+		Amount:    int64(s.faker.IntRange(8, 30)) * 100_00,
+		StartDate: s.day(s.faker.IntRange(0, s.opts.Months-1), 20),
+		UserID:    user.ID,
+	})
+
+	return user
+}
+
+// salaries inserts the salary the user started with and a raise some months later
+func (s *seeder) salaries(userID uuid.UUID) []domain.Salary {
+	amount := int64(s.faker.IntRange(6, 30)) * 500_00
+	salaries := []domain.Salary{
+		s.f.InsertSalary(&domain.Salary{Amount: amount, EffectiveFrom: s.monthStart(s.opts.Months - 1), UserID: userID}),
+	}
+
+	if s.opts.Months >= 4 {
+		raise := amount * int64(s.faker.IntRange(5, 15)) / 100
+		salaries = append(salaries, s.f.InsertSalary(&domain.Salary{
+			Amount:        (amount + raise) / 100_00 * 100_00,
+			EffectiveFrom: s.monthStart(s.faker.IntRange(1, s.opts.Months/2)),
+			UserID:        userID,
+		}))
+	}
+
+	return salaries
+}
+
+// monthExpenses spreads the limit of the goal among its expense templates, usage scales
+// the expenses that aren't fixed
+func (s *seeder) monthExpenses(userID uuid.UUID, goal domain.Goal, tags []domain.Tag, monthsAgo int, limit int64, usage float64) {
+	for _, t := range templates[goal.Name] {
+		if !t.fixed && s.faker.Float64() < 0.3 {
+			continue
+		}
+
+		value := float64(limit) * t.share
+		if !t.fixed {
+			value *= usage * s.faker.Float64Range(0.7, 1.3)
+		}
+
+		if value < 1 {
+			continue
+		}
+
+		day := s.faker.IntRange(1, 28)
+		if t.fixed {
+			day = 5
+		}
+
+		date := s.day(monthsAgo, day)
+		// Expenses of the current month only go up to today
+		if date.After(s.now) {
+			continue
+		}
+
+		expense := &domain.Expense{Name: t.name, Value: int64(value), Date: date, GoalID: goal.ID, UserID: userID}
+		if s.faker.Float64() < 0.3 {
+			expense.Tags = []domain.Tag{tags[s.faker.IntRange(0, len(tags)-1)]}
+		}
+
+		s.f.InsertExpense(expense)
+		s.expenses++
+	}
+}
+
+// installments inserts a purchase split like the API does, its last installments may
+// fall in future months
+func (s *seeder) installments(userID uuid.UUID, goal domain.Goal, name string, value int64, n int, monthsAgo int) {
+	for i := range n {
+		s.f.InsertExpense(&domain.Expense{
+			Name:   fmt.Sprintf("%s (%d/%d)", name, i+1, n),
+			Value:  value / int64(n),
+			Date:   s.day(monthsAgo-i, 10),
+			GoalID: goal.ID,
+			UserID: userID,
+		})
+		s.expenses++
+	}
+}
+
+func (s *seeder) monthStart(monthsAgo int) time.Time {
+	return time.Date(s.now.Year(), s.now.Month()-time.Month(monthsAgo), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func (s *seeder) day(monthsAgo int, day int) time.Time {
+	return s.monthStart(monthsAgo).AddDate(0, 0, day-1)
+}
+
+// salaryAt returns the amount effective at date, salaries must be inserted oldest first
+func salaryAt(salaries []domain.Salary, date time.Time) int64 {
+	var amount int64
+	for _, s := range salaries {
+		if !s.EffectiveFrom.After(date) {
+			amount = s.Amount
+		}
+	}
+
+	return amount
+}
+
+func goalIndex(goals []domain.Goal, name domain.GoalName) int {
+	for i, g := range goals {
+		if g.Name == name {
+			return i
+		}
+	}
+
+	return 0
+}
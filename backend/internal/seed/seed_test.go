@@ -0,0 +1,100 @@
+package seed_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/seed"
+	"github.com/joaopsramos/fincon/internal/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	ctx := context.Background()
+
+	opts := seed.Opts{Seed: 42, Users: 2, Months: 6, Now: time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)}
+
+	type row struct {
+		Name  string
+		Value int64
+		Date  time.Time
+	}
+
+	expensesOf := func(tx *gorm.DB, email string) []row {
+		var rows []row
+		tx.Model(&domain.Expense{}).
+			Joins("JOIN users ON users.id = expenses.user_id").
+			Where("users.email = ?", email).
+			Order("date, name, value").
+			Scan(&rows)
+
+		return rows
+	}
+
+	result, err := seed.Run(ctx, tx, opts)
+	require.NoError(t, err)
+	require.Len(t, result.Users, 2)
+	a.Equal(seed.Email(1), result.Users[0].Email)
+	a.True(result.Users[0].IsVerified())
+	a.NotZero(result.Expenses)
+
+	first := expensesOf(tx, seed.Email(1))
+	a.NotEmpty(first)
+
+	t.Run("has installments", func(t *testing.T) {
+		var installments int
+		for _, r := range first {
+			if strings.HasPrefix(r.Name, "Smartphone (") {
+				installments++
+			}
+		}
+
+		assert.Equal(t, 10, installments)
+	})
+
+	t.Run("only past expenses in the current month", func(t *testing.T) {
+		for _, r := range first {
+			if r.Date.After(opts.Now) {
+				assert.True(t, strings.Contains(r.Name, "/"), "only installments can be in the future, got %s", r.Name)
+			}
+		}
+	})
+
+	t.Run("has default goals and salary raise", func(t *testing.T) {
+		var goals, salaries int64
+		tx.Model(&domain.Goal{}).Where("user_id = ?", result.Users[0].ID).Count(&goals)
+		tx.Model(&domain.Salary{}).Where("user_id = ?", result.Users[0].ID).Count(&salaries)
+
+		assert.Equal(t, int64(len(domain.DefaultGoals())), goals)
+		assert.Equal(t, int64(2), salaries)
+	})
+
+	t.Run("same seed generates the same data", func(t *testing.T) {
+		again, err := seed.Run(ctx, tx, opts)
+		require.NoError(t, err)
+
+		assert.Equal(t, result.Users[0].ID, again.Users[0].ID)
+		assert.Equal(t, result.Expenses, again.Expenses)
+		assert.Equal(t, first, expensesOf(tx, seed.Email(1)))
+
+		var users int64
+		tx.Model(&domain.User{}).Where("email = ?", seed.Email(1)).Count(&users)
+		assert.Equal(t, int64(1), users)
+	})
+
+	t.Run("another seed generates different data", func(t *testing.T) {
+		opts.Seed = 7
+		_, err := seed.Run(ctx, tx, opts)
+		require.NoError(t, err)
+
+		assert.NotEqual(t, first, expensesOf(tx, seed.Email(1)))
+	})
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joaopsramos/fincon/internal/config"
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/mail"
+	"github.com/joaopsramos/fincon/internal/types"
+	"github.com/joaopsramos/fincon/internal/util"
+)
+
+type AlertService struct {
+	notificationRepo domain.NotificationRepo
+	userRepo         domain.UserRepo
+	mailer           mail.Mailer
+}
+
+func NewAlertService(notificationRepo domain.NotificationRepo, userRepo domain.UserRepo, mailer mail.Mailer) AlertService {
+	return AlertService{notificationRepo: notificationRepo, userRepo: userRepo, mailer: mailer}
+}
+
+func (s *AlertService) All(ctx context.Context, unreadOnly bool, userID uuid.UUID) ([]domain.Notification, error) {
+	return s.notificationRepo.All(ctx, unreadOnly, userID)
+}
+
+func (s *AlertService) MarkRead(ctx context.Context, id uint, userID uuid.UUID) error {
+	return s.notificationRepo.MarkRead(ctx, id, userID)
+}
+
+func (s *AlertService) MarkAllRead(ctx context.Context, userID uuid.UUID) error {
+	return s.notificationRepo.MarkAllRead(ctx, userID)
+}
+
+// Evaluate notifies the thresholds the goals crossed in the month, goals and summary must be
+// in the same order. Thresholds that already fired in the month are skipped, and only the
+// highest threshold crossed at once is sent by email.
+func (s *AlertService) Evaluate(ctx context.Context, goals []domain.Goal, summary []SummaryGoal, month time.Time, userID uuid.UUID) error {
+	period := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	for i, g := range goals {
+		sg := summary[i]
+		if sg.MustSpend <= 0 {
+			continue
+		}
+
+		used := sg.Spent * 100 / sg.MustSpend
+
+		var crossed *domain.Notification
+		for _, threshold := range g.Thresholds() {
+			if used < float64(threshold) {
+				break
+			}
+
+			n := domain.Notification{
+				UserID:    userID,
+				Kind:      domain.GoalThresholdNotification,
+				GoalID:    g.ID,
+				Threshold: threshold,
+				Period:    period,
+				Spent:     toMoneyAmount(sg.Spent),
+				Limit:     toMoneyAmount(sg.MustSpend),
+			}
+
+			created, err := s.notificationRepo.Create(ctx, &n)
+			if err != nil {
+				return err
+			}
+
+			if created {
+				crossed = &n
+			}
+		}
+
+		if crossed != nil && g.EmailAlerts {
+			if err := s.sendEmail(ctx, g, *crossed); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *AlertService) sendEmail(ctx context.Context, goal domain.Goal, n domain.Notification) error {
+	user, err := s.userRepo.Get(ctx, n.UserID)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, mail.Email{
+		To:       types.MailContact{Email: user.Email},
+		Subject:  mail.GoalAlertSubject,
+		Template: mail.GoalAlertTemplate,
+		Data: util.M{
+			"GoalName":  string(goal.Name),
+			"Threshold": n.Threshold,
+			"Period":    fmt.Sprintf("%s de %d", monthNames[n.Period.Month()-1], n.Period.Year()),
+			"Spent":     formatBRL(util.MoneyAmountToFloat(n.Spent)),
+			"Limit":     formatBRL(util.MoneyAmountToFloat(n.Limit)),
+			"Link":      config.Get().WebURL,
+		},
+	})
+}
+
+func toMoneyAmount(value float64) int64 {
+	return int64(math.Round(value * 100))
+}
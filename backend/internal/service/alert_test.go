@@ -0,0 +1,156 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/mail"
+	"github.com/joaopsramos/fincon/internal/repository"
+	"github.com/joaopsramos/fincon/internal/service"
+	"github.com/joaopsramos/fincon/internal/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpenseService_GoalAlerts(t *testing.T) {
+	t.Parallel()
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	ctx := context.Background()
+
+	user := f.InsertUser()
+	f.InsertSalary(&domain.Salary{Amount: 1000 * 100, UserID: user.ID})
+	pleasures := f.InsertGoal(&domain.Goal{Name: domain.Pleasures, Percentage: 10, UserID: user.ID})
+	f.InsertGoal(&domain.Goal{Name: domain.Comfort, Percentage: 90, UserID: user.ID})
+
+	mailer := testhelper.NewMockMailer(t)
+	notificationRepo := repository.NewPostgresNotification(tx)
+	alertService := service.NewAlertService(notificationRepo, repository.NewPostgresUser(tx), mailer)
+	expenseService := service.NewExpenseService(
+		repository.NewPostgresExpense(tx),
+		repository.NewPostgresGoal(tx),
+		repository.NewPostgresSalary(tx),
+		repository.NewPostgresRecurringExpense(tx),
+		repository.NewPostgresIncome(tx),
+		repository.NewPostgresTag(tx),
+		repository.NewPostgresTransactor(tx),
+		alertService,
+		nil,
+	)
+
+	now := time.Now().UTC()
+	create := func(value float64, date time.Time) {
+		_, err := expenseService.Create(ctx, service.CreateExpenseDTO{Name: "Cinema", Value: value, Date: date, GoalID: int(pleasures.ID)}, user.ID)
+		require.NoError(t, err)
+	}
+
+	thresholds := func() []int {
+		notifications, err := notificationRepo.All(ctx, false, user.ID)
+		require.NoError(t, err)
+
+		var result []int
+		for _, n := range notifications {
+			result = append(result, n.Threshold)
+		}
+
+		return result
+	}
+
+	t.Run("below every threshold", func(t *testing.T) {
+		create(50, now)
+		assert.Empty(t, thresholds())
+	})
+
+	t.Run("cross the first threshold", func(t *testing.T) {
+		create(35, now)
+		assert.Equal(t, []int{80}, thresholds())
+
+		notifications, _ := notificationRepo.All(ctx, false, user.ID)
+		assert.Equal(t, pleasures.ID, notifications[0].GoalID)
+		assert.Equal(t, int64(85*100), notifications[0].Spent)
+		assert.Equal(t, int64(100*100), notifications[0].Limit)
+	})
+
+	t.Run("thresholds fire once per month", func(t *testing.T) {
+		create(5, now)
+		assert.Equal(t, []int{80}, thresholds())
+
+		create(10, now)
+		assert.ElementsMatch(t, []int{80, 100}, thresholds())
+
+		create(10, now)
+		assert.Len(t, thresholds(), 2)
+	})
+
+	t.Run("future months are not evaluated", func(t *testing.T) {
+		create(500, now.AddDate(0, 2, 0))
+		assert.Len(t, thresholds(), 2)
+	})
+
+	t.Run("email only the highest threshold crossed at once", func(t *testing.T) {
+		require.NoError(t, tx.Model(&pleasures).Updates(map[string]any{"email_alerts": true}).Error)
+
+		mailer.EXPECT().Send(mock.Anything, mock.MatchedBy(func(e mail.Email) bool {
+			return e.To.Email == user.Email &&
+				e.Subject == mail.GoalAlertSubject &&
+				e.Template == mail.GoalAlertTemplate &&
+				e.Data["Threshold"] == 100 &&
+				e.Data["Spent"] == "R$ 150,00"
+		})).Return(nil).Once()
+
+		create(150, now.AddDate(0, -1, 0))
+		assert.Len(t, thresholds(), 4)
+	})
+
+	t.Run("failing alerts don't fail the expense", func(t *testing.T) {
+		mailer.EXPECT().Send(mock.Anything, mock.AnythingOfType("mail.Email")).Return(errors.New("smtp down")).Once()
+
+		create(150, now.AddDate(0, -2, 0))
+		assert.Len(t, thresholds(), 6)
+	})
+
+	t.Run("imports are evaluated as well", func(t *testing.T) {
+		dtos := []service.CreateExpenseDTO{
+			{Name: "Cinema", Value: 50, Date: now.AddDate(0, -3, 0), GoalID: int(pleasures.ID)},
+			{Name: "Concert", Value: 50, Date: now.AddDate(0, -3, 0), GoalID: int(pleasures.ID)},
+		}
+
+		mailer.EXPECT().Send(mock.Anything, mock.AnythingOfType("mail.Email")).Return(nil).Once()
+
+		_, err := expenseService.Import(ctx, dtos, user.ID)
+		require.NoError(t, err)
+		assert.Len(t, thresholds(), 8)
+	})
+}
+
+func TestAlertService_CustomThresholds(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	ctx := context.Background()
+
+	user := f.InsertUser()
+	disabled := f.InsertGoal(&domain.Goal{Name: domain.Comfort, Percentage: 50, UserID: user.ID, AlertThresholds: []int{}})
+	custom := f.InsertGoal(&domain.Goal{Name: domain.Pleasures, Percentage: 50, UserID: user.ID, AlertThresholds: []int{150, 50}})
+
+	notificationRepo := repository.NewPostgresNotification(tx)
+	s := service.NewAlertService(notificationRepo, repository.NewPostgresUser(tx), nil)
+
+	summary := []service.SummaryGoal{
+		{Name: "Comfort", Spent: 1000, MustSpend: 500},
+		{Name: "Pleasures", Spent: 300, MustSpend: 500},
+	}
+
+	a.NoError(s.Evaluate(ctx, []domain.Goal{disabled, custom}, summary, time.Now(), user.ID))
+
+	notifications, err := notificationRepo.All(ctx, false, user.ID)
+	a.NoError(err)
+	require.Len(t, notifications, 1)
+	a.Equal(custom.ID, notifications[0].GoalID)
+	a.Equal(50, notifications[0].Threshold)
+}
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joaopsramos/fincon/internal/auth"
+	"github.com/joaopsramos/fincon/internal/config"
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/mail"
+	"github.com/joaopsramos/fincon/internal/types"
+	"github.com/joaopsramos/fincon/internal/util"
+	"github.com/shopspring/decimal"
+)
+
+var monthNames = [...]string{
+	"janeiro", "fevereiro", "março", "abril", "maio", "junho",
+	"julho", "agosto", "setembro", "outubro", "novembro", "dezembro",
+}
+
+type DigestService struct {
+	digestRepo     domain.DigestRepo
+	expenseService ExpenseService
+	transactor     domain.Transactor
+	mailer         mail.Mailer
+}
+
+// DigestPeriod is the time span a digest is sent for
+type DigestPeriod struct {
+	Frequency domain.DigestFrequency
+	Start     time.Time
+	End       time.Time
+}
+
+type digestGoal struct {
+	Name      string
+	Spent     string
+	MustSpend string
+	Used      string
+	Over      bool
+}
+
+func NewDigestService(
+	digestRepo domain.DigestRepo,
+	expenseService ExpenseService,
+	transactor domain.Transactor,
+	mailer mail.Mailer,
+) DigestService {
+	return DigestService{digestRepo: digestRepo, expenseService: expenseService, transactor: transactor, mailer: mailer}
+}
+
+// LastDigestPeriod returns the last complete period of the frequency before now: the previous
+// month for monthly digests and the previous week, from Monday to Sunday, for weekly ones
+func LastDigestPeriod(frequency domain.DigestFrequency, now time.Time) DigestPeriod {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if frequency == domain.DigestWeekly {
+		monday := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+		start := monday.AddDate(0, 0, -7)
+		return DigestPeriod{Frequency: frequency, Start: start, End: monday.AddDate(0, 0, -1)}
+	}
+
+	start := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
+	return DigestPeriod{Frequency: domain.DigestMonthly, Start: start, End: start.AddDate(0, 1, -1)}
+}
+
+func (p DigestPeriod) label() string {
+	if p.Frequency == domain.DigestWeekly {
+		return fmt.Sprintf("semana de %s a %s", p.Start.Format("02/01"), p.End.Format("02/01"))
+	}
+
+	return fmt.Sprintf("%s de %d", monthNames[p.Start.Month()-1], p.Start.Year())
+}
+
+func (s *DigestService) Preference(ctx context.Context, userID uuid.UUID) (*domain.DigestPreference, error) {
+	return s.digestRepo.Get(ctx, userID)
+}
+
+func (s *DigestService) UpdatePreference(ctx context.Context, frequency domain.DigestFrequency, userID uuid.UUID) (*domain.DigestPreference, error) {
+	p := domain.DigestPreference{UserID: userID, Frequency: frequency}
+	if err := s.digestRepo.Save(ctx, &p); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// Unsubscribe turns digests off for the user of an unsubscribe token
+func (s *DigestService) Unsubscribe(ctx context.Context, token string) error {
+	userID, err := auth.ParseUnsubscribeToken(token)
+	if err != nil {
+		return err
+	}
+
+	p, err := s.digestRepo.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if p.Frequency == domain.DigestNone {
+		return nil
+	}
+
+	_, err = s.UpdatePreference(ctx, domain.DigestNone, userID)
+	return err
+}
+
+// SendDue sends the digests of the last period to users who didn't receive them yet,
+// it's safe to call it repeatedly. Failures of a user don't stop the others.
+func (s *DigestService) SendDue(ctx context.Context, now time.Time) (int, error) {
+	var sent int
+	var errList []error
+
+	for _, frequency := range []domain.DigestFrequency{domain.DigestWeekly, domain.DigestMonthly} {
+		period := LastDigestPeriod(frequency, now)
+
+		prefs, err := s.digestRepo.AllDue(ctx, frequency, period.Start)
+		if err != nil {
+			return sent, err
+		}
+
+		for _, p := range prefs {
+			// Enqueued along with the mark, so a digest is neither lost nor sent twice
+			err := s.transactor.Transaction(ctx, func(ctx context.Context) error {
+				if err := s.Send(ctx, p.User, period); err != nil {
+					return err
+				}
+
+				return s.digestRepo.MarkSent(ctx, p.UserID, period.Start)
+			})
+			if err != nil {
+				errList = append(errList, fmt.Errorf("digest for user %s: %w", p.UserID, err))
+				continue
+			}
+
+			sent++
+		}
+	}
+
+	return sent, errors.Join(errList...)
+}
+
+// Send emails the user what was spent in the period against the limits of the month the
+// period ends in
+func (s *DigestService) Send(ctx context.Context, user domain.User, period DigestPeriod) error {
+	var summary *Summary
+	var err error
+	if period.Frequency == domain.DigestWeekly {
+		summary, err = s.expenseService.GetPeriodSummary(ctx, period.Start, period.End, user.ID)
+	} else {
+		summary, err = s.expenseService.GetSummary(ctx, period.End, user.ID)
+	}
+	if err != nil {
+		return err
+	}
+
+	goals := make([]digestGoal, len(summary.Goals))
+	for i, g := range summary.Goals {
+		goals[i] = digestGoal{
+			Name:      g.Name,
+			Spent:     formatBRL(g.Spent),
+			MustSpend: formatBRL(g.MustSpend),
+			Used:      fmt.Sprintf("%.0f%%", g.Used),
+			Over:      g.Spent > g.MustSpend,
+		}
+	}
+
+	subject := mail.MonthlyDigestSubject
+	if period.Frequency == domain.DigestWeekly {
+		subject = mail.WeeklyDigestSubject
+	}
+
+	webURL := config.Get().WebURL
+
+	return s.mailer.Send(ctx, mail.Email{
+		To:       types.MailContact{Email: user.Email},
+		Subject:  subject,
+		Template: mail.BudgetDigestTemplate,
+		Data: util.M{
+			"Period":          period.label(),
+			"Goals":           goals,
+			"Spent":           formatBRL(summary.Spent),
+			"Remaining":       formatBRL(summary.MustSpend),
+			"Link":            webURL,
+			"UnsubscribeLink": fmt.Sprintf("%s/digest/unsubscribe?token=%s", webURL, auth.GenerateUnsubscribeToken(user.ID)),
+		},
+	})
+}
+
+// formatBRL formats an amount in Brazilian reais, e.g. R$ 1.234,56
+func formatBRL(amount float64) string {
+	fixed := decimal.NewFromFloat(amount).Abs().StringFixed(2)
+	integer, cents, _ := strings.Cut(fixed, ".")
+
+	var b strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteByte('.')
+		}
+		b.WriteRune(digit)
+	}
+
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+	}
+
+	return fmt.Sprintf("%sR$ %s,%s", sign, b.String(), cents)
+}
@@ -0,0 +1,151 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/joaopsramos/fincon/internal/auth"
+	"github.com/joaopsramos/fincon/internal/config"
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/mail"
+	"github.com/joaopsramos/fincon/internal/repository"
+	"github.com/joaopsramos/fincon/internal/service"
+	"github.com/joaopsramos/fincon/internal/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLastDigestPeriod(t *testing.T) {
+	t.Parallel()
+
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name      string
+		frequency domain.DigestFrequency
+		now       time.Time
+		start     time.Time
+		end       time.Time
+	}{
+		{"monthly in the middle of the month", domain.DigestMonthly, date(2025, 3, 15), date(2025, 2, 1), date(2025, 2, 28)},
+		{"monthly in january", domain.DigestMonthly, date(2025, 1, 1).Add(time.Hour), date(2024, 12, 1), date(2024, 12, 31)},
+		{"weekly on a monday", domain.DigestWeekly, date(2025, 3, 17).Add(9 * time.Hour), date(2025, 3, 10), date(2025, 3, 16)},
+		{"weekly on a sunday", domain.DigestWeekly, date(2025, 3, 16), date(2025, 3, 3), date(2025, 3, 9)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			period := service.LastDigestPeriod(tt.frequency, tt.now)
+			assert.Equal(t, tt.frequency, period.Frequency)
+			assert.Equal(t, tt.start, period.Start)
+			assert.Equal(t, tt.end, period.End)
+		})
+	}
+}
+
+func TestDigestService_SendDue(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	mailer := testhelper.NewMockMailer(t)
+
+	now := testhelper.MiddleOfMonth()
+	lastMonth := now.AddDate(0, -1, 0)
+	lastPeriod := service.LastDigestPeriod(domain.DigestMonthly, now).Start
+
+	monthly := f.InsertUser()
+	f.InsertSalary(&domain.Salary{Amount: 10000 * 100, EffectiveFrom: lastMonth.AddDate(0, -1, 0), UserID: monthly.ID})
+	goal := f.InsertGoal(&domain.Goal{Name: domain.Comfort, Percentage: 20, UserID: monthly.ID})
+	f.InsertExpense(&domain.Expense{Value: 2500 * 100, Date: lastMonth, GoalID: goal.ID, UserID: monthly.ID})
+
+	week := service.LastDigestPeriod(domain.DigestWeekly, now)
+	weekly := f.InsertUser()
+	f.InsertSalary(&domain.Salary{Amount: 10000 * 100, EffectiveFrom: lastMonth.AddDate(0, -1, 0), UserID: weekly.ID})
+	weeklyGoal := f.InsertGoal(&domain.Goal{Name: domain.Comfort, Percentage: 20, UserID: weekly.ID})
+	f.InsertExpense(
+		&domain.Expense{Value: 300 * 100, Date: week.End, GoalID: weeklyGoal.ID, UserID: weekly.ID},
+		&domain.Expense{Value: 1000 * 100, Date: week.Start.AddDate(0, 0, -1), GoalID: weeklyGoal.ID, UserID: weekly.ID},
+	)
+
+	alreadySent := f.InsertUser()
+	optedOut := f.InsertUser()
+
+	f.InsertDigestPreference(
+		&domain.DigestPreference{UserID: monthly.ID, Frequency: domain.DigestMonthly},
+		&domain.DigestPreference{UserID: weekly.ID, Frequency: domain.DigestWeekly},
+		&domain.DigestPreference{UserID: alreadySent.ID, Frequency: domain.DigestMonthly, LastPeriod: &lastPeriod},
+		&domain.DigestPreference{UserID: optedOut.ID, Frequency: domain.DigestNone},
+	)
+
+	mailer.EXPECT().Send(mock.Anything, mock.AnythingOfType("mail.Email")).Return(nil).Twice()
+
+	s := service.NewDigestService(repository.NewPostgresDigest(tx), NewTestExpenseService(t, tx), repository.NewPostgresTransactor(tx), mailer)
+
+	sent, err := s.SendDue(context.Background(), now)
+	a.NoError(err)
+	a.Equal(2, sent)
+
+	mailer.AssertCalled(t, "Send", mock.Anything, mock.MatchedBy(func(e mail.Email) bool {
+		if e.To.Email != monthly.Email {
+			return false
+		}
+
+		body, err := e.BuildBody()
+		unsubscribe := config.Get().WebURL + "/digest/unsubscribe?token=" + auth.GenerateUnsubscribeToken(monthly.ID)
+
+		return err == nil &&
+			e.Subject == mail.MonthlyDigestSubject &&
+			e.Template == mail.BudgetDigestTemplate &&
+			e.Data["UnsubscribeLink"] == unsubscribe &&
+			assert.Contains(t, body, "R$ 2.500,00") &&
+			assert.Contains(t, body, "R$ 2.000,00") &&
+			assert.Contains(t, body, "125%")
+	}))
+
+	mailer.AssertCalled(t, "Send", mock.Anything, mock.MatchedBy(func(e mail.Email) bool {
+		if e.To.Email != weekly.Email {
+			return false
+		}
+
+		body, err := e.BuildBody()
+
+		// only the expenses of the week count, against the limit of the month
+		return err == nil &&
+			e.Subject == mail.WeeklyDigestSubject &&
+			assert.Contains(t, body, "R$ 300,00") &&
+			assert.Contains(t, body, "R$ 2.000,00") &&
+			assert.Contains(t, body, "15%") &&
+			assert.NotContains(t, body, "R$ 1.300,00")
+	}))
+
+	// digests are sent once per period
+	sent, err = s.SendDue(context.Background(), now)
+	a.NoError(err)
+	a.Zero(sent)
+}
+
+func TestDigestService_Unsubscribe(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	repo := repository.NewPostgresDigest(tx)
+
+	user := f.InsertUser()
+	f.InsertDigestPreference(&domain.DigestPreference{UserID: user.ID, Frequency: domain.DigestWeekly})
+
+	s := service.NewDigestService(repo, NewTestExpenseService(t, tx), repository.NewPostgresTransactor(tx), nil)
+
+	a.NoError(s.Unsubscribe(context.Background(), auth.GenerateUnsubscribeToken(user.ID)))
+
+	p, err := repo.Get(context.Background(), user.ID)
+	a.NoError(err)
+	a.Equal(domain.DigestNone, p.Frequency)
+
+	// unsubscribing again is a no-op
+	a.NoError(s.Unsubscribe(context.Background(), auth.GenerateUnsubscribeToken(user.ID)))
+}
@@ -2,20 +2,32 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/dromara/carbon/v2"
 	"github.com/google/uuid"
 	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/errs"
 	"github.com/joaopsramos/fincon/internal/util"
 	"github.com/shopspring/decimal"
 )
 
 type ExpenseService struct {
-	expenseRepo domain.ExpenseRepo
-	goalRepo    domain.GoalRepo
-	salaryRepo  domain.SalaryRepo
+	expenseRepo          domain.ExpenseRepo
+	goalRepo             domain.GoalRepo
+	salaryRepo           domain.SalaryRepo
+	recurringExpenseRepo domain.RecurringExpenseRepo
+	incomeRepo           domain.IncomeRepo
+	tagRepo              domain.TagRepo
+	transactor           domain.Transactor
+	alertService         AlertService
+	logger               *slog.Logger
 }
 
 type CreateExpenseDTO struct {
@@ -24,6 +36,8 @@ type CreateExpenseDTO struct {
 	Date         time.Time
 	Installments int
 	GoalID       int
+	TagIDs       []int
+	CreatedByID  *uuid.UUID
 }
 
 type UpdateExpenseDTO struct {
@@ -31,14 +45,36 @@ type UpdateExpenseDTO struct {
 	Value  float64
 	Date   time.Time
 	GoalID int
+	// TagIDs replaces the tags of the expense when not nil
+	TagIDs *[]int
 }
 
+type ImportPreviewRow struct {
+	CreateExpenseDTO
+	Duplicate bool
+}
+
+// ExpensePage is a page of a search, NextCursor is empty on the last page
+type ExpensePage struct {
+	Expenses   []domain.Expense
+	NextCursor string
+}
+
+const (
+	defaultSearchLimit = 50
+	maxSearchLimit     = 100
+)
+
 type SummaryGoal = struct {
 	Name      string  `json:"name"`
 	Spent     float64 `json:"spent"`
 	MustSpend float64 `json:"must_spend"`
 	Used      float64 `json:"used"`
 	Total     float64 `json:"total"`
+	// CarriedOver is the balance brought from the previous months by the rollover policy of
+	// the goal, a surplus is positive and included in MustSpend, a deficit is negative and
+	// included in Spent
+	CarriedOver float64 `json:"carried_over"`
 }
 
 type Summary struct {
@@ -53,8 +89,18 @@ func NewExpenseService(
 	goalRepo domain.GoalRepo,
 
 	salaryRepo domain.SalaryRepo,
+	recurringExpenseRepo domain.RecurringExpenseRepo,
+	incomeRepo domain.IncomeRepo,
+	tagRepo domain.TagRepo,
+	transactor domain.Transactor,
+	alertService AlertService,
+	logger *slog.Logger,
 ) ExpenseService {
-	return ExpenseService{expenseRepo, goalRepo, salaryRepo}
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return ExpenseService{expenseRepo, goalRepo, salaryRepo, recurringExpenseRepo, incomeRepo, tagRepo, transactor, alertService, logger}
 }
 
 func (s *ExpenseService) Get(ctx context.Context, id uint, userID uuid.UUID) (*domain.Expense, error) {
@@ -67,12 +113,20 @@ func (s *ExpenseService) Create(ctx context.Context, dto CreateExpenseDTO, userI
 		return []domain.Expense{}, err
 	}
 
+	tags, err := s.tagRepo.GetMany(ctx, toUints(dto.TagIDs), userID)
+	if err != nil {
+		return []domain.Expense{}, err
+	}
+
 	base := domain.Expense{
 		Name:   dto.Name,
 		Value:  decimal.NewFromFloat(dto.Value).Mul(decimal.NewFromInt(100)).IntPart(),
 		Date:   dto.Date,
 		GoalID: goal.ID,
 		UserID: userID,
+		Tags:   tags,
+
+		CreatedByID: dto.CreatedByID,
 	}
 
 	if dto.Installments <= 0 {
@@ -91,9 +145,104 @@ func (s *ExpenseService) Create(ctx context.Context, dto CreateExpenseDTO, userI
 		expenses = append(expenses, e)
 	}
 
-	err = s.expenseRepo.CreateMany(ctx, expenses)
+	if err := s.expenseRepo.CreateMany(ctx, expenses); err != nil {
+		return expenses, err
+	}
+
+	dates := util.Map(expenses, func(e domain.Expense) time.Time { return e.Date })
+	s.checkAlerts(ctx, dates, userID)
+
+	return expenses, nil
+}
+
+// PreviewImport flags the rows that look like an expense that already exists or an
+// earlier row of the same import, that is, one with the same date, value and name.
+func (s *ExpenseService) PreviewImport(ctx context.Context, dtos []CreateExpenseDTO, userID uuid.UUID) ([]ImportPreviewRow, error) {
+	rows := make([]ImportPreviewRow, len(dtos))
+	if len(dtos) == 0 {
+		return rows, nil
+	}
+
+	from, to := dtos[0].Date, dtos[0].Date
+	for _, d := range dtos {
+		if d.Date.Before(from) {
+			from = d.Date
+		}
+
+		if d.Date.After(to) {
+			to = d.Date
+		}
+	}
+
+	existing, err := s.expenseRepo.AllBetween(ctx, from, to.AddDate(0, 0, 1), userID)
+	if err != nil {
+		return []ImportPreviewRow{}, err
+	}
+
+	existingKeys := make(map[string]bool, len(existing))
+	for _, e := range existing {
+		existingKeys[importKey(e.Date, e.Value, e.Name)] = true
+	}
+
+	for i, d := range dtos {
+		value := decimal.NewFromFloat(d.Value).Mul(decimal.NewFromInt(100)).IntPart()
+		key := importKey(d.Date, value, d.Name)
+
+		rows[i] = ImportPreviewRow{
+			CreateExpenseDTO: d,
+			Duplicate:        existingKeys[key],
+		}
+
+		// Repeated rows in the same file are flagged as well, only the first one is kept
+		existingKeys[key] = true
+	}
+
+	return rows, nil
+}
+
+// Import creates all the given expenses at once, either all of them are created or none is
+func (s *ExpenseService) Import(ctx context.Context, dtos []CreateExpenseDTO, userID uuid.UUID) ([]domain.Expense, error) {
+	checkedGoals := make(map[int]bool)
+
+	expenses := make([]domain.Expense, len(dtos))
+	for i, d := range dtos {
+		if !checkedGoals[d.GoalID] {
+			if _, err := s.goalRepo.Get(ctx, uint(d.GoalID), userID); err != nil {
+				return []domain.Expense{}, err
+			}
+
+			checkedGoals[d.GoalID] = true
+		}
+
+		expenses[i] = domain.Expense{
+			Name:   d.Name,
+			Value:  decimal.NewFromFloat(d.Value).Mul(decimal.NewFromInt(100)).IntPart(),
+			Date:   d.Date,
+			GoalID: uint(d.GoalID),
+			UserID: userID,
+		}
+	}
+
+	if len(expenses) == 0 {
+		return expenses, nil
+	}
+
+	if err := s.expenseRepo.CreateMany(ctx, expenses); err != nil {
+		return expenses, err
+	}
+
+	dates := util.Map(expenses, func(e domain.Expense) time.Time { return e.Date })
+	s.checkAlerts(ctx, dates, userID)
+
+	return expenses, nil
+}
+
+func toUints(ids []int) []uint {
+	return util.Map(ids, func(id int) uint { return uint(id) })
+}
 
-	return expenses, err
+func importKey(date time.Time, value int64, name string) string {
+	return fmt.Sprintf("%s|%d|%s", date.Format(util.ApiDateLayout), value, strings.ToLower(strings.TrimSpace(name)))
 }
 
 func (s *ExpenseService) UpdateByID(ctx context.Context, id uint, dto UpdateExpenseDTO, userID uuid.UUID) (*domain.Expense, error) {
@@ -114,9 +263,60 @@ func (s *ExpenseService) UpdateByID(ctx context.Context, id uint, dto UpdateExpe
 	util.UpdateIfNotZero(&e.Date, dto.Date)
 	util.UpdateIfNotZero(&e.GoalID, uint(dto.GoalID))
 
-	err = s.expenseRepo.Update(ctx, e)
+	var tags []domain.Tag
+	if dto.TagIDs != nil {
+		tags, err = s.tagRepo.GetMany(ctx, toUints(*dto.TagIDs), userID)
+		if err != nil {
+			return &domain.Expense{}, err
+		}
+	}
+
+	err = s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.expenseRepo.Update(ctx, e); err != nil {
+			return err
+		}
 
-	return e, err
+		if dto.TagIDs != nil {
+			return s.expenseRepo.ReplaceTags(ctx, e, tags)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return &domain.Expense{}, err
+	}
+
+	s.checkAlerts(ctx, []time.Time{e.Date}, userID)
+
+	return e, nil
+}
+
+// checkAlerts evaluates the alert thresholds of the goals in the months of the given dates,
+// future months are skipped since their spendings are only planned. The expenses are already
+// saved at this point, so failures are only logged instead of failing the request.
+func (s *ExpenseService) checkAlerts(ctx context.Context, dates []time.Time, userID uuid.UUID) {
+	now := time.Now().UTC()
+	checked := make(map[time.Time]bool)
+
+	for _, date := range dates {
+		month := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+		if checked[month] || month.After(now) {
+			continue
+		}
+
+		checked[month] = true
+
+		history, err := s.loadBudgetHistory(ctx, month, userID)
+		if err != nil {
+			s.logger.Error("Failed to check alerts", "user_id", userID, "month", month, "error", err)
+			continue
+		}
+
+		summary := history.summaryFor(month)
+		if err := s.alertService.Evaluate(ctx, history.goals, summary.Goals, month, userID); err != nil {
+			s.logger.Error("Failed to check alerts", "user_id", userID, "month", month, "error", err)
+		}
+	}
 }
 
 func (s *ExpenseService) Delete(ctx context.Context, id uint, userID uuid.UUID) error {
@@ -137,64 +337,284 @@ func (s *ExpenseService) ChangeGoal(ctx context.Context, e *domain.Expense, goal
 }
 
 func (s *ExpenseService) AllByGoalID(ctx context.Context, goalID uint, year int, month time.Month, userID uuid.UUID) ([]domain.Expense, error) {
+	if err := s.MaterializeRecurring(ctx, time.Date(year, month, 1, 0, 0, 0, 0, time.UTC), userID); err != nil {
+		return []domain.Expense{}, err
+	}
+
 	return s.expenseRepo.AllByGoalID(ctx, goalID, year, month, userID)
 }
 
+// MaterializeRecurring creates the expenses of the recurring expense templates due in the
+// month of the given date. Months that were already materialized are skipped, so calling
+// it more than once is safe and deleted occurrences don't come back.
+func (s *ExpenseService) MaterializeRecurring(ctx context.Context, date time.Time, userID uuid.UUID) error {
+	templates, err := s.recurringExpenseRepo.All(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, re := range templates {
+		if _, err := s.recurringExpenseRepo.MaterializeMonth(ctx, &re, date); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Search lists the expenses matching the filter, a page at a time. The cursor is the
+// NextCursor of the previous page, or empty for the first one.
+func (s *ExpenseService) Search(ctx context.Context, filter domain.ExpenseFilter, cursor string, userID uuid.UUID) (*ExpensePage, error) {
+	if filter.Sort == "" {
+		filter.Sort = domain.SortDateDesc
+	}
+
+	if !slices.Contains(domain.ExpenseSorts(), string(filter.Sort)) {
+		return &ExpensePage{}, errs.NewValidationErrorF("sort must be one of: %s", strings.Join(domain.ExpenseSorts(), ", "))
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = defaultSearchLimit
+	}
+
+	if filter.Limit < 1 || filter.Limit > maxSearchLimit {
+		return &ExpensePage{}, errs.NewValidationErrorF("limit must be between 1 and %d", maxSearchLimit)
+	}
+
+	if filter.MaxValue > 0 && filter.MinValue > filter.MaxValue {
+		return &ExpensePage{}, errs.NewValidationError("min value must not be greater than max value")
+	}
+
+	if cursor != "" {
+		after, err := decodeExpenseCursor(cursor)
+		if err != nil || after.Sort != filter.Sort {
+			return &ExpensePage{}, errs.NewValidationError("invalid cursor")
+		}
+
+		filter.After = after
+	}
+
+	// Fetch one extra expense to know whether there is a next page
+	limit := filter.Limit
+	filter.Limit++
+
+	expenses, err := s.expenseRepo.Search(ctx, filter, userID)
+	if err != nil {
+		return &ExpensePage{}, err
+	}
+
+	page := ExpensePage{Expenses: expenses}
+
+	if len(expenses) > limit {
+		page.Expenses = expenses[:limit]
+
+		last := page.Expenses[limit-1]
+		page.NextCursor = encodeExpenseCursor(domain.ExpenseCursor{
+			Sort:  filter.Sort,
+			ID:    last.ID,
+			Date:  last.Date,
+			Value: last.Value,
+		})
+	}
+
+	return &page, nil
+}
+
+func encodeExpenseCursor(c domain.ExpenseCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeExpenseCursor(cursor string) (*domain.ExpenseCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	var c domain.ExpenseCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+
+	return &c, nil
+}
+
 func (s *ExpenseService) FindMatchingNames(ctx context.Context, name string, userID uuid.UUID) ([]string, error) {
 	return s.expenseRepo.FindMatchingNames(ctx, name, userID)
 }
 
 func (s *ExpenseService) GetSummary(ctx context.Context, date time.Time, userID uuid.UUID) (*Summary, error) {
-	salary := util.Must(s.salaryRepo.Get(ctx, userID))
-	monthlyGoalSpendings, err := s.expenseRepo.GetMonthlyGoalSpendings(ctx, date, userID)
+	if err := s.MaterializeRecurring(ctx, date, userID); err != nil {
+		return &Summary{}, err
+	}
+
+	history, err := s.loadBudgetHistory(ctx, date, userID)
 	if err != nil {
 		return &Summary{}, err
 	}
 
-	monthStart := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return history.summaryFor(date), nil
+}
 
-	spendingsByGoalID := make(map[uint]domain.MonthlyGoalSpending)
-	for _, m := range monthlyGoalSpendings {
-		date = m.Date
-		goalLimit := int64(m.Goal.Percentage) * (salary.Amount / 100)
+// GetPeriodSummary returns the summary of the month "to" is in, but with Spent and Used
+// covering only the expenses dated from "from" up to "to", both inclusive. Limits and
+// the total MustSpend are still the ones of the month.
+func (s *ExpenseService) GetPeriodSummary(ctx context.Context, from time.Time, to time.Time, userID uuid.UUID) (*Summary, error) {
+	if err := s.MaterializeRecurring(ctx, to, userID); err != nil {
+		return &Summary{}, err
+	}
 
-		if m.Spent <= goalLimit && date.Before(monthStart) {
-			continue
-		}
+	history, err := s.loadBudgetHistory(ctx, to, userID)
+	if err != nil {
+		return &Summary{}, err
+	}
+
+	expenses, err := s.expenseRepo.AllBetween(ctx, from, to.AddDate(0, 0, 1), userID)
+	if err != nil {
+		return &Summary{}, err
+	}
+
+	spentByGoalID := make(map[uint]int64)
+	for _, e := range expenses {
+		spentByGoalID[e.GoalID] += e.Value
+	}
+
+	summary := history.summaryFor(to)
+	hundred := decimal.NewFromInt(100)
+	budget := util.MoneyAmountToDecimal(history.budgetFor(time.Date(to.Year(), to.Month(), 1, 0, 0, 0, 0, time.UTC)))
 
-		if m.Spent > goalLimit {
-			yearDiff := monthStart.Year() - date.Year()
-			monthDiff := int(monthStart.Month()) - int(date.Month()) + yearDiff*12
+	var totalSpent, totalUsed decimal.Decimal
+	for i, g := range history.goals {
+		spent := util.MoneyAmountToDecimal(spentByGoalID[g.ID])
+		mustSpend := decimal.NewFromFloat(summary.Goals[i].MustSpend)
 
-			m.Spent = max(0, m.Spent-int64(monthDiff)*goalLimit)
+		var used decimal.Decimal
+		if !mustSpend.IsZero() {
+			used = spent.Mul(hundred).Div(mustSpend)
 		}
 
-		if entry, ok := spendingsByGoalID[m.Goal.ID]; ok {
-			entry.Spent += m.Spent
-			spendingsByGoalID[m.Goal.ID] = entry
-		} else {
-			spendingsByGoalID[m.Goal.ID] = m
+		var total decimal.Decimal
+		if !budget.IsZero() {
+			total = spent.Mul(hundred).Div(budget)
 		}
+
+		summary.Goals[i].Spent = spent.InexactFloat64()
+		summary.Goals[i].Used = used.InexactFloat64()
+		summary.Goals[i].Total = total.InexactFloat64()
+
+		totalSpent = totalSpent.Add(spent)
+		totalUsed = totalUsed.Add(total)
+	}
+
+	summary.Spent = totalSpent.InexactFloat64()
+	summary.Used = totalUsed.InexactFloat64()
+
+	return summary, nil
+}
+
+// budgetHistory holds everything needed to build the summary of any month up to the one it was loaded for
+type budgetHistory struct {
+	goals       []domain.Goal
+	spendings   []domain.MonthlyGoalSpending
+	salaries    []domain.Salary
+	incomes     []domain.Income
+	allocations domain.GoalAllocations
+}
+
+func (s *ExpenseService) loadBudgetHistory(ctx context.Context, date time.Time, userID uuid.UUID) (*budgetHistory, error) {
+	salaries, err := s.salaryRepo.History(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	incomes, err := s.incomeRepo.All(ctx, userID)
+	if err != nil {
+		return nil, err
 	}
 
-	goals := s.goalRepo.All(ctx, userID)
+	spendings, err := s.expenseRepo.GetMonthlyGoalSpendings(ctx, date, userID)
+	if err != nil {
+		return nil, err
+	}
 
-	var totalSpent, totalMustSpend, totalUsed decimal.Decimal
+	allocations, err := s.goalRepo.AllAllocations(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
 
-	sg := make([]SummaryGoal, len(goals))
-	for i, g := range goals {
-		mgs, ok := spendingsByGoalID[g.ID]
-		if !ok {
-			mgs = domain.MonthlyGoalSpending{}
+	return &budgetHistory{
+		goals:       s.goalRepo.All(ctx, userID),
+		spendings:   spendings,
+		salaries:    salaries,
+		incomes:     incomes,
+		allocations: domain.NewGoalAllocations(allocations),
+	}, nil
+}
+
+// budgetFor returns the budget of a month, that is, its total income: the salary
+// effective at the time plus other incomes
+func (h *budgetHistory) budgetFor(month time.Time) int64 {
+	return domain.SalaryForMonth(h.salaries, month) + domain.IncomeForMonth(h.incomes, month)
+}
+
+// goalLimitFor returns how much can be spent on a goal in a month
+func (h *budgetHistory) goalLimitFor(goal domain.Goal, month time.Time) int64 {
+	return int64(h.allocations.PercentageFor(goal, month)) * (h.budgetFor(month) / 100)
+}
+
+// carryFor returns the balance a goal brings into month from the months before it, starting
+// at from, according to its rollover policy. A surplus is positive and a deficit negative.
+func (h *budgetHistory) carryFor(goal domain.Goal, spentByMonth map[time.Time]int64, from time.Time, month time.Time) int64 {
+	policy := goal.Rollover()
+
+	var carry int64
+	for m := from; m.Before(month); m = m.AddDate(0, 1, 0) {
+		carry = policy.Carry(h.goalLimitFor(goal, m) + carry - spentByMonth[m])
+	}
+
+	return carry
+}
+
+func (h *budgetHistory) summaryFor(date time.Time) *Summary {
+	monthStart := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+	budget := h.budgetFor(monthStart)
+
+	// Balances are carried from the first month with expenses, months before it weren't
+	// tracked so they have no surplus
+	firstMonth := monthStart
+	spentByGoalID := make(map[uint]map[time.Time]int64)
+	for _, m := range h.spendings {
+		month := time.Date(m.Date.Year(), m.Date.Month(), 1, 0, 0, 0, 0, time.UTC)
+		if month.After(monthStart) {
+			continue
+		}
+
+		if spentByGoalID[m.Goal.ID] == nil {
+			spentByGoalID[m.Goal.ID] = make(map[time.Time]int64)
 		}
 
-		percentage := decimal.NewFromInt(int64(g.Percentage))
+		spentByGoalID[m.Goal.ID][month] += m.Spent
+		if month.Before(firstMonth) {
+			firstMonth = month
+		}
+	}
+
+	var totalSpent, totalMustSpend, totalUsed, totalSurplus decimal.Decimal
+
+	sg := make([]SummaryGoal, len(h.goals))
+	for i, g := range h.goals {
+		spentByMonth := spentByGoalID[g.ID]
+		carry := h.carryFor(g, spentByMonth, firstMonth, monthStart)
+
+		percentage := decimal.NewFromInt(int64(h.allocations.PercentageFor(g, monthStart)))
 		hundred := decimal.NewFromInt(100)
-		spent := util.MoneyAmountToDecimal(mgs.Spent)
-		salaryDec := util.MoneyAmountToDecimal(salary.Amount)
+		// A deficit counts as spent in this month and a surplus raises its limit
+		spent := util.MoneyAmountToDecimal(spentByMonth[monthStart] + max(-carry, 0))
+		surplus := util.MoneyAmountToDecimal(max(carry, 0))
+		budgetDec := util.MoneyAmountToDecimal(budget)
 
-		// Calculate mustSpend (salary * percentage / 100)
-		mustSpend := salaryDec.Mul(percentage).Div(hundred)
+		// Calculate mustSpend (budget * percentage / 100 + surplus)
+		mustSpend := budgetDec.Mul(percentage).Div(hundred).Add(surplus)
 
 		// Calculate used percentage (100 + ((spent - mustSpend) * 100 / mustSpend))
 		var used decimal.Decimal
@@ -202,22 +622,24 @@ func (s *ExpenseService) GetSummary(ctx context.Context, date time.Time, userID
 			used = hundred.Add(spent.Sub(mustSpend).Mul(hundred).Div(mustSpend))
 		}
 
-		// Calculate total percentage (spent * 100 / salary)
+		// Calculate total percentage (spent * 100 / budget)
 		var total decimal.Decimal
-		if !salaryDec.IsZero() {
-			total = spent.Mul(hundred).Div(salaryDec)
+		if !budgetDec.IsZero() {
+			total = spent.Mul(hundred).Div(budgetDec)
 		}
 
 		sg[i] = SummaryGoal{
-			Name:      string(g.Name),
-			Spent:     spent.InexactFloat64(),
-			MustSpend: mustSpend.InexactFloat64(),
-			Used:      used.InexactFloat64(),
-			Total:     total.InexactFloat64(),
+			Name:        string(g.Name),
+			Spent:       spent.InexactFloat64(),
+			MustSpend:   mustSpend.InexactFloat64(),
+			Used:        used.InexactFloat64(),
+			Total:       total.InexactFloat64(),
+			CarriedOver: util.MoneyAmountToFloat(carry),
 		}
 
 		totalSpent = totalSpent.Add(spent)
-		totalMustSpend = salaryDec.Sub(totalSpent)
+		totalSurplus = totalSurplus.Add(surplus)
+		totalMustSpend = budgetDec.Add(totalSurplus).Sub(totalSpent)
 		totalUsed = totalUsed.Add(total)
 	}
 
@@ -226,5 +648,5 @@ func (s *ExpenseService) GetSummary(ctx context.Context, date time.Time, userID
 		Spent:     totalSpent.InexactFloat64(),
 		MustSpend: totalMustSpend.InexactFloat64(),
 		Used:      totalUsed.InexactFloat64(),
-	}, nil
+	}
 }
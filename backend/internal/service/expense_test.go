@@ -2,15 +2,18 @@ package service_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/errs"
 	"github.com/joaopsramos/fincon/internal/repository"
 	"github.com/joaopsramos/fincon/internal/service"
 	"github.com/joaopsramos/fincon/internal/testhelper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 )
 
@@ -20,8 +23,12 @@ func NewTestExpenseService(t *testing.T, tx *gorm.DB) service.ExpenseService {
 	salaryRepo := repository.NewPostgresSalary(tx)
 	goalRepo := repository.NewPostgresGoal(tx)
 	expenseRepo := repository.NewPostgresExpense(tx)
+	recurringExpenseRepo := repository.NewPostgresRecurringExpense(tx)
+	incomeRepo := repository.NewPostgresIncome(tx)
+	tagRepo := repository.NewPostgresTag(tx)
+	alertService := service.NewAlertService(repository.NewPostgresNotification(tx), repository.NewPostgresUser(tx), nil)
 
-	return service.NewExpenseService(expenseRepo, goalRepo, salaryRepo)
+	return service.NewExpenseService(expenseRepo, goalRepo, salaryRepo, recurringExpenseRepo, incomeRepo, tagRepo, repository.NewPostgresTransactor(tx), alertService, nil)
 }
 
 func TestPostgresExpense_GetSummary(t *testing.T) {
@@ -200,6 +207,209 @@ func TestPostgresExpense_GetSummary(t *testing.T) {
 	}
 }
 
+func TestExpenseService_GetSummaryWithSalaryHistory(t *testing.T) {
+	t.Parallel()
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	user := f.InsertUser()
+
+	now := testhelper.MiddleOfMonth()
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	twoMonthsAgo := now.AddDate(0, -2, 0)
+
+	// raise from 1000 to 2000 in the current month
+	f.InsertSalary(
+		&domain.Salary{Amount: 1000 * 100, EffectiveFrom: monthStart.AddDate(0, -2, 0), UserID: user.ID},
+		&domain.Salary{Amount: 2000 * 100, EffectiveFrom: monthStart, UserID: user.ID},
+	)
+
+	goal := f.InsertGoal(&domain.Goal{Name: domain.Comfort, Percentage: 50, UserID: user.ID})
+	f.InsertExpense(&domain.Expense{Value: 1500 * 100, Date: twoMonthsAgo, GoalID: goal.ID, UserID: user.ID})
+
+	tests := []struct {
+		name      string
+		date      time.Time
+		spent     float64
+		mustSpend float64
+	}{
+		{"past months keep the old salary", twoMonthsAgo, 1500, 500},
+		{"carry over uses the limit of each month", now.AddDate(0, -1, 0), 1000, 500},
+		{"current month uses the new salary", now, 500, 1000},
+		{"months before the first version use the first one", now.AddDate(0, -3, 0), 0, 500},
+	}
+
+	expenseService := NewTestExpenseService(t, tx)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			summary, err := expenseService.GetSummary(context.Background(), tt.date, user.ID)
+			a.NoError(err)
+
+			a.Len(summary.Goals, 1)
+			a.Equal(tt.spent, summary.Goals[0].Spent)
+			a.Equal(tt.mustSpend, summary.Goals[0].MustSpend)
+		})
+	}
+}
+
+func TestExpenseService_GetSummaryWithIncomes(t *testing.T) {
+	t.Parallel()
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	user := f.InsertUser()
+
+	now := testhelper.MiddleOfMonth()
+	lastMonth := now.AddDate(0, -1, 0)
+	nextMonth := now.AddDate(0, 1, 0)
+
+	f.InsertSalary(&domain.Salary{Amount: 1000 * 100, EffectiveFrom: lastMonth, UserID: user.ID})
+	freelance := f.InsertIncome(&domain.Income{Name: "Freelance", Amount: 500 * 100, Recurring: true, StartDate: lastMonth, EndDate: &now, UserID: user.ID})
+	f.InsertIncome(&domain.Income{Name: "Bonus", Amount: 300 * 100, StartDate: now, UserID: user.ID})
+
+	// Freelance paid more than usual this month
+	month := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	require.NoError(t, tx.Create(&domain.IncomeAmount{IncomeID: freelance.ID, Month: month, Amount: 700 * 100}).Error)
+
+	goal := f.InsertGoal(&domain.Goal{Name: domain.Comfort, Percentage: 50, UserID: user.ID})
+	f.InsertExpense(&domain.Expense{Value: 1000 * 100, Date: lastMonth, GoalID: goal.ID, UserID: user.ID})
+
+	tests := []struct {
+		name      string
+		date      time.Time
+		spent     float64
+		mustSpend float64
+	}{
+		{"salary plus recurring income", lastMonth, 1000, 750},
+		{"one-off income and the amount of the month", now, 250, 1000},
+		{"recurring income stops after its end date", nextMonth, 0, 500},
+	}
+
+	expenseService := NewTestExpenseService(t, tx)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			summary, err := expenseService.GetSummary(context.Background(), tt.date, user.ID)
+			a.NoError(err)
+
+			a.Len(summary.Goals, 1)
+			a.Equal(tt.spent, summary.Goals[0].Spent)
+			a.Equal(tt.mustSpend, summary.Goals[0].MustSpend)
+		})
+	}
+}
+
+func TestExpenseService_GetSummaryWithGoalAllocations(t *testing.T) {
+	t.Parallel()
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	user := f.InsertUser()
+
+	now := testhelper.MiddleOfMonth()
+	twoMonthsAgo := now.AddDate(0, -2, 0)
+
+	f.InsertSalary(&domain.Salary{Amount: 1000 * 100, EffectiveFrom: twoMonthsAgo, UserID: user.ID})
+
+	comfort := f.InsertGoal(&domain.Goal{Name: domain.Comfort, Percentage: 50, UserID: user.ID})
+	pleasures := f.InsertGoal(&domain.Goal{Name: domain.Pleasures, Percentage: 50, Position: 1, UserID: user.ID})
+	f.InsertExpense(&domain.Expense{Value: 900 * 100, Date: twoMonthsAgo, GoalID: comfort.ID, UserID: user.ID})
+
+	goalService := service.NewGoalService(repository.NewPostgresGoal(tx))
+	_, err := goalService.UpdateAll(context.Background(), []service.UpdateGoalDTO{
+		{ID: int(comfort.ID), Percentage: 20},
+		{ID: int(pleasures.ID), Percentage: 80},
+	}, user.ID)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		date      time.Time
+		spent     float64
+		mustSpend float64
+	}{
+		{"past months keep their percentage", twoMonthsAgo, 900, 500},
+		{"carry over uses the percentage of each month", now.AddDate(0, -1, 0), 400, 500},
+		{"current month uses the new percentage", now, 0, 200},
+	}
+
+	expenseService := NewTestExpenseService(t, tx)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := assert.New(t)
+
+			summary, err := expenseService.GetSummary(context.Background(), tt.date, user.ID)
+			a.NoError(err)
+
+			a.Len(summary.Goals, 2)
+			a.Equal(tt.spent, summary.Goals[0].Spent)
+			a.Equal(tt.mustSpend, summary.Goals[0].MustSpend)
+		})
+	}
+}
+
+func TestExpenseService_GetSummaryWithRolloverPolicies(t *testing.T) {
+	t.Parallel()
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	user := f.InsertUser()
+
+	now := testhelper.MiddleOfMonth()
+	monthsAgo := func(n int) time.Time { return now.AddDate(0, -n, 0) }
+
+	// Months before the first expense don't have a surplus to carry
+	f.InsertSalary(&domain.Salary{Amount: 1000 * 100, EffectiveFrom: monthsAgo(5), UserID: user.ID})
+
+	policies := []domain.RolloverPolicy{domain.RolloverNone, domain.RolloverSurplus, domain.RolloverDeficit, domain.RolloverBoth}
+	for i, policy := range policies {
+		// limit 250
+		goal := f.InsertGoal(&domain.Goal{Name: domain.GoalName(policy), Percentage: 25, Position: i, RolloverPolicy: policy, UserID: user.ID})
+
+		for n, value := range []int64{100, 600, 200, 50} {
+			f.InsertExpense(&domain.Expense{Value: value * 100, Date: monthsAgo(3 - n), GoalID: goal.ID, UserID: user.ID})
+		}
+	}
+
+	tests := []struct {
+		policy      domain.RolloverPolicy
+		spent       float64
+		mustSpend   float64
+		carriedOver float64
+	}{
+		{domain.RolloverNone, 50, 250, 0},
+		// +150, then -200 is dropped, then +50
+		{domain.RolloverSurplus, 50, 300, 50},
+		// +150 is dropped, then -350, then -300
+		{domain.RolloverDeficit, 350, 250, -300},
+		// +150, then -200, then -150
+		{domain.RolloverBoth, 200, 250, -150},
+	}
+
+	expenseService := NewTestExpenseService(t, tx)
+
+	summary, err := expenseService.GetSummary(context.Background(), now, user.ID)
+	assert.NoError(t, err)
+	assert.Len(t, summary.Goals, len(tests))
+
+	for i, tt := range tests {
+		t.Run(string(tt.policy), func(t *testing.T) {
+			a := assert.New(t)
+			goal := summary.Goals[i]
+
+			a.Equal(string(tt.policy), goal.Name)
+			a.Equal(tt.spent, goal.Spent)
+			a.Equal(tt.mustSpend, goal.MustSpend)
+			a.Equal(tt.carriedOver, goal.CarriedOver)
+		})
+	}
+
+	// 1000 plus the surplus minus everything spent
+	assert.Equal(t, 400.0, summary.MustSpend)
+}
+
 func TestExpenseService_Create(t *testing.T) {
 	t.Parallel()
 
@@ -306,3 +516,83 @@ func TestExpenseService_UpdateByID(t *testing.T) {
 		})
 	}
 }
+
+// failingTagsRepo fails to replace the tags of an expense
+type failingTagsRepo struct {
+	domain.ExpenseRepo
+}
+
+func (failingTagsRepo) ReplaceTags(context.Context, *domain.Expense, []domain.Tag) error {
+	return errors.New("replace tags failed")
+}
+
+func TestExpenseService_UpdateByIDRollsBack(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	user := f.InsertUser()
+	goal := f.InsertGoal(&domain.Goal{Name: "Comfort", UserID: user.ID})
+	expense := f.InsertExpense(&domain.Expense{Name: "Market", Value: 7000, GoalID: goal.ID, UserID: user.ID, Date: time.Now().UTC()})
+
+	expenseService := service.NewExpenseService(
+		failingTagsRepo{repository.NewPostgresExpense(tx)},
+		repository.NewPostgresGoal(tx),
+		repository.NewPostgresSalary(tx),
+		repository.NewPostgresRecurringExpense(tx),
+		repository.NewPostgresIncome(tx),
+		repository.NewPostgresTag(tx),
+		repository.NewPostgresTransactor(tx),
+		service.NewAlertService(repository.NewPostgresNotification(tx), repository.NewPostgresUser(tx), nil),
+		nil,
+	)
+
+	tagIDs := []int{}
+	_, err := expenseService.UpdateByID(context.Background(), expense.ID, service.UpdateExpenseDTO{Name: "Pharmacy", TagIDs: &tagIDs}, user.ID)
+	a.EqualError(err, "replace tags failed")
+
+	var got domain.Expense
+	a.NoError(tx.First(&got, expense.ID).Error)
+	a.Equal("Market", got.Name)
+}
+
+func TestExpenseService_Import(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	s := NewTestExpenseService(t, tx)
+
+	user := f.InsertUser()
+	goal := f.InsertGoal(&domain.Goal{Name: domain.Comfort, UserID: user.ID})
+	date := time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)
+	f.InsertExpense(&domain.Expense{Name: "Market", Value: 12050, Date: date, GoalID: goal.ID, UserID: user.ID})
+
+	dtos := []service.CreateExpenseDTO{
+		{Name: " market ", Value: 120.5, Date: date, GoalID: int(goal.ID)},
+		{Name: "Market", Value: 120.5, Date: date.AddDate(0, 0, 1), GoalID: int(goal.ID)},
+		{Name: "Market", Value: 99, Date: date, GoalID: int(goal.ID)},
+	}
+
+	preview, err := s.PreviewImport(context.Background(), dtos, user.ID)
+	assert.NoError(err)
+	assert.Equal([]bool{true, false, false}, []bool{preview[0].Duplicate, preview[1].Duplicate, preview[2].Duplicate})
+
+	// nothing is created when any of the goals doesn't belong to the user
+	invalid := append(dtos[1:], service.CreateExpenseDTO{Name: "Gas", Value: 10, Date: date, GoalID: int(goal.ID) + 1})
+	_, err = s.Import(context.Background(), invalid, user.ID)
+	assert.Equal(errs.NewNotFound("goal"), err)
+
+	var count int64
+	tx.Model(&domain.Expense{}).Where("user_id = ?", user.ID).Count(&count)
+	assert.Equal(int64(1), count)
+
+	expenses, err := s.Import(context.Background(), dtos[1:], user.ID)
+	assert.NoError(err)
+	assert.Len(expenses, 2)
+	assert.Equal(int64(9900), expenses[1].Value)
+
+	tx.Model(&domain.Expense{}).Where("user_id = ?", user.ID).Count(&count)
+	assert.Equal(int64(3), count)
+}
@@ -0,0 +1,407 @@
+package service
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/util"
+)
+
+// exportBatchSize is how many expenses are loaded at a time while writing an export
+const exportBatchSize = 500
+
+type ExportService struct {
+	userRepo             domain.UserRepo
+	salaryRepo           domain.SalaryRepo
+	goalRepo             domain.GoalRepo
+	expenseRepo          domain.ExpenseRepo
+	recurringExpenseRepo domain.RecurringExpenseRepo
+	incomeRepo           domain.IncomeRepo
+	tagRepo              domain.TagRepo
+	savingsTargetRepo    domain.SavingsTargetRepo
+	transactor           domain.Transactor
+}
+
+// Export holds the data of a user, except for the expenses, which are only loaded
+// in batches while the export is written
+type Export struct {
+	ExportedAt        time.Time                    `json:"exported_at"`
+	User              domain.UserDTO               `json:"user"`
+	Salary            domain.SalaryDTO             `json:"salary"`
+	SalaryHistory     []domain.SalaryVersionDTO    `json:"salary_history"`
+	Goals             []domain.GoalDTO             `json:"goals"`
+	Tags              []domain.TagDTO              `json:"tags"`
+	RecurringExpenses []domain.RecurringExpenseDTO `json:"recurring_expenses"`
+	Incomes           []domain.IncomeDTO           `json:"incomes"`
+	SavingsTargets    []domain.SavingsTargetDTO    `json:"savings_targets"`
+}
+
+func NewExportService(
+	userRepo domain.UserRepo,
+	salaryRepo domain.SalaryRepo,
+	goalRepo domain.GoalRepo,
+	expenseRepo domain.ExpenseRepo,
+	recurringExpenseRepo domain.RecurringExpenseRepo,
+	incomeRepo domain.IncomeRepo,
+	tagRepo domain.TagRepo,
+	savingsTargetRepo domain.SavingsTargetRepo,
+	transactor domain.Transactor,
+) ExportService {
+	return ExportService{
+		userRepo:             userRepo,
+		salaryRepo:           salaryRepo,
+		goalRepo:             goalRepo,
+		expenseRepo:          expenseRepo,
+		recurringExpenseRepo: recurringExpenseRepo,
+		incomeRepo:           incomeRepo,
+		tagRepo:              tagRepo,
+		savingsTargetRepo:    savingsTargetRepo,
+		transactor:           transactor,
+	}
+}
+
+// WriteJSON writes all the data of a user as a single JSON object, it never includes
+// passwords or tokens. Everything is read in one read-only transaction, so the export
+// is consistent, and the expenses are streamed as they are loaded so the whole export
+// is never held in memory.
+func (s *ExportService) WriteJSON(ctx context.Context, userID uuid.UUID, w io.Writer) error {
+	return s.transactor.ReadOnlyTransaction(ctx, func(ctx context.Context) error {
+		export, err := s.export(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		value, err := json.Marshal(export)
+		if err != nil {
+			return err
+		}
+
+		// The expenses are appended to the marshaled object, before its closing brace
+		bw := bufio.NewWriter(w)
+		bw.Write(value[:len(value)-1])
+		bw.WriteString(`,"expenses":[`)
+
+		first := true
+		err = s.expenseRepo.AllInBatches(ctx, userID, exportBatchSize, func(expenses []domain.Expense) error {
+			for _, expense := range expenses {
+				value, err := json.Marshal(expense.ToDTO())
+				if err != nil {
+					return err
+				}
+
+				if !first {
+					bw.WriteByte(',')
+				}
+				first = false
+
+				if _, err := bw.Write(value); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		bw.WriteString("]}\n")
+
+		return bw.Flush()
+	})
+}
+
+// WriteCSVZip writes all the data of a user as a ZIP file containing one CSV file per
+// resource, read in one read-only transaction like WriteJSON.
+// Text cells are escaped so spreadsheets don't evaluate them as formulas.
+func (s *ExportService) WriteCSVZip(ctx context.Context, userID uuid.UUID, w io.Writer) error {
+	return s.transactor.ReadOnlyTransaction(ctx, func(ctx context.Context) error {
+		export, err := s.export(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		zw := zip.NewWriter(w)
+
+		for _, f := range export.csvFiles() {
+			cw, err := export.createCSV(zw, f.name, f.header)
+			if err != nil {
+				return err
+			}
+
+			if err := cw.WriteAll(f.records); err != nil {
+				return err
+			}
+		}
+
+		cw, err := export.createCSV(zw, "expenses.csv", []string{"id", "name", "value", "date", "goal_id", "tags"})
+		if err != nil {
+			return err
+		}
+
+		err = s.expenseRepo.AllInBatches(ctx, userID, exportBatchSize, func(expenses []domain.Expense) error {
+			for _, expense := range expenses {
+				tags := util.Map(expense.Tags, func(t domain.Tag) string { return t.Name })
+
+				record := []string{
+					strconv.FormatUint(uint64(expense.ID), 10),
+					csvCell(expense.Name),
+					formatMoney(util.MoneyAmountToFloat(expense.Value)),
+					expense.Date.Format(util.ApiDateLayout),
+					strconv.FormatUint(uint64(expense.GoalID), 10),
+					csvCell(strings.Join(tags, ";")),
+				}
+
+				if err := cw.Write(record); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+
+		return zw.Close()
+	})
+}
+
+// export gathers all the data of a user but the expenses
+func (s *ExportService) export(ctx context.Context, userID uuid.UUID) (*Export, error) {
+	user, err := s.userRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	salary, err := s.salaryRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	salaryHistory, err := s.salaryRepo.History(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	goals := s.goalRepo.AllWithArchived(ctx, userID)
+
+	tags, err := s.tagRepo.All(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	recurringExpenses, err := s.recurringExpenseRepo.All(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	incomes, err := s.incomeRepo.All(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	savingsTargets, err := s.savingsTargetRepo.All(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := Export{
+		ExportedAt:        time.Now().UTC(),
+		User:              user.ToDTO(),
+		Salary:            salary.ToDTO(),
+		SalaryHistory:     make([]domain.SalaryVersionDTO, len(salaryHistory)),
+		Goals:             make([]domain.GoalDTO, len(goals)),
+		Tags:              make([]domain.TagDTO, len(tags)),
+		RecurringExpenses: make([]domain.RecurringExpenseDTO, len(recurringExpenses)),
+		Incomes:           make([]domain.IncomeDTO, len(incomes)),
+		SavingsTargets:    make([]domain.SavingsTargetDTO, len(savingsTargets)),
+	}
+
+	for i, v := range salaryHistory {
+		export.SalaryHistory[i] = v.ToVersionDTO()
+	}
+
+	for i, g := range goals {
+		export.Goals[i] = g.ToDTO()
+	}
+
+	for i, t := range tags {
+		export.Tags[i] = t.ToDTO()
+	}
+
+	for i, r := range recurringExpenses {
+		export.RecurringExpenses[i] = r.ToDTO()
+	}
+
+	for i, v := range incomes {
+		export.Incomes[i] = v.ToDTO()
+	}
+
+	for i, t := range savingsTargets {
+		export.SavingsTargets[i] = t.ToDTO()
+	}
+
+	return &export, nil
+}
+
+type csvFile struct {
+	name    string
+	header  []string
+	records [][]string
+}
+
+// csvFiles returns a CSV file for each resource of the export
+func (e *Export) csvFiles() []csvFile {
+	return []csvFile{
+		{
+			"user.csv",
+			[]string{"id", "email"},
+			[][]string{{e.User.ID.String(), csvCell(e.User.Email)}},
+		},
+		{
+			"salary.csv",
+			[]string{"amount", "effective_from"},
+			util.Map(e.SalaryHistory, func(s domain.SalaryVersionDTO) []string {
+				return []string{formatMoney(s.Amount), s.EffectiveFrom.Format(util.ApiDateLayout)}
+			}),
+		},
+		{
+			"goals.csv",
+			[]string{"id", "name", "percentage", "color", "position", "archived_at"},
+			util.Map(e.Goals, func(g domain.GoalDTO) []string {
+				var archivedAt string
+				if g.ArchivedAt != nil {
+					archivedAt = g.ArchivedAt.Format(time.RFC3339)
+				}
+
+				return []string{
+					strconv.FormatUint(uint64(g.ID), 10),
+					csvCell(string(g.Name)),
+					strconv.FormatUint(uint64(g.Percentage), 10),
+					csvCell(g.Color),
+					strconv.Itoa(g.Position),
+					archivedAt,
+				}
+			}),
+		},
+		{
+			"tags.csv",
+			[]string{"id", "name"},
+			util.Map(e.Tags, func(t domain.TagDTO) []string {
+				return []string{strconv.FormatUint(uint64(t.ID), 10), csvCell(t.Name)}
+			}),
+		},
+		{
+			"recurring_expenses.csv",
+			[]string{"id", "name", "value", "day_of_month", "cadence", "start_date", "end_date", "goal_id"},
+			util.Map(e.RecurringExpenses, func(r domain.RecurringExpenseDTO) []string {
+				var endDate string
+				if r.EndDate != nil {
+					endDate = r.EndDate.Format(util.ApiDateLayout)
+				}
+
+				return []string{
+					strconv.FormatUint(uint64(r.ID), 10),
+					csvCell(r.Name),
+					formatMoney(r.Value),
+					strconv.Itoa(r.DayOfMonth),
+					string(r.Cadence),
+					r.StartDate.Format(util.ApiDateLayout),
+					endDate,
+					strconv.FormatUint(uint64(r.GoalID), 10),
+				}
+			}),
+		},
+		{
+			"incomes.csv",
+			[]string{"id", "name", "amount", "recurring", "start_date", "end_date"},
+			util.Map(e.Incomes, func(i domain.IncomeDTO) []string {
+				var endDate string
+				if i.EndDate != nil {
+					endDate = i.EndDate.Format(util.ApiDateLayout)
+				}
+
+				return []string{
+					strconv.FormatUint(uint64(i.ID), 10),
+					csvCell(i.Name),
+					formatMoney(i.Amount),
+					strconv.FormatBool(i.Recurring),
+					i.StartDate.Format(util.ApiDateLayout),
+					endDate,
+				}
+			}),
+		},
+		{
+			"income_amounts.csv",
+			[]string{"income_id", "month", "amount"},
+			incomeAmountRecords(e.Incomes),
+		},
+		{
+			"savings_targets.csv",
+			[]string{"id", "name", "target_amount", "start_date", "deadline", "goal_id"},
+			util.Map(e.SavingsTargets, func(t domain.SavingsTargetDTO) []string {
+				return []string{
+					strconv.FormatUint(uint64(t.ID), 10),
+					csvCell(t.Name),
+					formatMoney(t.TargetAmount),
+					t.StartDate.Format(util.ApiDateLayout),
+					t.Deadline.Format(util.ApiDateLayout),
+					strconv.FormatUint(uint64(t.GoalID), 10),
+				}
+			}),
+		},
+	}
+}
+
+func (e *Export) createCSV(zw *zip.Writer, name string, header []string) (*csv.Writer, error) {
+	fw, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: e.ExportedAt})
+	if err != nil {
+		return nil, err
+	}
+
+	cw := csv.NewWriter(fw)
+	if err := cw.Write(header); err != nil {
+		return nil, err
+	}
+
+	return cw, nil
+}
+
+func incomeAmountRecords(incomes []domain.IncomeDTO) [][]string {
+	var records [][]string
+	for _, i := range incomes {
+		for _, a := range i.Amounts {
+			records = append(records, []string{strconv.FormatUint(uint64(i.ID), 10), a.Month, formatMoney(a.Amount)})
+		}
+	}
+
+	return records
+}
+
+// csvCell prefixes values that spreadsheets would evaluate as a formula with a quote
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
+
+func formatMoney(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
@@ -2,21 +2,43 @@ package service
 
 import (
 	"context"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/joaopsramos/fincon/internal/domain"
 	"github.com/joaopsramos/fincon/internal/errs"
+	"github.com/joaopsramos/fincon/internal/util"
 )
 
 type GoalService struct {
 	goalRepo domain.GoalRepo
 }
 
+type CreateGoalDTO struct {
+	Name  string
+	Color string
+}
+
 type UpdateGoalDTO struct {
 	ID         int
 	Percentage int
 }
 
+type UpdateGoalDetailsDTO struct {
+	Name     string
+	Color    string
+	Position *int
+	Archived *bool
+	// AlertThresholds replaces the thresholds of the goal when not nil, an empty list disables alerts
+	AlertThresholds *[]int
+	EmailAlerts     *bool
+	RolloverPolicy  string
+}
+
+const maxAlertThresholds = 5
+
 func NewGoalService(goalRepo domain.GoalRepo) GoalService {
 	return GoalService{goalRepo: goalRepo}
 }
@@ -25,26 +47,190 @@ func (s *GoalService) All(ctx context.Context, userID uuid.UUID) []domain.Goal {
 	return s.goalRepo.All(ctx, userID)
 }
 
+func (s *GoalService) AllWithArchived(ctx context.Context, userID uuid.UUID) []domain.Goal {
+	return s.goalRepo.AllWithArchived(ctx, userID)
+}
+
 func (s *GoalService) Get(ctx context.Context, id uint, userID uuid.UUID) (*domain.Goal, error) {
 	return s.goalRepo.Get(ctx, id, userID)
 }
 
-func (s *GoalService) Create(ctx context.Context, goals ...domain.Goal) error {
-	return s.goalRepo.Create(ctx, goals...)
+// Create adds a goal after the existing ones. New goals start with 0%, since the sum of
+// the percentages must stay at 100 they are distributed afterwards through UpdateAll.
+func (s *GoalService) Create(ctx context.Context, dto CreateGoalDTO, userID uuid.UUID) (*domain.Goal, error) {
+	goals := s.goalRepo.AllWithArchived(ctx, userID)
+
+	if err := validateGoalName(goals, dto.Name, 0); err != nil {
+		return &domain.Goal{}, err
+	}
+
+	goal := domain.Goal{
+		Name:   domain.GoalName(dto.Name),
+		Color:  dto.Color,
+		UserID: userID,
+	}
+
+	for _, g := range goals {
+		goal.Position = max(goal.Position, g.Position+1)
+	}
+
+	if goal.Color == "" {
+		goal.Color = domain.GoalColors[len(goals)%len(domain.GoalColors)]
+	}
+
+	created := []domain.Goal{goal}
+	err := s.goalRepo.Create(ctx, created...)
+
+	return &created[0], err
+}
+
+func (s *GoalService) UpdateByID(ctx context.Context, id uint, dto UpdateGoalDetailsDTO, userID uuid.UUID) (*domain.Goal, error) {
+	goal, err := s.goalRepo.Get(ctx, id, userID)
+	if err != nil {
+		return &domain.Goal{}, err
+	}
+
+	if dto.Name != "" {
+		if err := validateGoalName(s.goalRepo.AllWithArchived(ctx, userID), dto.Name, goal.ID); err != nil {
+			return &domain.Goal{}, err
+		}
+	}
+
+	util.UpdateIfNotZero(&goal.Name, domain.GoalName(dto.Name))
+	util.UpdateIfNotZero(&goal.Color, dto.Color)
+
+	if dto.Position != nil {
+		goal.Position = *dto.Position
+	}
+
+	if dto.AlertThresholds != nil {
+		thresholds := slices.Clone(*dto.AlertThresholds)
+		slices.Sort(thresholds)
+		thresholds = slices.Compact(thresholds)
+
+		if len(thresholds) > maxAlertThresholds {
+			return &domain.Goal{}, errs.NewValidationErrorF("a goal can have at most %d alert thresholds", maxAlertThresholds)
+		}
+
+		goal.AlertThresholds = thresholds
+		if goal.AlertThresholds == nil {
+			goal.AlertThresholds = []int{}
+		}
+	}
+
+	if dto.EmailAlerts != nil {
+		goal.EmailAlerts = *dto.EmailAlerts
+	}
+
+	if dto.RolloverPolicy != "" {
+		if !slices.Contains(domain.RolloverPolicies(), dto.RolloverPolicy) {
+			return &domain.Goal{}, errs.NewValidationErrorF("rollover policy must be one of: %s", strings.Join(domain.RolloverPolicies(), ", "))
+		}
+
+		goal.RolloverPolicy = domain.RolloverPolicy(dto.RolloverPolicy)
+	}
+
+	if dto.Archived != nil {
+		switch {
+		case *dto.Archived && !goal.IsArchived():
+			if goal.Percentage > 0 {
+				return &domain.Goal{}, errs.NewValidationError("set the goal percentage to 0 before archiving it")
+			}
+
+			now := time.Now().UTC()
+			goal.ArchivedAt = &now
+		case !*dto.Archived:
+			goal.ArchivedAt = nil
+		}
+	}
+
+	err = s.goalRepo.Update(ctx, goal)
+
+	return goal, err
 }
 
+// Delete removes a goal, its expenses, savings targets and percentage are moved to the goal with
+// id reassignToID. It can be 0 only when the goal has none of them.
+func (s *GoalService) Delete(ctx context.Context, id uint, reassignToID uint, userID uuid.UUID) error {
+	goal, err := s.goalRepo.Get(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	var reassignTo *domain.Goal
+
+	if reassignToID > 0 {
+		if reassignToID == goal.ID {
+			return errs.NewValidationError("a goal cannot be reassigned to itself")
+		}
+
+		reassignTo, err = s.goalRepo.Get(ctx, reassignToID, userID)
+		if err != nil {
+			return err
+		}
+
+		if reassignTo.IsArchived() {
+			return errs.NewValidationError("cannot reassign to an archived goal")
+		}
+	} else if goal.Percentage > 0 {
+		return errs.NewValidationError("goal has a percentage, choose a goal to reassign it to")
+	}
+
+	return s.goalRepo.Delete(ctx, goal, reassignTo)
+}
+
+// UpdateAll sets the percentages of all the active goals at once from the current month
+// onwards, their sum must be 100
 func (s *GoalService) UpdateAll(ctx context.Context, dtos []UpdateGoalDTO, userID uuid.UUID) ([]domain.Goal, error) {
+	return s.SetAllocation(ctx, time.Now(), dtos, userID)
+}
+
+// Allocation returns the active goals with the percentages they had in the month of the given date
+func (s *GoalService) Allocation(ctx context.Context, date time.Time, userID uuid.UUID) ([]domain.Goal, error) {
+	goals := s.All(ctx, userID)
+
+	history, err := s.goalRepo.AllAllocations(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	allocations := domain.NewGoalAllocations(history)
+	for i, g := range goals {
+		goals[i].Percentage = allocations.PercentageFor(g, date)
+	}
+
+	return goals, nil
+}
+
+// SetAllocation sets the percentages of all the active goals for the month of the given date,
+// their sum must be 100. Changing a past month keeps the percentages of the following months,
+// while the current and future months apply until the next change.
+func (s *GoalService) SetAllocation(ctx context.Context, date time.Time, dtos []UpdateGoalDTO, userID uuid.UUID) ([]domain.Goal, error) {
 	var zero []domain.Goal
 
-	if len(dtos) < len(domain.DefaultGoalPercentages()) {
+	goals := s.All(ctx, userID)
+
+	if len(dtos) < len(goals) {
 		return zero, errs.NewValidationError("one or more goals are missing")
 	}
 
-	percentageSum := 0
 	for _, d := range dtos {
 		if d.Percentage < 0 || d.Percentage > 100 {
 			return zero, errs.NewValidationErrorF("invalid percentage for goal id %d, it must be between 1 and 100", d.ID)
 		}
+	}
+
+	dtosByID := make(map[int]UpdateGoalDTO, len(dtos))
+	for _, d := range dtos {
+		dtosByID[d.ID] = d
+	}
+
+	percentageSum := 0
+	for _, g := range goals {
+		d, exists := dtosByID[int(g.ID)]
+		if !exists {
+			return zero, errs.NewValidationErrorF("missing goal with id %d", g.ID)
+		}
 
 		percentageSum += d.Percentage
 	}
@@ -53,23 +239,65 @@ func (s *GoalService) UpdateAll(ctx context.Context, dtos []UpdateGoalDTO, userI
 		return zero, errs.NewValidationError("the sum of all percentages must be equal to 100")
 	}
 
-	goals := s.All(ctx, userID)
+	history, err := s.goalRepo.AllAllocations(ctx, userID)
+	if err != nil {
+		return zero, err
+	}
 
-	dtosByID := make(map[int]UpdateGoalDTO, len(dtos))
-	for _, d := range dtos {
-		dtosByID[d.ID] = d
+	allocations := domain.NewGoalAllocations(history)
+
+	month := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+	nextMonth := month.AddDate(0, 1, 0)
+	isPast := nextMonth.Before(time.Now())
+
+	var changes []domain.GoalAllocation
+	for _, g := range goals {
+		allocation := domain.GoalAllocation{GoalID: g.ID, UserID: userID}
+
+		if len(allocations[g.ID]) == 0 {
+			// Goals without history had their current percentage in every month so far
+			baseline := allocation
+			baseline.Percentage = g.Percentage
+			changes = append(changes, baseline)
+		}
+
+		if isPast && !allocations.HasAllocationAt(g.ID, nextMonth) {
+			next := allocation
+			next.EffectiveFrom = nextMonth
+			next.Percentage = allocations.PercentageFor(g, nextMonth)
+			changes = append(changes, next)
+		}
+
+		allocation.EffectiveFrom = month
+		allocation.Percentage = uint(dtosByID[int(g.ID)].Percentage)
+		changes = append(changes, allocation)
 	}
 
+	allocations = allocations.With(changes...)
+
+	now := time.Now()
 	for i, g := range goals {
-		d, exists := dtosByID[int(g.ID)]
-		if !exists {
-			return zero, errs.NewValidationErrorF("missing goal with id %d", g.ID)
-		}
+		goals[i].Percentage = allocations.PercentageFor(g, now)
+	}
 
-		goals[i].Percentage = uint(d.Percentage)
+	if err := s.goalRepo.UpdateAll(ctx, goals, changes); err != nil {
+		return zero, err
 	}
 
-	err := s.goalRepo.UpdateAll(ctx, goals)
+	for i, g := range goals {
+		goals[i].Percentage = allocations.PercentageFor(g, month)
+	}
+
+	return goals, nil
+}
+
+// validateGoalName ensures the user doesn't have another goal with the same name, ignoring case
+func validateGoalName(goals []domain.Goal, name string, ignoreID uint) error {
+	for _, g := range goals {
+		if g.ID != ignoreID && strings.EqualFold(string(g.Name), strings.TrimSpace(name)) {
+			return errs.NewValidationError("a goal with this name already exists")
+		}
+	}
 
-	return goals, err
+	return nil
 }
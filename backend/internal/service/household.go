@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joaopsramos/fincon/internal/config"
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/errs"
+	"github.com/joaopsramos/fincon/internal/mail"
+	"github.com/joaopsramos/fincon/internal/types"
+	"github.com/joaopsramos/fincon/internal/util"
+)
+
+const invitationExpiresIn = 7 * 24 * time.Hour
+
+type HouseholdService struct {
+	householdRepo domain.HouseholdRepo
+	userRepo      domain.UserRepo
+	transactor    domain.Transactor
+	mailer        mail.Mailer
+}
+
+type InviteMemberDTO struct {
+	Email string
+	Role  domain.HouseholdRole
+}
+
+func NewHouseholdService(
+	householdRepo domain.HouseholdRepo,
+	userRepo domain.UserRepo,
+	transactor domain.Transactor,
+	mailer mail.Mailer,
+) HouseholdService {
+	return HouseholdService{householdRepo: householdRepo, userRepo: userRepo, transactor: transactor, mailer: mailer}
+}
+
+func (s *HouseholdService) All(ctx context.Context, userID uuid.UUID) ([]domain.Membership, error) {
+	return s.householdRepo.AllMemberships(ctx, userID)
+}
+
+// Create shares the budget of the user, each user can own a single household
+func (s *HouseholdService) Create(ctx context.Context, name string, userID uuid.UUID) (*domain.Membership, error) {
+	if _, err := s.householdRepo.GetByOwner(ctx, userID); err == nil {
+		return nil, errs.NewValidationError("you already own a household")
+	} else if !errors.Is(err, errs.ErrNotFound{}) {
+		return nil, err
+	}
+
+	household := domain.Household{Name: name, OwnerID: userID}
+	if err := s.householdRepo.Create(ctx, &household); err != nil {
+		return nil, err
+	}
+
+	return &domain.Membership{HouseholdID: household.ID, Name: name, OwnerID: userID, Role: domain.RoleOwner}, nil
+}
+
+func (s *HouseholdService) Delete(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	if _, err := s.ownerMembership(ctx, id, userID); err != nil {
+		return err
+	}
+
+	return s.householdRepo.Delete(ctx, id)
+}
+
+// Membership returns the household with the role of the user in it
+func (s *HouseholdService) Membership(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.Membership, error) {
+	return s.householdRepo.GetMembership(ctx, id, userID)
+}
+
+func (s *HouseholdService) Members(ctx context.Context, id uuid.UUID, userID uuid.UUID) ([]domain.HouseholdMember, error) {
+	if _, err := s.householdRepo.GetMembership(ctx, id, userID); err != nil {
+		return nil, err
+	}
+
+	return s.householdRepo.Members(ctx, id)
+}
+
+func (s *HouseholdService) UpdateMemberRole(ctx context.Context, id uuid.UUID, memberID uuid.UUID, role domain.HouseholdRole, userID uuid.UUID) error {
+	if _, err := s.ownerMembership(ctx, id, userID); err != nil {
+		return err
+	}
+
+	if err := validateInvitableRole(role); err != nil {
+		return err
+	}
+
+	return s.householdRepo.UpdateMemberRole(ctx, id, memberID, role)
+}
+
+// RemoveMember lets the owner remove anyone and the other members leave the household
+func (s *HouseholdService) RemoveMember(ctx context.Context, id uuid.UUID, memberID uuid.UUID, userID uuid.UUID) error {
+	membership, err := s.householdRepo.GetMembership(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if memberID == membership.OwnerID {
+		return errs.NewValidationError("the owner can't leave the household, delete it instead")
+	}
+
+	if memberID != userID && membership.Role != domain.RoleOwner {
+		return errs.ErrForbidden
+	}
+
+	return s.householdRepo.RemoveMember(ctx, id, memberID)
+}
+
+// Invite emails a link to join the household, only the owner can invite. The invitation
+// isn't kept when the email can't be sent.
+func (s *HouseholdService) Invite(ctx context.Context, id uuid.UUID, dto InviteMemberDTO, userID uuid.UUID) (*domain.HouseholdInvitation, error) {
+	membership, err := s.ownerMembership(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateInvitableRole(dto.Role); err != nil {
+		return nil, err
+	}
+
+	members, err := s.householdRepo.Members(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if slices.ContainsFunc(members, func(m domain.HouseholdMember) bool { return strings.EqualFold(m.User.Email, dto.Email) }) {
+		return nil, errs.NewValidationError("user is already a member of the household")
+	}
+
+	inviter, err := s.userRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation := domain.HouseholdInvitation{
+		HouseholdID: id,
+		Email:       dto.Email,
+		Role:        dto.Role,
+		InvitedByID: userID,
+		ExpiresAt:   time.Now().UTC().Add(invitationExpiresIn),
+	}
+
+	err = s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.householdRepo.CreateInvitation(ctx, &invitation); err != nil {
+			return err
+		}
+
+		return s.mailer.Send(ctx, mail.Email{
+			To:       types.MailContact{Email: dto.Email},
+			Subject:  mail.HouseholdInvitationSubject,
+			Template: mail.HouseholdInvitationTemplate,
+			Data: util.M{
+				"Household": membership.Name,
+				"InvitedBy": inviter.Email,
+				"Link":      fmt.Sprintf("%s/households/join?token=%s", config.Get().WebURL, invitation.Token),
+			},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &invitation, nil
+}
+
+// Accept adds the user to the household, invitations can only be accepted by the invited email
+func (s *HouseholdService) Accept(ctx context.Context, token string, userID uuid.UUID) (*domain.Membership, error) {
+	if err := uuid.Validate(token); err != nil {
+		return nil, errs.ErrInvalidToken
+	}
+
+	invitation, err := s.householdRepo.GetInvitationByToken(ctx, token)
+	if errors.Is(err, errs.ErrNotFound{}) {
+		return nil, errs.ErrInvalidToken
+	} else if err != nil {
+		return nil, err
+	}
+
+	if invitation.AcceptedAt != nil || time.Now().UTC().After(invitation.ExpiresAt) {
+		return nil, errs.ErrInvalidToken
+	}
+
+	user, err := s.userRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.EqualFold(user.Email, invitation.Email) {
+		return nil, errs.ErrForbidden
+	}
+
+	if err := s.householdRepo.AcceptInvitation(ctx, invitation, userID); err != nil {
+		return nil, err
+	}
+
+	return s.householdRepo.GetMembership(ctx, invitation.HouseholdID, userID)
+}
+
+func (s *HouseholdService) ownerMembership(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.Membership, error) {
+	membership, err := s.householdRepo.GetMembership(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if membership.Role != domain.RoleOwner {
+		return nil, errs.ErrForbidden
+	}
+
+	return membership, nil
+}
+
+func validateInvitableRole(role domain.HouseholdRole) error {
+	if !slices.Contains(domain.InvitableRoles(), string(role)) {
+		return errs.NewValidationErrorF("role must be one of: %s", strings.Join(domain.InvitableRoles(), ", "))
+	}
+
+	return nil
+}
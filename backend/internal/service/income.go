@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/errs"
+	"github.com/joaopsramos/fincon/internal/util"
+	"github.com/shopspring/decimal"
+)
+
+type IncomeService struct {
+	incomeRepo domain.IncomeRepo
+}
+
+type CreateIncomeDTO struct {
+	Name      string
+	Amount    float64
+	Recurring bool
+	StartDate time.Time
+	EndDate   *time.Time
+}
+
+type UpdateIncomeDTO struct {
+	Name      string
+	Amount    float64
+	Recurring *bool
+	StartDate time.Time
+	EndDate   *time.Time
+	// ClearEndDate removes the end date, making a recurring income open-ended
+	ClearEndDate bool
+}
+
+func NewIncomeService(incomeRepo domain.IncomeRepo) IncomeService {
+	return IncomeService{incomeRepo: incomeRepo}
+}
+
+func (s *IncomeService) All(ctx context.Context, userID uuid.UUID) ([]domain.Income, error) {
+	return s.incomeRepo.All(ctx, userID)
+}
+
+func (s *IncomeService) Get(ctx context.Context, id uint, userID uuid.UUID) (*domain.Income, error) {
+	return s.incomeRepo.Get(ctx, id, userID)
+}
+
+func (s *IncomeService) Create(ctx context.Context, dto CreateIncomeDTO, userID uuid.UUID) (*domain.Income, error) {
+	income := domain.Income{
+		Name:      dto.Name,
+		Amount:    decimal.NewFromFloat(dto.Amount).Mul(decimal.NewFromInt(100)).IntPart(),
+		Recurring: dto.Recurring,
+		StartDate: dto.StartDate,
+		EndDate:   dto.EndDate,
+		UserID:    userID,
+	}
+
+	if err := validateIncome(income); err != nil {
+		return &domain.Income{}, err
+	}
+
+	err := s.incomeRepo.Create(ctx, &income)
+
+	return &income, err
+}
+
+func (s *IncomeService) UpdateByID(ctx context.Context, id uint, dto UpdateIncomeDTO, userID uuid.UUID) (*domain.Income, error) {
+	income, err := s.incomeRepo.Get(ctx, id, userID)
+	if err != nil {
+		return &domain.Income{}, err
+	}
+
+	util.UpdateIfNotZero(&income.Name, dto.Name)
+	util.UpdateIfNotZero(&income.Amount, decimal.NewFromFloat(dto.Amount).Mul(decimal.NewFromInt(100)).IntPart())
+	util.UpdateIfNotZero(&income.StartDate, dto.StartDate)
+	util.UpdateIfNotZero(&income.EndDate, dto.EndDate)
+
+	if dto.Recurring != nil {
+		income.Recurring = *dto.Recurring
+	}
+
+	if dto.ClearEndDate {
+		income.EndDate = nil
+	}
+
+	if err := validateIncome(*income); err != nil {
+		return &domain.Income{}, err
+	}
+
+	err = s.incomeRepo.Update(ctx, income)
+
+	return income, err
+}
+
+func (s *IncomeService) Delete(ctx context.Context, id uint, userID uuid.UUID) error {
+	return s.incomeRepo.Delete(ctx, id, userID)
+}
+
+// SetAmount sets what the income paid in the month of the given date, replacing its usual
+// amount in that month only
+func (s *IncomeService) SetAmount(ctx context.Context, id uint, month time.Time, amount float64, userID uuid.UUID) (*domain.Income, error) {
+	income, err := s.incomeRepo.Get(ctx, id, userID)
+	if err != nil {
+		return &domain.Income{}, err
+	}
+
+	if !income.OccursIn(month) {
+		return &domain.Income{}, errs.NewValidationError("the income is not received in this month")
+	}
+
+	a := domain.IncomeAmount{
+		IncomeID: income.ID,
+		Month:    time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC),
+		Amount:   decimal.NewFromFloat(amount).Mul(decimal.NewFromInt(100)).IntPart(),
+	}
+
+	if err := s.incomeRepo.SetAmount(ctx, &a); err != nil {
+		return &domain.Income{}, err
+	}
+
+	return s.incomeRepo.Get(ctx, id, userID)
+}
+
+// DeleteAmount makes the income pay its usual amount again in the month of the given date
+func (s *IncomeService) DeleteAmount(ctx context.Context, id uint, month time.Time, userID uuid.UUID) (*domain.Income, error) {
+	income, err := s.incomeRepo.Get(ctx, id, userID)
+	if err != nil {
+		return &domain.Income{}, err
+	}
+
+	if err := s.incomeRepo.DeleteAmount(ctx, income.ID, time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		return &domain.Income{}, err
+	}
+
+	return s.incomeRepo.Get(ctx, id, userID)
+}
+
+func validateIncome(i domain.Income) error {
+	if i.EndDate != nil && !i.Recurring {
+		return errs.NewValidationError("only recurring incomes can have an end date")
+	}
+
+	if i.EndDate != nil && i.EndDate.Before(i.StartDate) {
+		return errs.NewValidationError("end date must not be before start date")
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/errs"
+	"github.com/joaopsramos/fincon/internal/util"
+	"github.com/shopspring/decimal"
+)
+
+type RecurringExpenseService struct {
+	recurringExpenseRepo domain.RecurringExpenseRepo
+	goalRepo             domain.GoalRepo
+}
+
+type CreateRecurringExpenseDTO struct {
+	Name       string
+	Value      float64
+	DayOfMonth int
+	Cadence    string
+	StartDate  time.Time
+	EndDate    *time.Time
+	GoalID     int
+}
+
+type UpdateRecurringExpenseDTO struct {
+	Name       string
+	Value      float64
+	DayOfMonth int
+	Cadence    string
+	StartDate  time.Time
+	EndDate    *time.Time
+	// ClearEndDate removes the end date, making the template repeat indefinitely
+	ClearEndDate bool
+	GoalID       int
+}
+
+func NewRecurringExpenseService(recurringExpenseRepo domain.RecurringExpenseRepo, goalRepo domain.GoalRepo) RecurringExpenseService {
+	return RecurringExpenseService{recurringExpenseRepo: recurringExpenseRepo, goalRepo: goalRepo}
+}
+
+func (s *RecurringExpenseService) All(ctx context.Context, userID uuid.UUID) ([]domain.RecurringExpense, error) {
+	return s.recurringExpenseRepo.All(ctx, userID)
+}
+
+func (s *RecurringExpenseService) Get(ctx context.Context, id uint, userID uuid.UUID) (*domain.RecurringExpense, error) {
+	return s.recurringExpenseRepo.Get(ctx, id, userID)
+}
+
+func (s *RecurringExpenseService) Create(ctx context.Context, dto CreateRecurringExpenseDTO, userID uuid.UUID) (*domain.RecurringExpense, error) {
+	goal, err := s.goalRepo.Get(ctx, uint(dto.GoalID), userID)
+	if err != nil {
+		return &domain.RecurringExpense{}, err
+	}
+
+	re := domain.RecurringExpense{
+		Name:       dto.Name,
+		Value:      decimal.NewFromFloat(dto.Value).Mul(decimal.NewFromInt(100)).IntPart(),
+		DayOfMonth: dto.DayOfMonth,
+		Cadence:    domain.RecurringCadence(dto.Cadence),
+		StartDate:  dto.StartDate,
+		EndDate:    dto.EndDate,
+		GoalID:     goal.ID,
+		UserID:     userID,
+	}
+
+	if re.Cadence == "" {
+		re.Cadence = domain.Monthly
+	}
+
+	if err := validateRecurringExpense(re); err != nil {
+		return &domain.RecurringExpense{}, err
+	}
+
+	if err := s.recurringExpenseRepo.Create(ctx, &re); err != nil {
+		return &domain.RecurringExpense{}, err
+	}
+
+	if _, err := s.recurringExpenseRepo.Materialize(ctx, &re, time.Now().UTC()); err != nil {
+		return &domain.RecurringExpense{}, err
+	}
+
+	return &re, nil
+}
+
+func (s *RecurringExpenseService) UpdateByID(ctx context.Context, id uint, dto UpdateRecurringExpenseDTO, userID uuid.UUID) (*domain.RecurringExpense, error) {
+	re, err := s.recurringExpenseRepo.Get(ctx, id, userID)
+	if err != nil {
+		return &domain.RecurringExpense{}, err
+	}
+
+	if dto.GoalID > 0 {
+		_, err := s.goalRepo.Get(ctx, uint(dto.GoalID), userID)
+		if err != nil {
+			return &domain.RecurringExpense{}, err
+		}
+	}
+
+	util.UpdateIfNotZero(&re.Name, dto.Name)
+	util.UpdateIfNotZero(&re.Value, decimal.NewFromFloat(dto.Value).Mul(decimal.NewFromInt(100)).IntPart())
+	util.UpdateIfNotZero(&re.DayOfMonth, dto.DayOfMonth)
+	util.UpdateIfNotZero(&re.Cadence, domain.RecurringCadence(dto.Cadence))
+	util.UpdateIfNotZero(&re.StartDate, dto.StartDate)
+	util.UpdateIfNotZero(&re.EndDate, dto.EndDate)
+	util.UpdateIfNotZero(&re.GoalID, uint(dto.GoalID))
+
+	if dto.ClearEndDate {
+		re.EndDate = nil
+	}
+
+	if err := validateRecurringExpense(*re); err != nil {
+		return &domain.RecurringExpense{}, err
+	}
+
+	if err := s.recurringExpenseRepo.Update(ctx, re); err != nil {
+		return &domain.RecurringExpense{}, err
+	}
+
+	// Moving the start date back or clearing the end date may make more months due
+	if _, err := s.recurringExpenseRepo.Materialize(ctx, re, time.Now().UTC()); err != nil {
+		return &domain.RecurringExpense{}, err
+	}
+
+	return re, nil
+}
+
+func (s *RecurringExpenseService) Delete(ctx context.Context, id uint, userID uuid.UUID) error {
+	return s.recurringExpenseRepo.Delete(ctx, id, userID)
+}
+
+// MaterializeDue creates the expenses of every template up to the month of now, it's run
+// periodically so new months get their expenses without the templates being changed
+func (s *RecurringExpenseService) MaterializeDue(ctx context.Context, now time.Time) (int, error) {
+	templates, err := s.recurringExpenseRepo.AllStartedBy(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	var errList []error
+	created := 0
+
+	for _, re := range templates {
+		n, err := s.recurringExpenseRepo.Materialize(ctx, &re, now)
+		if err != nil {
+			errList = append(errList, fmt.Errorf("recurring expense %d: %w", re.ID, err))
+			continue
+		}
+
+		created += n
+	}
+
+	return created, errors.Join(errList...)
+}
+
+func validateRecurringExpense(re domain.RecurringExpense) error {
+	if re.EndDate != nil && re.EndDate.Before(re.StartDate) {
+		return errs.NewValidationError("end date must not be before start date")
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/repository"
+	"github.com/joaopsramos/fincon/internal/service"
+	"github.com/joaopsramos/fincon/internal/testhelper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecurringExpenseService_MaterializeDue(t *testing.T) {
+	t.Parallel()
+	a := assert.New(t)
+	ctx := context.Background()
+
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	user := f.InsertUser()
+	expenseService := NewTestExpenseService(t, tx)
+	recurringExpenseService := service.NewRecurringExpenseService(repository.NewPostgresRecurringExpense(tx), repository.NewPostgresGoal(tx))
+
+	goal := domain.Goal{Name: domain.FixedCosts, UserID: user.ID}
+	f.InsertGoal(&goal)
+
+	start := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	rent := f.InsertRecurringExpense(&domain.RecurringExpense{
+		Name: "Rent", Value: 150000, DayOfMonth: 31, Cadence: domain.Monthly, StartDate: start, GoalID: goal.ID, UserID: user.ID,
+	})
+	f.InsertRecurringExpense(&domain.RecurringExpense{
+		Name: "Insurance", Value: 90000, DayOfMonth: 5, Cadence: domain.Quarterly, StartDate: start, GoalID: goal.ID, UserID: user.ID,
+	})
+
+	now := time.Date(2025, 5, 15, 0, 0, 0, 0, time.UTC)
+
+	// Every month since the start: rent from January to May and insurance in April, its
+	// January expense would be dated before the start date
+	created, err := recurringExpenseService.MaterializeDue(ctx, now)
+	a.NoError(err)
+	a.Equal(6, created)
+
+	// calling it again must not duplicate expenses
+	created, err = recurringExpenseService.MaterializeDue(ctx, now)
+	a.NoError(err)
+	a.Zero(created)
+
+	aprilExpenses, err := expenseService.AllByGoalID(ctx, goal.ID, 2025, time.April, user.ID)
+	a.NoError(err)
+	a.Len(aprilExpenses, 2)
+
+	for _, e := range aprilExpenses {
+		if e.Name == "Rent" {
+			// April has only 30 days
+			a.Equal(time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC), e.Date)
+		} else {
+			a.Equal(time.Date(2025, 4, 5, 0, 0, 0, 0, time.UTC), e.Date)
+		}
+	}
+
+	mayExpenses, err := expenseService.AllByGoalID(ctx, goal.ID, 2025, time.May, user.ID)
+	a.NoError(err)
+	a.Len(mayExpenses, 1)
+
+	a.Equal("Rent", mayExpenses[0].Name)
+	a.Equal(int64(150000), mayExpenses[0].Value)
+	a.Equal(time.Date(2025, 5, 31, 0, 0, 0, 0, time.UTC), mayExpenses[0].Date)
+	a.Equal(rent.ID, *mayExpenses[0].RecurringExpenseID)
+
+	// A deleted occurrence doesn't come back
+	a.NoError(expenseService.Delete(ctx, mayExpenses[0].ID, user.ID))
+
+	created, err = recurringExpenseService.MaterializeDue(ctx, now)
+	a.NoError(err)
+	a.Zero(created)
+
+	mayExpenses, err = expenseService.AllByGoalID(ctx, goal.ID, 2025, time.May, user.ID)
+	a.NoError(err)
+	a.Empty(mayExpenses)
+
+	// Reading a month the job didn't reach yet materializes it
+	juneExpenses, err := expenseService.AllByGoalID(ctx, goal.ID, 2025, time.June, user.ID)
+	a.NoError(err)
+	a.Len(juneExpenses, 1)
+	a.Equal(time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC), juneExpenses[0].Date)
+
+	juneExpenses, err = expenseService.AllByGoalID(ctx, goal.ID, 2025, time.June, user.ID)
+	a.NoError(err)
+	a.Len(juneExpenses, 1)
+
+	july := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	_, err = expenseService.GetSummary(ctx, july, user.ID)
+	a.NoError(err)
+
+	var julyCount int64
+	a.NoError(tx.Model(&domain.Expense{}).Where("recurring_expense_id = ? AND date >= ?", rent.ID, july).Count(&julyCount).Error)
+	a.Equal(int64(1), julyCount)
+}
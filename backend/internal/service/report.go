@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/errs"
+	"github.com/joaopsramos/fincon/internal/util"
+	"github.com/shopspring/decimal"
+)
+
+// maxTrendMonths limits how many months a trend report can span
+const maxTrendMonths = 60
+
+// TrendMonth is the summary of a month, including the excess carried over from previous months
+type TrendMonth struct {
+	Month string `json:"month"`
+	Summary
+}
+
+// TrendTotals sums what was actually spent in each month, without carry-over, against the month limits
+type TrendTotals struct {
+	Spent            float64 `json:"spent"`
+	MustSpend        float64 `json:"must_spend"`
+	AverageSpent     float64 `json:"average_spent"`
+	AverageMustSpend float64 `json:"average_must_spend"`
+}
+
+type TrendGoalTotals struct {
+	Name string `json:"name"`
+	TrendTotals
+}
+
+type TrendYear struct {
+	Year   int               `json:"year"`
+	Months int               `json:"months"`
+	Goals  []TrendGoalTotals `json:"goals"`
+	TrendTotals
+}
+
+type Trend struct {
+	Months []TrendMonth `json:"months"`
+	Years  []TrendYear  `json:"years"`
+}
+
+// GetTrend returns the summary of every month from the month of "from" up to the month of "to",
+// both inclusive, along with the totals and monthly averages of each year in the range
+func (s *ExpenseService) GetTrend(ctx context.Context, from time.Time, to time.Time, userID uuid.UUID) (*Trend, error) {
+	from = time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to = time.Date(to.Year(), to.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	if to.Before(from) {
+		return &Trend{}, errs.NewValidationError("to must not be before from")
+	}
+
+	if (to.Year()-from.Year())*12+int(to.Month()-from.Month()) >= maxTrendMonths {
+		return &Trend{}, errs.NewValidationErrorF("the range must have at most %d months", maxTrendMonths)
+	}
+
+	history, err := s.loadBudgetHistory(ctx, to, userID)
+	if err != nil {
+		return &Trend{}, err
+	}
+
+	type spendingKey struct {
+		goalID uint
+		month  time.Time
+	}
+
+	spent := make(map[spendingKey]int64, len(history.spendings))
+	for _, m := range history.spendings {
+		month := time.Date(m.Date.Year(), m.Date.Month(), 1, 0, 0, 0, 0, time.UTC)
+		spent[spendingKey{m.Goal.ID, month}] += m.Spent
+	}
+
+	// The yearly totals include archived goals in the months before they were archived, so
+	// past years keep what was spent on them
+	goals := s.goalRepo.AllWithArchived(ctx, userID)
+	activeIn := func(g domain.Goal, month time.Time) bool {
+		return g.ArchivedAt == nil || month.Before(*g.ArchivedAt)
+	}
+
+	type totals struct {
+		spent, mustSpend decimal.Decimal
+	}
+
+	trend := Trend{Months: []TrendMonth{}, Years: []TrendYear{}}
+
+	// The totals of each goal in the year being built, the last element is for all of them
+	var year []totals
+	var active []bool
+	var months int
+
+	hundred := decimal.NewFromInt(100)
+
+	closeYear := func(y int) {
+		if months == 0 {
+			return
+		}
+
+		ty := TrendYear{Year: y, Months: months, Goals: []TrendGoalTotals{}}
+		for i, g := range goals {
+			if active[i] {
+				ty.Goals = append(ty.Goals, TrendGoalTotals{Name: string(g.Name), TrendTotals: newTrendTotals(year[i].spent, year[i].mustSpend, months)})
+			}
+		}
+
+		all := year[len(year)-1]
+		ty.TrendTotals = newTrendTotals(all.spent, all.mustSpend, months)
+
+		trend.Years = append(trend.Years, ty)
+	}
+
+	for month := from; !month.After(to); month = month.AddDate(0, 1, 0) {
+		if month.Month() == time.January || month.Equal(from) {
+			closeYear(month.Year() - 1)
+			year = make([]totals, len(goals)+1)
+			active = make([]bool, len(goals))
+			months = 0
+		}
+
+		trend.Months = append(trend.Months, TrendMonth{
+			Month:   month.Format(util.ApiMonthLayout),
+			Summary: *history.summaryFor(month),
+		})
+
+		budget := util.MoneyAmountToDecimal(history.budgetFor(month))
+		all := &year[len(year)-1]
+
+		for i, g := range goals {
+			if !activeIn(g, month) {
+				continue
+			}
+
+			active[i] = true
+			goalSpent := util.MoneyAmountToDecimal(spent[spendingKey{g.ID, month}])
+			mustSpend := budget.Mul(decimal.NewFromInt(int64(history.allocations.PercentageFor(g, month)))).Div(hundred)
+
+			year[i].spent = year[i].spent.Add(goalSpent)
+			year[i].mustSpend = year[i].mustSpend.Add(mustSpend)
+			all.spent = all.spent.Add(goalSpent)
+		}
+
+		all.mustSpend = all.mustSpend.Add(budget)
+		months++
+	}
+
+	closeYear(to.Year())
+
+	return &trend, nil
+}
+
+func newTrendTotals(spent decimal.Decimal, mustSpend decimal.Decimal, months int) TrendTotals {
+	count := decimal.NewFromInt(int64(months))
+
+	return TrendTotals{
+		Spent:            spent.InexactFloat64(),
+		MustSpend:        mustSpend.InexactFloat64(),
+		AverageSpent:     spent.Div(count).Round(2).InexactFloat64(),
+		AverageMustSpend: mustSpend.Div(count).Round(2).InexactFloat64(),
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/joaopsramos/fincon/internal/domain"
@@ -20,17 +21,52 @@ func NewSalaryService(salaryRepo domain.SalaryRepo) SalaryService {
 }
 
 func BuildSalary(dto CreateSalaryDTO) domain.Salary {
+	now := time.Now().UTC()
+
 	return domain.Salary{
-		Amount: int64(dto.Amount * 100),
+		Amount:        int64(dto.Amount * 100),
+		EffectiveFrom: time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC),
 	}
 }
 
-func (s *SalaryService) UpdateAmount(ctx context.Context, salary *domain.Salary, amount float64) error {
-	salary.Amount = int64(amount * 100)
+// UpdateAmount sets the salary from the month of effectiveFrom onwards, it defaults to the
+// current month. Previous months keep the amount that was effective for them.
+func (s *SalaryService) UpdateAmount(ctx context.Context, userID uuid.UUID, amount float64, effectiveFrom time.Time) (*domain.Salary, error) {
+	if effectiveFrom.IsZero() {
+		effectiveFrom = time.Now().UTC()
+	}
+
+	monthStart := time.Date(effectiveFrom.Year(), effectiveFrom.Month(), 1, 0, 0, 0, 0, time.UTC)
 
-	return s.salaryRepo.Update(ctx, salary)
+	history, err := s.salaryRepo.History(ctx, userID)
+	if err != nil {
+		return &domain.Salary{}, err
+	}
+
+	for _, salary := range history {
+		if salary.EffectiveFrom.Equal(monthStart) {
+			salary.Amount = int64(amount * 100)
+			err = s.salaryRepo.Update(ctx, &salary)
+
+			return &salary, err
+		}
+	}
+
+	salary := domain.Salary{
+		Amount:        int64(amount * 100),
+		EffectiveFrom: monthStart,
+		UserID:        userID,
+	}
+
+	err = s.salaryRepo.Create(ctx, &salary)
+
+	return &salary, err
 }
 
 func (s *SalaryService) Get(ctx context.Context, userID uuid.UUID) (*domain.Salary, error) {
 	return s.salaryRepo.Get(ctx, userID)
 }
+
+func (s *SalaryService) History(ctx context.Context, userID uuid.UUID) ([]domain.Salary, error) {
+	return s.salaryRepo.History(ctx, userID)
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/errs"
+	"github.com/joaopsramos/fincon/internal/util"
+	"github.com/shopspring/decimal"
+)
+
+type SavingsTargetService struct {
+	savingsTargetRepo domain.SavingsTargetRepo
+	goalRepo          domain.GoalRepo
+	expenseRepo       domain.ExpenseRepo
+}
+
+type CreateSavingsTargetDTO struct {
+	Name         string
+	TargetAmount float64
+	// StartDate defaults to the first day of the current month
+	StartDate time.Time
+	Deadline  time.Time
+	GoalID    int
+}
+
+type UpdateSavingsTargetDTO struct {
+	Name         string
+	TargetAmount float64
+	StartDate    time.Time
+	Deadline     time.Time
+	GoalID       int
+}
+
+func NewSavingsTargetService(
+	savingsTargetRepo domain.SavingsTargetRepo,
+	goalRepo domain.GoalRepo,
+	expenseRepo domain.ExpenseRepo,
+) SavingsTargetService {
+	return SavingsTargetService{savingsTargetRepo: savingsTargetRepo, goalRepo: goalRepo, expenseRepo: expenseRepo}
+}
+
+func (s *SavingsTargetService) All(ctx context.Context, userID uuid.UUID) ([]domain.SavingsTarget, error) {
+	return s.savingsTargetRepo.All(ctx, userID)
+}
+
+func (s *SavingsTargetService) Get(ctx context.Context, id uint, userID uuid.UUID) (*domain.SavingsTarget, error) {
+	return s.savingsTargetRepo.Get(ctx, id, userID)
+}
+
+func (s *SavingsTargetService) Create(ctx context.Context, dto CreateSavingsTargetDTO, userID uuid.UUID) (*domain.SavingsTarget, error) {
+	goal, err := s.goalRepo.Get(ctx, uint(dto.GoalID), userID)
+	if err != nil {
+		return &domain.SavingsTarget{}, err
+	}
+
+	if dto.StartDate.IsZero() {
+		now := time.Now().UTC()
+		dto.StartDate = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	target := domain.SavingsTarget{
+		Name:         dto.Name,
+		TargetAmount: decimal.NewFromFloat(dto.TargetAmount).Mul(decimal.NewFromInt(100)).IntPart(),
+		StartDate:    dto.StartDate,
+		Deadline:     dto.Deadline,
+		GoalID:       goal.ID,
+		UserID:       userID,
+	}
+
+	if err := validateSavingsTarget(target); err != nil {
+		return &domain.SavingsTarget{}, err
+	}
+
+	err = s.savingsTargetRepo.Create(ctx, &target)
+
+	return &target, err
+}
+
+func (s *SavingsTargetService) UpdateByID(ctx context.Context, id uint, dto UpdateSavingsTargetDTO, userID uuid.UUID) (*domain.SavingsTarget, error) {
+	target, err := s.savingsTargetRepo.Get(ctx, id, userID)
+	if err != nil {
+		return &domain.SavingsTarget{}, err
+	}
+
+	if dto.GoalID != 0 {
+		goal, err := s.goalRepo.Get(ctx, uint(dto.GoalID), userID)
+		if err != nil {
+			return &domain.SavingsTarget{}, err
+		}
+
+		target.GoalID = goal.ID
+	}
+
+	util.UpdateIfNotZero(&target.Name, dto.Name)
+	util.UpdateIfNotZero(&target.TargetAmount, decimal.NewFromFloat(dto.TargetAmount).Mul(decimal.NewFromInt(100)).IntPart())
+	util.UpdateIfNotZero(&target.StartDate, dto.StartDate)
+	util.UpdateIfNotZero(&target.Deadline, dto.Deadline)
+
+	if err := validateSavingsTarget(*target); err != nil {
+		return &domain.SavingsTarget{}, err
+	}
+
+	err = s.savingsTargetRepo.Update(ctx, target)
+
+	return target, err
+}
+
+func (s *SavingsTargetService) Delete(ctx context.Context, id uint, userID uuid.UUID) error {
+	return s.savingsTargetRepo.Delete(ctx, id, userID)
+}
+
+// Progress sums the expenses of the target goal from its start date up to now, future
+// installments don't count until their month comes
+func (s *SavingsTargetService) Progress(ctx context.Context, id uint, now time.Time, userID uuid.UUID) (*domain.SavingsTarget, domain.SavingsProgress, error) {
+	target, err := s.savingsTargetRepo.Get(ctx, id, userID)
+	if err != nil {
+		return &domain.SavingsTarget{}, domain.SavingsProgress{}, err
+	}
+
+	now = now.UTC()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+
+	saved, err := s.expenseRepo.SumByGoalBetween(ctx, target.GoalID, target.StartDate, tomorrow, userID)
+	if err != nil {
+		return &domain.SavingsTarget{}, domain.SavingsProgress{}, err
+	}
+
+	return target, target.Progress(saved, now), nil
+}
+
+func validateSavingsTarget(t domain.SavingsTarget) error {
+	if t.Deadline.Before(t.StartDate) {
+		return errs.NewValidationError("deadline must not be before start date")
+	}
+
+	return nil
+}
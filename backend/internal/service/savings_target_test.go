@@ -0,0 +1,147 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/repository"
+	"github.com/joaopsramos/fincon/internal/service"
+	"github.com/joaopsramos/fincon/internal/testhelper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavingsTargetService_Progress(t *testing.T) {
+	t.Parallel()
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	ctx := context.Background()
+
+	user := f.InsertUser()
+	goal := f.InsertGoal(&domain.Goal{Name: domain.Goals, UserID: user.ID})
+	otherGoal := f.InsertGoal(&domain.Goal{Name: domain.Pleasures, UserID: user.ID})
+
+	s := service.NewSavingsTargetService(
+		repository.NewPostgresSavingsTarget(tx),
+		repository.NewPostgresGoal(tx),
+		repository.NewPostgresExpense(tx),
+	)
+
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	ptr := func(t time.Time) *time.Time { return &t }
+
+	// 10,000 for a trip by December, started in January
+	target, err := s.Create(ctx, service.CreateSavingsTargetDTO{
+		Name:         "Trip",
+		TargetAmount: 10_000,
+		StartDate:    date(2025, 1, 1),
+		Deadline:     date(2025, 12, 31),
+		GoalID:       int(goal.ID),
+	}, user.ID)
+	require.NoError(t, err)
+
+	insertExpense := func(goalID uint, value int64, date time.Time) {
+		f.InsertExpense(&domain.Expense{Name: "Deposit", Value: value, Date: date, GoalID: goalID, UserID: user.ID})
+	}
+
+	// Before the start and in other goals, they don't count
+	insertExpense(goal.ID, 500_00, date(2024, 12, 20))
+	insertExpense(otherGoal.ID, 500_00, date(2025, 2, 10))
+
+	insertExpense(goal.ID, 1_000_00, date(2025, 1, 10))
+	insertExpense(goal.ID, 1_000_00, date(2025, 2, 10))
+	insertExpense(goal.ID, 1_000_00, date(2025, 3, 10))
+	// Future installment
+	insertExpense(goal.ID, 1_000_00, date(2025, 4, 10))
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want domain.SavingsProgress
+	}{
+		{
+			name: "behind schedule",
+			now:  date(2025, 3, 15),
+			want: domain.SavingsProgress{
+				Saved:          3_000_00,
+				Remaining:      7_000_00,
+				MonthlyAverage: 1_000_00,
+				// 7,000 over the 10 months from March to December
+				RequiredMonthly:     700_00,
+				ProjectedCompletion: ptr(date(2025, 10, 31)),
+			},
+		},
+		{
+			name: "installments count once their day comes",
+			now:  date(2025, 4, 10),
+			want: domain.SavingsProgress{
+				Saved:               4_000_00,
+				Remaining:           6_000_00,
+				MonthlyAverage:      1_000_00,
+				RequiredMonthly:     666_67,
+				ProjectedCompletion: ptr(date(2025, 10, 31)),
+			},
+		},
+		{
+			name: "after the deadline everything that remains is required",
+			now:  date(2026, 2, 1),
+			want: domain.SavingsProgress{
+				Saved:               4_000_00,
+				Remaining:           6_000_00,
+				MonthlyAverage:      285_71,
+				RequiredMonthly:     6_000_00,
+				ProjectedCompletion: ptr(date(2027, 12, 31)),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, progress, err := s.Progress(ctx, target.ID, tt.now, user.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, progress)
+		})
+	}
+
+	t.Run("completed", func(t *testing.T) {
+		insertExpense(goal.ID, 7_000_00, date(2025, 5, 1))
+
+		_, progress, err := s.Progress(ctx, target.ID, date(2025, 5, 2), user.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.SavingsProgress{Saved: 11_000_00, MonthlyAverage: 2_200_00}, progress)
+	})
+}
+
+func TestSavingsTargetService_Create(t *testing.T) {
+	t.Parallel()
+	tx := testhelper.NewTestPostgresTx(t)
+	f := testhelper.NewFactory(tx)
+	ctx := context.Background()
+
+	user := f.InsertUser()
+	goal := f.InsertGoal(&domain.Goal{UserID: user.ID})
+	anotherUserGoal := f.InsertGoal(&domain.Goal{UserID: f.InsertUser().ID})
+
+	s := service.NewSavingsTargetService(
+		repository.NewPostgresSavingsTarget(tx),
+		repository.NewPostgresGoal(tx),
+		repository.NewPostgresExpense(tx),
+	)
+
+	deadline := time.Now().UTC().AddDate(1, 0, 0)
+
+	target, err := s.Create(ctx, service.CreateSavingsTargetDTO{Name: "Car", TargetAmount: 50_000, Deadline: deadline, GoalID: int(goal.ID)}, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(50_000_00), target.TargetAmount)
+	assert.Equal(t, 1, target.StartDate.Day())
+
+	_, err = s.Create(ctx, service.CreateSavingsTargetDTO{Name: "Car", TargetAmount: 1, Deadline: deadline, GoalID: int(anotherUserGoal.ID)}, user.ID)
+	assert.ErrorContains(t, err, "goal not found")
+
+	_, err = s.Create(ctx, service.CreateSavingsTargetDTO{Name: "Car", TargetAmount: 1, Deadline: deadline.AddDate(-2, 0, 0), GoalID: int(goal.ID)}, user.ID)
+	assert.EqualError(t, err, "deadline must not be before start date")
+}
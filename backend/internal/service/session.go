@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joaopsramos/fincon/internal/auth"
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/errs"
+)
+
+type SessionService struct {
+	sessionRepo domain.SessionRepo
+}
+
+// Tokens are the credentials of a session, the access token authenticates requests
+// and the refresh token issues new ones when it expires
+type Tokens struct {
+	AccessToken  string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+func NewSessionService(sessionRepo domain.SessionRepo) SessionService {
+	return SessionService{sessionRepo: sessionRepo}
+}
+
+func (s *SessionService) Create(ctx context.Context, userID uuid.UUID, userAgent string) (*Tokens, error) {
+	refreshToken := auth.GenerateRefreshToken()
+	now := time.Now().UTC()
+
+	session := domain.Session{
+		UserID:           userID,
+		RefreshTokenHash: auth.HashToken(refreshToken),
+		UserAgent:        userAgent,
+		ExpiresAt:        now.Add(auth.RefreshTokenExpiresIn),
+		LastUsedAt:       now,
+	}
+
+	if err := s.sessionRepo.Create(ctx, &session); err != nil {
+		return &Tokens{}, err
+	}
+
+	return newTokens(session, refreshToken), nil
+}
+
+// Refresh rotates the refresh token, issuing a new access token. Reusing an already
+// rotated refresh token revokes the whole session.
+func (s *SessionService) Refresh(ctx context.Context, refreshToken string) (*Tokens, error) {
+	hash := auth.HashToken(refreshToken)
+
+	session, err := s.sessionRepo.GetByRefreshTokenHash(ctx, hash)
+	if errors.Is(err, errs.ErrNotFound{}) {
+		if reused, err := s.sessionRepo.GetByPreviousTokenHash(ctx, hash); err == nil {
+			if err := s.sessionRepo.Revoke(ctx, reused.ID, reused.UserID); err != nil && !errors.Is(err, errs.ErrNotFound{}) {
+				return &Tokens{}, err
+			}
+		} else if !errors.Is(err, errs.ErrNotFound{}) {
+			return &Tokens{}, err
+		}
+
+		return &Tokens{}, errs.ErrInvalidToken
+	} else if err != nil {
+		return &Tokens{}, err
+	}
+
+	if !session.IsActive() {
+		return &Tokens{}, errs.ErrInvalidToken
+	}
+
+	newRefreshToken := auth.GenerateRefreshToken()
+
+	err = s.sessionRepo.Rotate(ctx, session, auth.HashToken(newRefreshToken), time.Now().UTC().Add(auth.RefreshTokenExpiresIn))
+	if err != nil {
+		return &Tokens{}, err
+	}
+
+	return newTokens(*session, newRefreshToken), nil
+}
+
+// IsActive reports whether the session exists and wasn't revoked nor expired
+func (s *SessionService) IsActive(ctx context.Context, id uuid.UUID, userID uuid.UUID) (bool, error) {
+	session, err := s.sessionRepo.Get(ctx, id)
+	if errors.Is(err, errs.ErrNotFound{}) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return session.UserID == userID && session.IsActive(), nil
+}
+
+func (s *SessionService) Revoke(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	return s.sessionRepo.Revoke(ctx, id, userID)
+}
+
+func (s *SessionService) RevokeAll(ctx context.Context, userID uuid.UUID) error {
+	return s.sessionRepo.RevokeAll(ctx, userID)
+}
+
+func newTokens(session domain.Session, refreshToken string) *Tokens {
+	return &Tokens{
+		AccessToken:  auth.GenerateJWTToken(session.UserID, session.ID, auth.AccessTokenExpiresIn),
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(auth.AccessTokenExpiresIn.Seconds()),
+	}
+}
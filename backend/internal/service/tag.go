@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/errs"
+	"github.com/joaopsramos/fincon/internal/util"
+)
+
+type TagService struct {
+	tagRepo domain.TagRepo
+}
+
+type TagReportMonth struct {
+	Month string  `json:"month"`
+	Total float64 `json:"total"`
+}
+
+type TagReport struct {
+	ID     uint             `json:"id"`
+	Name   string           `json:"name"`
+	Total  float64          `json:"total"`
+	Months []TagReportMonth `json:"months"`
+}
+
+func NewTagService(tagRepo domain.TagRepo) TagService {
+	return TagService{tagRepo: tagRepo}
+}
+
+func (s *TagService) All(ctx context.Context, userID uuid.UUID) ([]domain.Tag, error) {
+	return s.tagRepo.All(ctx, userID)
+}
+
+func (s *TagService) Create(ctx context.Context, name string, userID uuid.UUID) (*domain.Tag, error) {
+	if err := s.validateTagName(ctx, name, 0, userID); err != nil {
+		return &domain.Tag{}, err
+	}
+
+	tag := domain.Tag{Name: strings.TrimSpace(name), UserID: userID}
+	err := s.tagRepo.Create(ctx, &tag)
+
+	return &tag, err
+}
+
+func (s *TagService) Rename(ctx context.Context, id uint, name string, userID uuid.UUID) (*domain.Tag, error) {
+	tag, err := s.tagRepo.Get(ctx, id, userID)
+	if err != nil {
+		return &domain.Tag{}, err
+	}
+
+	if err := s.validateTagName(ctx, name, tag.ID, userID); err != nil {
+		return &domain.Tag{}, err
+	}
+
+	tag.Name = strings.TrimSpace(name)
+	err = s.tagRepo.Update(ctx, tag)
+
+	return tag, err
+}
+
+func (s *TagService) Delete(ctx context.Context, id uint, userID uuid.UUID) error {
+	return s.tagRepo.Delete(ctx, id, userID)
+}
+
+// Merge moves the expenses of the tag to the target one and deletes it, returning the target
+func (s *TagService) Merge(ctx context.Context, id uint, targetID uint, userID uuid.UUID) (*domain.Tag, error) {
+	if id == targetID {
+		return &domain.Tag{}, errs.NewValidationError("a tag cannot be merged into itself")
+	}
+
+	source, err := s.tagRepo.Get(ctx, id, userID)
+	if err != nil {
+		return &domain.Tag{}, err
+	}
+
+	target, err := s.tagRepo.Get(ctx, targetID, userID)
+	if err != nil {
+		return &domain.Tag{}, err
+	}
+
+	err = s.tagRepo.Merge(ctx, source, target)
+
+	return target, err
+}
+
+// Report returns how much was spent on each tag in every month from the month of "from"
+// up to the month of "to", both inclusive
+func (s *TagService) Report(ctx context.Context, from time.Time, to time.Time, userID uuid.UUID) ([]TagReport, error) {
+	from = time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to = time.Date(to.Year(), to.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	if to.Before(from) {
+		return nil, errs.NewValidationError("to must not be before from")
+	}
+
+	tags, err := s.tagRepo.All(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := s.tagRepo.MonthlyTotals(ctx, from, to.AddDate(0, 1, 0), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	totalsByTag := make(map[uint]map[string]int64)
+	for _, t := range totals {
+		if totalsByTag[t.TagID] == nil {
+			totalsByTag[t.TagID] = make(map[string]int64)
+		}
+
+		totalsByTag[t.TagID][t.Date.Format(util.ApiMonthLayout)] = t.Total
+	}
+
+	reports := make([]TagReport, len(tags))
+	for i, t := range tags {
+		report := TagReport{ID: t.ID, Name: t.Name, Months: []TagReportMonth{}}
+
+		var total int64
+		for month := from; !month.After(to); month = month.AddDate(0, 1, 0) {
+			key := month.Format(util.ApiMonthLayout)
+			monthTotal := totalsByTag[t.ID][key]
+
+			report.Months = append(report.Months, TagReportMonth{Month: key, Total: util.MoneyAmountToFloat(monthTotal)})
+			total += monthTotal
+		}
+
+		report.Total = util.MoneyAmountToFloat(total)
+		reports[i] = report
+	}
+
+	return reports, nil
+}
+
+// validateTagName ensures the user doesn't have another tag with the same name, ignoring case
+func (s *TagService) validateTagName(ctx context.Context, name string, ignoreID uint, userID uuid.UUID) error {
+	tags, err := s.tagRepo.All(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range tags {
+		if t.ID != ignoreID && strings.EqualFold(t.Name, strings.TrimSpace(name)) {
+			return errs.NewValidationError("a tag with this name already exists")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/joaopsramos/fincon/internal/auth"
+	"github.com/joaopsramos/fincon/internal/domain"
+	"github.com/joaopsramos/fincon/internal/errs"
+	"github.com/joaopsramos/fincon/internal/util"
+)
+
+const recoveryCodesCount = 10
+
+var totpCodeRegex = regexp.MustCompile(`^\s*\d{6}\s*$`)
+
+type TwoFactorService struct {
+	userRepo domain.UserRepo
+	now      func() time.Time
+}
+
+type TOTPEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+type TwoFactorStatus struct {
+	Enabled       bool  `json:"enabled"`
+	RecoveryCodes int64 `json:"recovery_codes_left"`
+}
+
+func NewTwoFactorService(userRepo domain.UserRepo) TwoFactorService {
+	return TwoFactorService{userRepo: userRepo, now: time.Now}
+}
+
+// SetClock overrides the clock TOTP codes are validated against
+func (s *TwoFactorService) SetClock(now func() time.Time) {
+	s.now = now
+}
+
+func (s *TwoFactorService) Status(ctx context.Context, userID uuid.UUID) (*TwoFactorStatus, error) {
+	user, err := s.userRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.TwoFactorEnabled() {
+		return &TwoFactorStatus{}, nil
+	}
+
+	count, err := s.userRepo.CountRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TwoFactorStatus{Enabled: true, RecoveryCodes: count}, nil
+}
+
+// Enroll generates a new secret for the user, it's only enforced after being confirmed with a code.
+// Enrolling again before confirming replaces the previous secret.
+func (s *TwoFactorService) Enroll(ctx context.Context, userID uuid.UUID) (*TOTPEnrollment, error) {
+	user, err := s.userRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TwoFactorEnabled() {
+		return nil, errs.NewValidationError("two-factor authentication is already enabled")
+	}
+
+	secret := auth.GenerateTOTPSecret()
+	if err := s.userRepo.SetTOTPSecret(ctx, userID, secret); err != nil {
+		return nil, err
+	}
+
+	return &TOTPEnrollment{Secret: secret, URI: auth.TOTPURI(secret, user.Email)}, nil
+}
+
+// Confirm enables two-factor authentication, returning the recovery codes. They are only
+// stored hashed, so this is the only time they can be shown to the user.
+func (s *TwoFactorService) Confirm(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	user, err := s.userRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.TwoFactorEnabled() {
+		return nil, errs.NewValidationError("two-factor authentication is already enabled")
+	}
+
+	if user.TOTPSecret == "" {
+		return nil, errs.NewValidationError("two-factor enrollment was not started")
+	}
+
+	step, ok := auth.ValidateTOTP(user.TOTPSecret, code, s.now())
+	if !ok {
+		return nil, errs.ErrInvalidCode
+	}
+
+	codes := auth.GenerateRecoveryCodes(recoveryCodesCount)
+	hashes := util.Map(codes, auth.HashToken)
+
+	if err := s.userRepo.EnableTOTP(ctx, userID, step, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func (s *TwoFactorService) Disable(ctx context.Context, userID uuid.UUID, code string) error {
+	user, err := s.userRepo.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.TwoFactorEnabled() {
+		return errs.NewValidationError("two-factor authentication is not enabled")
+	}
+
+	if err := s.Verify(ctx, user, code); err != nil {
+		return err
+	}
+
+	return s.userRepo.DisableTOTP(ctx, userID)
+}
+
+// VerifyChallenge completes a two-factor login, returning the user the challenge was issued to
+func (s *TwoFactorService) VerifyChallenge(ctx context.Context, challengeToken string, code string) (uuid.UUID, error) {
+	userID, err := auth.ParseChallengeToken(challengeToken)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	user, err := s.userRepo.Get(ctx, userID)
+	if err != nil {
+		return uuid.Nil, errs.ErrInvalidToken
+	}
+
+	if !user.TwoFactorEnabled() {
+		return uuid.Nil, errs.ErrInvalidToken
+	}
+
+	if err := s.Verify(ctx, user, code); err != nil {
+		return uuid.Nil, err
+	}
+
+	return user.ID, nil
+}
+
+// Verify accepts either a TOTP code or one of the unused recovery codes
+func (s *TwoFactorService) Verify(ctx context.Context, user *domain.User, code string) error {
+	if totpCodeRegex.MatchString(code) {
+		step, ok := auth.ValidateTOTP(user.TOTPSecret, code, s.now())
+		if !ok {
+			return errs.ErrInvalidCode
+		}
+
+		return s.userRepo.UseTOTPStep(ctx, user.ID, step)
+	}
+
+	return s.userRepo.UseRecoveryCode(ctx, user.ID, auth.HashToken(auth.NormalizeRecoveryCode(code)))
+}
@@ -17,8 +17,10 @@ import (
 )
 
 type UserService struct {
-	userRepo domain.UserRepo
-	mailer   mail.Mailer
+	userRepo    domain.UserRepo
+	sessionRepo domain.SessionRepo
+	transactor  domain.Transactor
+	mailer      mail.Mailer
 }
 
 type CreateUserDTO struct {
@@ -33,45 +35,85 @@ type ResetPasswordDTO struct {
 	Password string
 }
 
-func NewUserService(userRepo domain.UserRepo, mailer mail.Mailer) UserService {
-	return UserService{userRepo: userRepo, mailer: mailer}
+type ChangePasswordDTO struct {
+	CurrentPassword string
+	NewPassword     string
+	// SessionID is kept active, every other session of the user is revoked
+	SessionID uuid.UUID
 }
 
+func NewUserService(
+	userRepo domain.UserRepo,
+	sessionRepo domain.SessionRepo,
+	transactor domain.Transactor,
+	mailer mail.Mailer,
+) UserService {
+	return UserService{userRepo: userRepo, sessionRepo: sessionRepo, transactor: transactor, mailer: mailer}
+}
+
+// SendForgotPasswordEmail creates a reset token and emails it, the token is discarded when
+// the email can't be sent
 func (s *UserService) SendForgotPasswordEmail(ctx context.Context, user domain.User) error {
-	userToken := &domain.UserToken{
-		UserID:    user.ID,
-		ExpiresAt: time.Now().UTC().Add(24 * time.Hour),
-		Used:      false,
+	return s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		userToken, err := s.createToken(ctx, user.ID, domain.PasswordResetToken)
+		if err != nil {
+			return err
+		}
+
+		email := mail.Email{
+			To:       types.MailContact{Email: user.Email},
+			Subject:  mail.ForgotPasswordSubject,
+			Template: mail.ForgotPasswordTemplate,
+			Data:     util.M{"Link": fmt.Sprintf("%s/password/reset?token=%s", config.Get().WebURL, userToken.Token)},
+		}
+
+		return s.mailer.Send(ctx, email)
+	})
+}
+
+// SendVerificationEmail creates a verification token and emails it, the token is discarded
+// when the email can't be sent
+func (s *UserService) SendVerificationEmail(ctx context.Context, user domain.User) error {
+	if user.IsVerified() {
+		return errs.ErrAlreadyVerified
 	}
 
-	if err := s.userRepo.CreateToken(ctx, userToken); err != nil {
+	return s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		userToken, err := s.createToken(ctx, user.ID, domain.EmailVerificationToken)
+		if err != nil {
+			return err
+		}
+
+		email := mail.Email{
+			To:       types.MailContact{Email: user.Email},
+			Subject:  mail.VerifyEmailSubject,
+			Template: mail.VerifyEmailTemplate,
+			Data:     util.M{"Link": fmt.Sprintf("%s/email/verify?token=%s", config.Get().WebURL, userToken.Token)},
+		}
+
+		return s.mailer.Send(ctx, email)
+	})
+}
+
+func (s *UserService) VerifyEmail(ctx context.Context, token string) error {
+	userToken, err := s.useToken(ctx, token, domain.EmailVerificationToken)
+	if err != nil {
 		return err
 	}
 
-	email := mail.Email{
-		To:       types.MailContact{Email: user.Email},
-		Subject:  mail.ForgotPasswordSubject,
-		Template: mail.ForgotPasswordTemplate,
-		Data:     util.M{"Link": fmt.Sprintf("%s/password/reset?token=%s", config.Get().WebURL, userToken.Token)},
+	if err := s.userRepo.MarkAsVerified(ctx, userToken.UserID); err != nil {
+		return err
 	}
 
-	return s.mailer.Send(email)
+	return s.userRepo.MarkTokenAsUsed(ctx, userToken.ID)
 }
 
 func (s *UserService) ResetPassword(ctx context.Context, dto ResetPasswordDTO) error {
-	if err := uuid.Validate(dto.Token); err != nil {
-		return errs.ErrInvalidToken
-	}
-
-	token, err := s.userRepo.GetUserTokenByToken(ctx, dto.Token)
+	token, err := s.useToken(ctx, dto.Token, domain.PasswordResetToken)
 	if err != nil {
 		return err
 	}
 
-	if token.Used || time.Now().UTC().After(token.ExpiresAt) {
-		return errs.ErrInvalidToken
-	}
-
 	hashPassword, err := s.generatePassword([]byte(dto.Password))
 	if err != nil {
 		return err
@@ -81,7 +123,12 @@ func (s *UserService) ResetPassword(ctx context.Context, dto ResetPasswordDTO) e
 		return err
 	}
 
-	return s.userRepo.MarkTokenAsUsed(ctx, token.ID)
+	if err := s.userRepo.MarkTokenAsUsed(ctx, token.ID); err != nil {
+		return err
+	}
+
+	// Whoever had the old password must log in again
+	return s.sessionRepo.RevokeAll(ctx, token.UserID)
 }
 
 func (s *UserService) Create(ctx context.Context, dto CreateUserDTO) (*domain.User, *domain.Salary, error) {
@@ -104,6 +151,130 @@ func (s *UserService) Create(ctx context.Context, dto CreateUserDTO) (*domain.Us
 	return &user, &salary, nil
 }
 
+// Register creates the user and sends the verification email, neither happens without the other
+func (s *UserService) Register(ctx context.Context, dto CreateUserDTO) (*domain.User, *domain.Salary, error) {
+	var user *domain.User
+	var salary *domain.Salary
+
+	err := s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		var err error
+		if user, salary, err = s.Create(ctx, dto); err != nil {
+			return err
+		}
+
+		return s.SendVerificationEmail(ctx, *user)
+	})
+	if err != nil {
+		return &domain.User{}, &domain.Salary{}, err
+	}
+
+	return user, salary, nil
+}
+
+func (s *UserService) ChangePassword(ctx context.Context, userID uuid.UUID, dto ChangePasswordDTO) error {
+	user, err := s.userRepo.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !s.isSamePassword(*user, dto.CurrentPassword) {
+		return errs.ErrInvalidCredentials
+	}
+
+	hashPassword, err := s.generatePassword([]byte(dto.NewPassword))
+	if err != nil {
+		return err
+	}
+
+	if err := s.userRepo.UpdateUserPassword(ctx, userID, string(hashPassword)); err != nil {
+		return err
+	}
+
+	return s.sessionRepo.RevokeOthers(ctx, userID, dto.SessionID)
+}
+
+// ChangeEmail moves the account to a new address, which must be verified again. The
+// verification email is sent along with the change.
+func (s *UserService) ChangeEmail(ctx context.Context, userID uuid.UUID, email string, password string) (*domain.User, error) {
+	user, err := s.userRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.isSamePassword(*user, password) {
+		return nil, errs.ErrInvalidCredentials
+	}
+
+	err = s.transactor.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.UpdateEmail(ctx, userID, email); err != nil {
+			return err
+		}
+
+		user.Email = email
+		user.VerifiedAt = nil
+
+		return s.SendVerificationEmail(ctx, *user)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// Delete removes the account with all of its data, returning the deleted user
+func (s *UserService) Delete(ctx context.Context, userID uuid.UUID, password string) (*domain.User, error) {
+	user, err := s.userRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.isSamePassword(*user, password) {
+		return nil, errs.ErrInvalidCredentials
+	}
+
+	if err := s.userRepo.Delete(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// ForceDelete removes the account without asking for the password, it's meant for
+// administrators only
+func (s *UserService) ForceDelete(ctx context.Context, userID uuid.UUID) error {
+	return s.userRepo.Delete(ctx, userID)
+}
+
+// SetPassword replaces the password without the current one or a reset token, it's meant
+// for administrators only. Every session of the user is revoked.
+func (s *UserService) SetPassword(ctx context.Context, userID uuid.UUID, password string) error {
+	hashPassword, err := s.generatePassword([]byte(password))
+	if err != nil {
+		return err
+	}
+
+	if err := s.userRepo.UpdateUserPassword(ctx, userID, string(hashPassword)); err != nil {
+		return err
+	}
+
+	return s.sessionRepo.RevokeAll(ctx, userID)
+}
+
+func (s *UserService) MarkAsVerified(ctx context.Context, userID uuid.UUID) error {
+	return s.userRepo.MarkAsVerified(ctx, userID)
+}
+
+func (s *UserService) SendAccountDeletedEmail(ctx context.Context, user domain.User) error {
+	email := mail.Email{
+		To:       types.MailContact{Email: user.Email},
+		Subject:  mail.AccountDeletedSubject,
+		Template: mail.AccountDeletedTemplate,
+	}
+
+	return s.mailer.Send(ctx, email)
+}
+
 func (s *UserService) GetByEmailAndPassword(ctx context.Context, email string, password string) (*domain.User, error) {
 	user, err := s.GetByEmail(ctx, email)
 	if errors.Is(err, errs.ErrNotFound{}) {
@@ -119,10 +290,51 @@ func (s *UserService) GetByEmailAndPassword(ctx context.Context, email string, p
 	return user, nil
 }
 
+func (s *UserService) All(ctx context.Context) ([]domain.User, error) {
+	return s.userRepo.All(ctx)
+}
+
+func (s *UserService) Get(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	return s.userRepo.Get(ctx, id)
+}
+
 func (s *UserService) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
 	return s.userRepo.GetByEmail(ctx, email)
 }
 
+func (s *UserService) createToken(ctx context.Context, userID uuid.UUID, purpose domain.TokenPurpose) (*domain.UserToken, error) {
+	userToken := &domain.UserToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		ExpiresAt: time.Now().UTC().Add(24 * time.Hour),
+		Used:      false,
+	}
+
+	if err := s.userRepo.CreateToken(ctx, userToken); err != nil {
+		return nil, err
+	}
+
+	return userToken, nil
+}
+
+// useToken fetches a token that can still be used for the given purpose
+func (s *UserService) useToken(ctx context.Context, token string, purpose domain.TokenPurpose) (*domain.UserToken, error) {
+	if err := uuid.Validate(token); err != nil {
+		return nil, errs.ErrInvalidToken
+	}
+
+	userToken, err := s.userRepo.GetUserTokenByToken(ctx, token, purpose)
+	if err != nil {
+		return nil, err
+	}
+
+	if userToken.Used || time.Now().UTC().After(userToken.ExpiresAt) {
+		return nil, errs.ErrInvalidToken
+	}
+
+	return userToken, nil
+}
+
 func (s *UserService) isSamePassword(user domain.User, password string) bool {
 	err := bcrypt.CompareHashAndPassword([]byte(user.HashPassword), []byte(password))
 	return err == nil
@@ -36,7 +36,7 @@ func TestUserService_SendForgotPasswordEmail(t *testing.T) {
 		{
 			name: "create valid token",
 			setupMocks: func() {
-				mailer.EXPECT().Send(mock.AnythingOfType("mail.Email")).Return(nil).Once()
+				mailer.EXPECT().Send(mock.Anything, mock.AnythingOfType("mail.Email")).Return(nil).Once()
 			},
 			postAssert: func(t *testing.T, tx *gorm.DB, user domain.User) {
 				assert := assert.New(t)
@@ -51,7 +51,7 @@ func TestUserService_SendForgotPasswordEmail(t *testing.T) {
 		{
 			name: "send email with created token",
 			setupMocks: func() {
-				mailer.EXPECT().Send(mock.AnythingOfType("mail.Email")).Return(nil).Once()
+				mailer.EXPECT().Send(mock.Anything, mock.AnythingOfType("mail.Email")).Return(nil).Once()
 			},
 			postAssert: func(t *testing.T, tx *gorm.DB, user domain.User) {
 				assert := assert.New(t)
@@ -60,7 +60,7 @@ func TestUserService_SendForgotPasswordEmail(t *testing.T) {
 				err := tx.First(&token, domain.UserToken{UserID: user.ID}).Error
 				assert.NoError(err)
 
-				mailer.AssertCalled(t, "Send", mock.MatchedBy(func(e mail.Email) bool {
+				mailer.AssertCalled(t, "Send", mock.Anything, mock.MatchedBy(func(e mail.Email) bool {
 					link := fmt.Sprintf("%s/password/reset?token=%s", config.Get().WebURL, token.Token)
 
 					return e.To.Email == user.Email &&
@@ -86,7 +86,7 @@ func TestUserService_SendForgotPasswordEmail(t *testing.T) {
 		{
 			name: "return error if email sending fails",
 			setupMocks: func() {
-				mailer.EXPECT().Send(mock.AnythingOfType("mail.Email")).Return(errors.New("error")).Once()
+				mailer.EXPECT().Send(mock.Anything, mock.AnythingOfType("mail.Email")).Return(errors.New("error")).Once()
 			},
 			wantErr: true,
 		},
@@ -108,7 +108,7 @@ func TestUserService_SendForgotPasswordEmail(t *testing.T) {
 				tt.repo = repository.NewPostgresUser(tx)
 			}
 
-			s := service.NewUserService(tt.repo, mailer)
+			s := service.NewUserService(tt.repo, repository.NewPostgresSession(tx), repository.NewPostgresTransactor(tx), mailer)
 			err := s.SendForgotPasswordEmail(context.Background(), user)
 
 			if tt.wantErr {
@@ -185,10 +185,12 @@ func TestUserService_ResetPassword(t *testing.T) {
 			assert := assert.New(t)
 			user := factory.InsertUser()
 
+			session := factory.InsertSession(&domain.Session{UserID: user.ID})
+
 			tokenID, token := tt.setupToken(user)
 			newPassword := "new-password"
 
-			s := service.NewUserService(repo, nil)
+			s := service.NewUserService(repo, repository.NewPostgresSession(tx), repository.NewPostgresTransactor(tx), nil)
 			err := s.ResetPassword(context.Background(), service.ResetPasswordDTO{
 				Token:    token,
 				Password: newPassword,
@@ -210,6 +212,70 @@ func TestUserService_ResetPassword(t *testing.T) {
 			var userToken domain.UserToken
 			tx.First(&userToken, tokenID)
 			assert.True(userToken.Used)
+
+			tx.First(&session, session.ID)
+			assert.NotNil(session.RevokedAt)
 		})
 	}
 }
+
+func TestUserService_SetPassword(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	factory := testhelper.NewFactory(tx)
+
+	user := factory.InsertUser()
+	session := factory.InsertSession(&domain.Session{UserID: user.ID})
+
+	s := service.NewUserService(repository.NewPostgresUser(tx), repository.NewPostgresSession(tx), repository.NewPostgresTransactor(tx), nil)
+	assert.NoError(s.SetPassword(context.Background(), user.ID, "new-password"))
+
+	var updatedUser domain.User
+	tx.First(&updatedUser, user.ID)
+	assert.NoError(bcrypt.CompareHashAndPassword([]byte(updatedUser.HashPassword), []byte("new-password")))
+
+	tx.First(&session, session.ID)
+	assert.NotNil(session.RevokedAt)
+}
+
+func TestUserService_ForceDelete(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	tx := testhelper.NewTestPostgresTx(t)
+	factory := testhelper.NewFactory(tx)
+
+	user := factory.InsertUser()
+	factory.InsertGoal(&domain.Goal{UserID: user.ID})
+
+	s := service.NewUserService(repository.NewPostgresUser(tx), repository.NewPostgresSession(tx), repository.NewPostgresTransactor(tx), nil)
+	assert.NoError(s.ForceDelete(context.Background(), user.ID))
+
+	var count int64
+	tx.Model(&domain.User{}).Where("id = ?", user.ID).Count(&count)
+	assert.Zero(count)
+
+	assert.ErrorIs(s.ForceDelete(context.Background(), user.ID), errs.NewNotFound("user"))
+}
+
+func TestUserService_VerifyEmail(t *testing.T) {
+	t.Parallel()
+	tx := testhelper.NewTestPostgresTx(t)
+	repo := repository.NewPostgresUser(tx)
+	factory := testhelper.NewFactory(tx)
+	s := service.NewUserService(repo, repository.NewPostgresSession(tx), repository.NewPostgresTransactor(tx), nil)
+
+	a := assert.New(t)
+	user := factory.InsertUser()
+	token := factory.InsertUserToken(&domain.UserToken{UserID: user.ID, Purpose: domain.EmailVerificationToken})
+
+	a.NoError(s.VerifyEmail(context.Background(), token.Token.String()))
+
+	got, err := repo.Get(context.Background(), user.ID)
+	a.NoError(err)
+	a.True(got.IsVerified())
+
+	// tokens can only be used once
+	a.ErrorIs(s.VerifyEmail(context.Background(), token.Token.String()), errs.ErrInvalidToken)
+	a.ErrorIs(s.SendVerificationEmail(context.Background(), *got), errs.ErrAlreadyVerified)
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"io"
 	"log/slog"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -14,6 +15,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/joaopsramos/fincon/internal/api"
 	"github.com/joaopsramos/fincon/internal/auth"
+	"github.com/joaopsramos/fincon/internal/config"
+	"github.com/joaopsramos/fincon/internal/domain"
 	"github.com/joaopsramos/fincon/internal/mail"
 	"gorm.io/gorm"
 )
@@ -22,13 +25,16 @@ type TestApp struct {
 	*api.App
 	token  string
 	Mailer mail.Mailer
+	// Headers are sent along with every request
+	Headers http.Header
 }
 
 type TestAppOpts struct {
-	UserID       uuid.UUID
-	Logger       *slog.Logger
-	Mailer       mail.Mailer
-	WithoutSetup bool
+	UserID             uuid.UUID
+	Logger             *slog.Logger
+	Mailer             mail.Mailer
+	VerificationPolicy config.VerificationPolicy
+	WithoutSetup       bool
 }
 
 func NewTestApp(t testing.TB, tx *gorm.DB, options ...TestAppOpts) *TestApp {
@@ -42,6 +48,9 @@ func NewTestApp(t testing.TB, tx *gorm.DB, options ...TestAppOpts) *TestApp {
 	}
 
 	app := api.NewApp(tx, opts.Logger, opts.Mailer)
+	if opts.VerificationPolicy != "" {
+		app.SetVerificationPolicy(opts.VerificationPolicy)
+	}
 
 	if !opts.WithoutSetup {
 		app.SetupAll()
@@ -49,13 +58,15 @@ func NewTestApp(t testing.TB, tx *gorm.DB, options ...TestAppOpts) *TestApp {
 
 	var token string
 	if opts.UserID != uuid.Nil {
-		token = auth.GenerateJWTToken(opts.UserID, time.Minute)
+		session := NewFactory(tx).InsertSession(&domain.Session{UserID: opts.UserID})
+		token = auth.GenerateJWTToken(opts.UserID, session.ID, time.Minute)
 	}
 
 	return &TestApp{
-		App:    app,
-		token:  token,
-		Mailer: opts.Mailer,
+		App:     app,
+		token:   token,
+		Mailer:  opts.Mailer,
+		Headers: make(http.Header),
 	}
 }
 
@@ -70,10 +81,35 @@ func (t *TestApp) Test(method string, path string, body ...any) *http.Response {
 	req := httptest.NewRequest(method, path, bodyReader)
 	req.Header.Set("Content-Type", "application/json")
 
-	if t.token != "" {
-		req.Header.Set("Authorization", "Bearer "+t.token)
+	t.setHeaders(req)
+
+	w := httptest.NewRecorder()
+	t.Router.ServeHTTP(w, req)
+
+	return w.Result()
+}
+
+// TestMultipart sends a multipart form with the given fields and a single file
+func (t *TestApp) TestMultipart(path string, fields map[string]string, fileName string, file []byte) *http.Response {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	for k, v := range fields {
+		_ = writer.WriteField(k, v)
 	}
 
+	if fileName != "" {
+		part, _ := writer.CreateFormFile("file", fileName)
+		_, _ = part.Write(file)
+	}
+
+	_ = writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, path, &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	t.setHeaders(req)
+
 	w := httptest.NewRecorder()
 	t.Router.ServeHTTP(w, req)
 
@@ -86,3 +122,13 @@ func (t *TestApp) UnmarshalBody(body io.ReadCloser, dst any) {
 		panic(err)
 	}
 }
+
+func (t *TestApp) setHeaders(req *http.Request) {
+	for k, v := range t.Headers {
+		req.Header[k] = v
+	}
+
+	if t.token != "" {
+		req.Header.Set("Authorization", "Bearer "+t.token)
+	}
+}
@@ -13,6 +13,241 @@ import (
 	mock "github.com/stretchr/testify/mock"
 )
 
+// NewMockDigestRepo creates a new instance of MockDigestRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockDigestRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockDigestRepo {
+	mock := &MockDigestRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockDigestRepo is an autogenerated mock type for the DigestRepo type
+type MockDigestRepo struct {
+	mock.Mock
+}
+
+type MockDigestRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockDigestRepo) EXPECT() *MockDigestRepo_Expecter {
+	return &MockDigestRepo_Expecter{mock: &_m.Mock}
+}
+
+// AllDue provides a mock function for the type MockDigestRepo
+func (_mock *MockDigestRepo) AllDue(ctx context.Context, frequency domain.DigestFrequency, periodStart time.Time) ([]domain.DigestPreference, error) {
+	ret := _mock.Called(ctx, frequency, periodStart)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AllDue")
+	}
+
+	var r0 []domain.DigestPreference
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.DigestFrequency, time.Time) ([]domain.DigestPreference, error)); ok {
+		return returnFunc(ctx, frequency, periodStart)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.DigestFrequency, time.Time) []domain.DigestPreference); ok {
+		r0 = returnFunc(ctx, frequency, periodStart)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.DigestPreference)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.DigestFrequency, time.Time) error); ok {
+		r1 = returnFunc(ctx, frequency, periodStart)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDigestRepo_AllDue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AllDue'
+type MockDigestRepo_AllDue_Call struct {
+	*mock.Call
+}
+
+// AllDue is a helper method to define mock.On call
+//   - ctx
+//   - frequency
+//   - periodStart
+func (_e *MockDigestRepo_Expecter) AllDue(ctx interface{}, frequency interface{}, periodStart interface{}) *MockDigestRepo_AllDue_Call {
+	return &MockDigestRepo_AllDue_Call{Call: _e.mock.On("AllDue", ctx, frequency, periodStart)}
+}
+
+func (_c *MockDigestRepo_AllDue_Call) Run(run func(ctx context.Context, frequency domain.DigestFrequency, periodStart time.Time)) *MockDigestRepo_AllDue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.DigestFrequency), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockDigestRepo_AllDue_Call) Return(digestPreferences []domain.DigestPreference, err error) *MockDigestRepo_AllDue_Call {
+	_c.Call.Return(digestPreferences, err)
+	return _c
+}
+
+func (_c *MockDigestRepo_AllDue_Call) RunAndReturn(run func(ctx context.Context, frequency domain.DigestFrequency, periodStart time.Time) ([]domain.DigestPreference, error)) *MockDigestRepo_AllDue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockDigestRepo
+func (_mock *MockDigestRepo) Get(ctx context.Context, userID uuid.UUID) (*domain.DigestPreference, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domain.DigestPreference
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.DigestPreference, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.DigestPreference); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.DigestPreference)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockDigestRepo_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockDigestRepo_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockDigestRepo_Expecter) Get(ctx interface{}, userID interface{}) *MockDigestRepo_Get_Call {
+	return &MockDigestRepo_Get_Call{Call: _e.mock.On("Get", ctx, userID)}
+}
+
+func (_c *MockDigestRepo_Get_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockDigestRepo_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockDigestRepo_Get_Call) Return(digestPreference *domain.DigestPreference, err error) *MockDigestRepo_Get_Call {
+	_c.Call.Return(digestPreference, err)
+	return _c
+}
+
+func (_c *MockDigestRepo_Get_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) (*domain.DigestPreference, error)) *MockDigestRepo_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkSent provides a mock function for the type MockDigestRepo
+func (_mock *MockDigestRepo) MarkSent(ctx context.Context, userID uuid.UUID, periodStart time.Time) error {
+	ret := _mock.Called(ctx, userID, periodStart)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkSent")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r0 = returnFunc(ctx, userID, periodStart)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDigestRepo_MarkSent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkSent'
+type MockDigestRepo_MarkSent_Call struct {
+	*mock.Call
+}
+
+// MarkSent is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - periodStart
+func (_e *MockDigestRepo_Expecter) MarkSent(ctx interface{}, userID interface{}, periodStart interface{}) *MockDigestRepo_MarkSent_Call {
+	return &MockDigestRepo_MarkSent_Call{Call: _e.mock.On("MarkSent", ctx, userID, periodStart)}
+}
+
+func (_c *MockDigestRepo_MarkSent_Call) Run(run func(ctx context.Context, userID uuid.UUID, periodStart time.Time)) *MockDigestRepo_MarkSent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockDigestRepo_MarkSent_Call) Return(err error) *MockDigestRepo_MarkSent_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDigestRepo_MarkSent_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, periodStart time.Time) error) *MockDigestRepo_MarkSent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Save provides a mock function for the type MockDigestRepo
+func (_mock *MockDigestRepo) Save(ctx context.Context, p *domain.DigestPreference) error {
+	ret := _mock.Called(ctx, p)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.DigestPreference) error); ok {
+		r0 = returnFunc(ctx, p)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockDigestRepo_Save_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Save'
+type MockDigestRepo_Save_Call struct {
+	*mock.Call
+}
+
+// Save is a helper method to define mock.On call
+//   - ctx
+//   - p
+func (_e *MockDigestRepo_Expecter) Save(ctx interface{}, p interface{}) *MockDigestRepo_Save_Call {
+	return &MockDigestRepo_Save_Call{Call: _e.mock.On("Save", ctx, p)}
+}
+
+func (_c *MockDigestRepo_Save_Call) Run(run func(ctx context.Context, p *domain.DigestPreference)) *MockDigestRepo_Save_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.DigestPreference))
+	})
+	return _c
+}
+
+func (_c *MockDigestRepo_Save_Call) Return(err error) *MockDigestRepo_Save_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockDigestRepo_Save_Call) RunAndReturn(run func(ctx context.Context, p *domain.DigestPreference) error) *MockDigestRepo_Save_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockExpenseRepo creates a new instance of MockExpenseRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockExpenseRepo(t interface {
@@ -40,6 +275,65 @@ func (_m *MockExpenseRepo) EXPECT() *MockExpenseRepo_Expecter {
 	return &MockExpenseRepo_Expecter{mock: &_m.Mock}
 }
 
+// AllBetween provides a mock function for the type MockExpenseRepo
+func (_mock *MockExpenseRepo) AllBetween(ctx context.Context, from time.Time, to time.Time, userID uuid.UUID) ([]domain.Expense, error) {
+	ret := _mock.Called(ctx, from, to, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AllBetween")
+	}
+
+	var r0 []domain.Expense
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, uuid.UUID) ([]domain.Expense, error)); ok {
+		return returnFunc(ctx, from, to, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, uuid.UUID) []domain.Expense); ok {
+		r0 = returnFunc(ctx, from, to, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Expense)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, from, to, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockExpenseRepo_AllBetween_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AllBetween'
+type MockExpenseRepo_AllBetween_Call struct {
+	*mock.Call
+}
+
+// AllBetween is a helper method to define mock.On call
+//   - ctx
+//   - from
+//   - to
+//   - userID
+func (_e *MockExpenseRepo_Expecter) AllBetween(ctx interface{}, from interface{}, to interface{}, userID interface{}) *MockExpenseRepo_AllBetween_Call {
+	return &MockExpenseRepo_AllBetween_Call{Call: _e.mock.On("AllBetween", ctx, from, to, userID)}
+}
+
+func (_c *MockExpenseRepo_AllBetween_Call) Run(run func(ctx context.Context, from time.Time, to time.Time, userID uuid.UUID)) *MockExpenseRepo_AllBetween_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time), args[3].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockExpenseRepo_AllBetween_Call) Return(expenses []domain.Expense, err error) *MockExpenseRepo_AllBetween_Call {
+	_c.Call.Return(expenses, err)
+	return _c
+}
+
+func (_c *MockExpenseRepo_AllBetween_Call) RunAndReturn(run func(ctx context.Context, from time.Time, to time.Time, userID uuid.UUID) ([]domain.Expense, error)) *MockExpenseRepo_AllBetween_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // AllByGoalID provides a mock function for the type MockExpenseRepo
 func (_mock *MockExpenseRepo) AllByGoalID(ctx context.Context, goalID uint, year int, month time.Month, userID uuid.UUID) ([]domain.Expense, error) {
 	ret := _mock.Called(ctx, goalID, year, month, userID)
@@ -100,6 +394,54 @@ func (_c *MockExpenseRepo_AllByGoalID_Call) RunAndReturn(run func(ctx context.Co
 	return _c
 }
 
+// AllInBatches provides a mock function for the type MockExpenseRepo
+func (_mock *MockExpenseRepo) AllInBatches(ctx context.Context, userID uuid.UUID, size int, fn func([]domain.Expense) error) error {
+	ret := _mock.Called(ctx, userID, size, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AllInBatches")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, func([]domain.Expense) error) error); ok {
+		r0 = returnFunc(ctx, userID, size, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockExpenseRepo_AllInBatches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AllInBatches'
+type MockExpenseRepo_AllInBatches_Call struct {
+	*mock.Call
+}
+
+// AllInBatches is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - size
+//   - fn
+func (_e *MockExpenseRepo_Expecter) AllInBatches(ctx interface{}, userID interface{}, size interface{}, fn interface{}) *MockExpenseRepo_AllInBatches_Call {
+	return &MockExpenseRepo_AllInBatches_Call{Call: _e.mock.On("AllInBatches", ctx, userID, size, fn)}
+}
+
+func (_c *MockExpenseRepo_AllInBatches_Call) Run(run func(ctx context.Context, userID uuid.UUID, size int, fn func([]domain.Expense) error)) *MockExpenseRepo_AllInBatches_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int), args[3].(func([]domain.Expense) error))
+	})
+	return _c
+}
+
+func (_c *MockExpenseRepo_AllInBatches_Call) Return(err error) *MockExpenseRepo_AllInBatches_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockExpenseRepo_AllInBatches_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, size int, fn func([]domain.Expense) error) error) *MockExpenseRepo_AllInBatches_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function for the type MockExpenseRepo
 func (_mock *MockExpenseRepo) Create(ctx context.Context, e *domain.Expense) error {
 	ret := _mock.Called(ctx, e)
@@ -413,68 +755,231 @@ func (_c *MockExpenseRepo_GetMonthlyGoalSpendings_Call) RunAndReturn(run func(ct
 	return _c
 }
 
-// Update provides a mock function for the type MockExpenseRepo
-func (_mock *MockExpenseRepo) Update(ctx context.Context, e *domain.Expense) error {
-	ret := _mock.Called(ctx, e)
+// ReplaceTags provides a mock function for the type MockExpenseRepo
+func (_mock *MockExpenseRepo) ReplaceTags(ctx context.Context, e *domain.Expense, tags []domain.Tag) error {
+	ret := _mock.Called(ctx, e, tags)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Update")
+		panic("no return value specified for ReplaceTags")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Expense) error); ok {
-		r0 = returnFunc(ctx, e)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Expense, []domain.Tag) error); ok {
+		r0 = returnFunc(ctx, e, tags)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockExpenseRepo_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
-type MockExpenseRepo_Update_Call struct {
+// MockExpenseRepo_ReplaceTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceTags'
+type MockExpenseRepo_ReplaceTags_Call struct {
 	*mock.Call
 }
 
-// Update is a helper method to define mock.On call
+// ReplaceTags is a helper method to define mock.On call
 //   - ctx
 //   - e
-func (_e *MockExpenseRepo_Expecter) Update(ctx interface{}, e interface{}) *MockExpenseRepo_Update_Call {
-	return &MockExpenseRepo_Update_Call{Call: _e.mock.On("Update", ctx, e)}
+//   - tags
+func (_e *MockExpenseRepo_Expecter) ReplaceTags(ctx interface{}, e interface{}, tags interface{}) *MockExpenseRepo_ReplaceTags_Call {
+	return &MockExpenseRepo_ReplaceTags_Call{Call: _e.mock.On("ReplaceTags", ctx, e, tags)}
 }
 
-func (_c *MockExpenseRepo_Update_Call) Run(run func(ctx context.Context, e *domain.Expense)) *MockExpenseRepo_Update_Call {
+func (_c *MockExpenseRepo_ReplaceTags_Call) Run(run func(ctx context.Context, e *domain.Expense, tags []domain.Tag)) *MockExpenseRepo_ReplaceTags_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*domain.Expense))
+		run(args[0].(context.Context), args[1].(*domain.Expense), args[2].([]domain.Tag))
 	})
 	return _c
 }
 
-func (_c *MockExpenseRepo_Update_Call) Return(err error) *MockExpenseRepo_Update_Call {
+func (_c *MockExpenseRepo_ReplaceTags_Call) Return(err error) *MockExpenseRepo_ReplaceTags_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockExpenseRepo_Update_Call) RunAndReturn(run func(ctx context.Context, e *domain.Expense) error) *MockExpenseRepo_Update_Call {
+func (_c *MockExpenseRepo_ReplaceTags_Call) RunAndReturn(run func(ctx context.Context, e *domain.Expense, tags []domain.Tag) error) *MockExpenseRepo_ReplaceTags_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockGoalRepo creates a new instance of MockGoalRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockGoalRepo(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockGoalRepo {
-	mock := &MockGoalRepo{}
-	mock.Mock.Test(t)
+// Search provides a mock function for the type MockExpenseRepo
+func (_mock *MockExpenseRepo) Search(ctx context.Context, filter domain.ExpenseFilter, userID uuid.UUID) ([]domain.Expense, error) {
+	ret := _mock.Called(ctx, filter, userID)
 
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
 
-// MockGoalRepo is an autogenerated mock type for the GoalRepo type
-type MockGoalRepo struct {
+	var r0 []domain.Expense
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ExpenseFilter, uuid.UUID) ([]domain.Expense, error)); ok {
+		return returnFunc(ctx, filter, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, domain.ExpenseFilter, uuid.UUID) []domain.Expense); ok {
+		r0 = returnFunc(ctx, filter, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Expense)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, domain.ExpenseFilter, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, filter, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockExpenseRepo_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type MockExpenseRepo_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx
+//   - filter
+//   - userID
+func (_e *MockExpenseRepo_Expecter) Search(ctx interface{}, filter interface{}, userID interface{}) *MockExpenseRepo_Search_Call {
+	return &MockExpenseRepo_Search_Call{Call: _e.mock.On("Search", ctx, filter, userID)}
+}
+
+func (_c *MockExpenseRepo_Search_Call) Run(run func(ctx context.Context, filter domain.ExpenseFilter, userID uuid.UUID)) *MockExpenseRepo_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.ExpenseFilter), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockExpenseRepo_Search_Call) Return(expenses []domain.Expense, err error) *MockExpenseRepo_Search_Call {
+	_c.Call.Return(expenses, err)
+	return _c
+}
+
+func (_c *MockExpenseRepo_Search_Call) RunAndReturn(run func(ctx context.Context, filter domain.ExpenseFilter, userID uuid.UUID) ([]domain.Expense, error)) *MockExpenseRepo_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SumByGoalBetween provides a mock function for the type MockExpenseRepo
+func (_mock *MockExpenseRepo) SumByGoalBetween(ctx context.Context, goalID uint, from time.Time, to time.Time, userID uuid.UUID) (int64, error) {
+	ret := _mock.Called(ctx, goalID, from, to, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SumByGoalBetween")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint, time.Time, time.Time, uuid.UUID) (int64, error)); ok {
+		return returnFunc(ctx, goalID, from, to, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint, time.Time, time.Time, uuid.UUID) int64); ok {
+		r0 = returnFunc(ctx, goalID, from, to, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint, time.Time, time.Time, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, goalID, from, to, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockExpenseRepo_SumByGoalBetween_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SumByGoalBetween'
+type MockExpenseRepo_SumByGoalBetween_Call struct {
+	*mock.Call
+}
+
+// SumByGoalBetween is a helper method to define mock.On call
+//   - ctx
+//   - goalID
+//   - from
+//   - to
+//   - userID
+func (_e *MockExpenseRepo_Expecter) SumByGoalBetween(ctx interface{}, goalID interface{}, from interface{}, to interface{}, userID interface{}) *MockExpenseRepo_SumByGoalBetween_Call {
+	return &MockExpenseRepo_SumByGoalBetween_Call{Call: _e.mock.On("SumByGoalBetween", ctx, goalID, from, to, userID)}
+}
+
+func (_c *MockExpenseRepo_SumByGoalBetween_Call) Run(run func(ctx context.Context, goalID uint, from time.Time, to time.Time, userID uuid.UUID)) *MockExpenseRepo_SumByGoalBetween_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(time.Time), args[3].(time.Time), args[4].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockExpenseRepo_SumByGoalBetween_Call) Return(n int64, err error) *MockExpenseRepo_SumByGoalBetween_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockExpenseRepo_SumByGoalBetween_Call) RunAndReturn(run func(ctx context.Context, goalID uint, from time.Time, to time.Time, userID uuid.UUID) (int64, error)) *MockExpenseRepo_SumByGoalBetween_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockExpenseRepo
+func (_mock *MockExpenseRepo) Update(ctx context.Context, e *domain.Expense) error {
+	ret := _mock.Called(ctx, e)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Expense) error); ok {
+		r0 = returnFunc(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockExpenseRepo_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockExpenseRepo_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx
+//   - e
+func (_e *MockExpenseRepo_Expecter) Update(ctx interface{}, e interface{}) *MockExpenseRepo_Update_Call {
+	return &MockExpenseRepo_Update_Call{Call: _e.mock.On("Update", ctx, e)}
+}
+
+func (_c *MockExpenseRepo_Update_Call) Run(run func(ctx context.Context, e *domain.Expense)) *MockExpenseRepo_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Expense))
+	})
+	return _c
+}
+
+func (_c *MockExpenseRepo_Update_Call) Return(err error) *MockExpenseRepo_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockExpenseRepo_Update_Call) RunAndReturn(run func(ctx context.Context, e *domain.Expense) error) *MockExpenseRepo_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockGoalRepo creates a new instance of MockGoalRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockGoalRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockGoalRepo {
+	mock := &MockGoalRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockGoalRepo is an autogenerated mock type for the GoalRepo type
+type MockGoalRepo struct {
 	mock.Mock
 }
 
@@ -534,6 +1039,111 @@ func (_c *MockGoalRepo_All_Call) RunAndReturn(run func(ctx context.Context, user
 	return _c
 }
 
+// AllAllocations provides a mock function for the type MockGoalRepo
+func (_mock *MockGoalRepo) AllAllocations(ctx context.Context, userID uuid.UUID) ([]domain.GoalAllocation, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AllAllocations")
+	}
+
+	var r0 []domain.GoalAllocation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]domain.GoalAllocation, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []domain.GoalAllocation); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.GoalAllocation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockGoalRepo_AllAllocations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AllAllocations'
+type MockGoalRepo_AllAllocations_Call struct {
+	*mock.Call
+}
+
+// AllAllocations is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockGoalRepo_Expecter) AllAllocations(ctx interface{}, userID interface{}) *MockGoalRepo_AllAllocations_Call {
+	return &MockGoalRepo_AllAllocations_Call{Call: _e.mock.On("AllAllocations", ctx, userID)}
+}
+
+func (_c *MockGoalRepo_AllAllocations_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockGoalRepo_AllAllocations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockGoalRepo_AllAllocations_Call) Return(goalAllocations []domain.GoalAllocation, err error) *MockGoalRepo_AllAllocations_Call {
+	_c.Call.Return(goalAllocations, err)
+	return _c
+}
+
+func (_c *MockGoalRepo_AllAllocations_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) ([]domain.GoalAllocation, error)) *MockGoalRepo_AllAllocations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AllWithArchived provides a mock function for the type MockGoalRepo
+func (_mock *MockGoalRepo) AllWithArchived(ctx context.Context, userID uuid.UUID) []domain.Goal {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AllWithArchived")
+	}
+
+	var r0 []domain.Goal
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []domain.Goal); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Goal)
+		}
+	}
+	return r0
+}
+
+// MockGoalRepo_AllWithArchived_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AllWithArchived'
+type MockGoalRepo_AllWithArchived_Call struct {
+	*mock.Call
+}
+
+// AllWithArchived is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockGoalRepo_Expecter) AllWithArchived(ctx interface{}, userID interface{}) *MockGoalRepo_AllWithArchived_Call {
+	return &MockGoalRepo_AllWithArchived_Call{Call: _e.mock.On("AllWithArchived", ctx, userID)}
+}
+
+func (_c *MockGoalRepo_AllWithArchived_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockGoalRepo_AllWithArchived_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockGoalRepo_AllWithArchived_Call) Return(goals []domain.Goal) *MockGoalRepo_AllWithArchived_Call {
+	_c.Call.Return(goals)
+	return _c
+}
+
+func (_c *MockGoalRepo_AllWithArchived_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) []domain.Goal) *MockGoalRepo_AllWithArchived_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function for the type MockGoalRepo
 func (_mock *MockGoalRepo) Create(ctx context.Context, goals ...domain.Goal) error {
 	var tmpRet mock.Arguments
@@ -588,6 +1198,53 @@ func (_c *MockGoalRepo_Create_Call) RunAndReturn(run func(ctx context.Context, g
 	return _c
 }
 
+// Delete provides a mock function for the type MockGoalRepo
+func (_mock *MockGoalRepo) Delete(ctx context.Context, goal *domain.Goal, reassignTo *domain.Goal) error {
+	ret := _mock.Called(ctx, goal, reassignTo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Goal, *domain.Goal) error); ok {
+		r0 = returnFunc(ctx, goal, reassignTo)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockGoalRepo_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockGoalRepo_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx
+//   - goal
+//   - reassignTo
+func (_e *MockGoalRepo_Expecter) Delete(ctx interface{}, goal interface{}, reassignTo interface{}) *MockGoalRepo_Delete_Call {
+	return &MockGoalRepo_Delete_Call{Call: _e.mock.On("Delete", ctx, goal, reassignTo)}
+}
+
+func (_c *MockGoalRepo_Delete_Call) Run(run func(ctx context.Context, goal *domain.Goal, reassignTo *domain.Goal)) *MockGoalRepo_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Goal), args[2].(*domain.Goal))
+	})
+	return _c
+}
+
+func (_c *MockGoalRepo_Delete_Call) Return(err error) *MockGoalRepo_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockGoalRepo_Delete_Call) RunAndReturn(run func(ctx context.Context, goal *domain.Goal, reassignTo *domain.Goal) error) *MockGoalRepo_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Get provides a mock function for the type MockGoalRepo
 func (_mock *MockGoalRepo) Get(ctx context.Context, id uint, userID uuid.UUID) (*domain.Goal, error) {
 	ret := _mock.Called(ctx, id, userID)
@@ -646,59 +1303,106 @@ func (_c *MockGoalRepo_Get_Call) RunAndReturn(run func(ctx context.Context, id u
 	return _c
 }
 
-// UpdateAll provides a mock function for the type MockGoalRepo
-func (_mock *MockGoalRepo) UpdateAll(ctx context.Context, goals []domain.Goal) error {
-	ret := _mock.Called(ctx, goals)
+// Update provides a mock function for the type MockGoalRepo
+func (_mock *MockGoalRepo) Update(ctx context.Context, goal *domain.Goal) error {
+	ret := _mock.Called(ctx, goal)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateAll")
+		panic("no return value specified for Update")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []domain.Goal) error); ok {
-		r0 = returnFunc(ctx, goals)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Goal) error); ok {
+		r0 = returnFunc(ctx, goal)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockGoalRepo_UpdateAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAll'
-type MockGoalRepo_UpdateAll_Call struct {
+// MockGoalRepo_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockGoalRepo_Update_Call struct {
 	*mock.Call
 }
 
-// UpdateAll is a helper method to define mock.On call
+// Update is a helper method to define mock.On call
 //   - ctx
-//   - goals
-func (_e *MockGoalRepo_Expecter) UpdateAll(ctx interface{}, goals interface{}) *MockGoalRepo_UpdateAll_Call {
-	return &MockGoalRepo_UpdateAll_Call{Call: _e.mock.On("UpdateAll", ctx, goals)}
+//   - goal
+func (_e *MockGoalRepo_Expecter) Update(ctx interface{}, goal interface{}) *MockGoalRepo_Update_Call {
+	return &MockGoalRepo_Update_Call{Call: _e.mock.On("Update", ctx, goal)}
 }
 
-func (_c *MockGoalRepo_UpdateAll_Call) Run(run func(ctx context.Context, goals []domain.Goal)) *MockGoalRepo_UpdateAll_Call {
+func (_c *MockGoalRepo_Update_Call) Run(run func(ctx context.Context, goal *domain.Goal)) *MockGoalRepo_Update_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].([]domain.Goal))
+		run(args[0].(context.Context), args[1].(*domain.Goal))
 	})
 	return _c
 }
 
-func (_c *MockGoalRepo_UpdateAll_Call) Return(err error) *MockGoalRepo_UpdateAll_Call {
+func (_c *MockGoalRepo_Update_Call) Return(err error) *MockGoalRepo_Update_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockGoalRepo_UpdateAll_Call) RunAndReturn(run func(ctx context.Context, goals []domain.Goal) error) *MockGoalRepo_UpdateAll_Call {
+func (_c *MockGoalRepo_Update_Call) RunAndReturn(run func(ctx context.Context, goal *domain.Goal) error) *MockGoalRepo_Update_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockSalaryRepo creates a new instance of MockSalaryRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockSalaryRepo(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockSalaryRepo {
-	mock := &MockSalaryRepo{}
+// UpdateAll provides a mock function for the type MockGoalRepo
+func (_mock *MockGoalRepo) UpdateAll(ctx context.Context, goals []domain.Goal, allocations []domain.GoalAllocation) error {
+	ret := _mock.Called(ctx, goals, allocations)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAll")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []domain.Goal, []domain.GoalAllocation) error); ok {
+		r0 = returnFunc(ctx, goals, allocations)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockGoalRepo_UpdateAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAll'
+type MockGoalRepo_UpdateAll_Call struct {
+	*mock.Call
+}
+
+// UpdateAll is a helper method to define mock.On call
+//   - ctx
+//   - goals
+//   - allocations
+func (_e *MockGoalRepo_Expecter) UpdateAll(ctx interface{}, goals interface{}, allocations interface{}) *MockGoalRepo_UpdateAll_Call {
+	return &MockGoalRepo_UpdateAll_Call{Call: _e.mock.On("UpdateAll", ctx, goals, allocations)}
+}
+
+func (_c *MockGoalRepo_UpdateAll_Call) Run(run func(ctx context.Context, goals []domain.Goal, allocations []domain.GoalAllocation)) *MockGoalRepo_UpdateAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.Goal), args[2].([]domain.GoalAllocation))
+	})
+	return _c
+}
+
+func (_c *MockGoalRepo_UpdateAll_Call) Return(err error) *MockGoalRepo_UpdateAll_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockGoalRepo_UpdateAll_Call) RunAndReturn(run func(ctx context.Context, goals []domain.Goal, allocations []domain.GoalAllocation) error) *MockGoalRepo_UpdateAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockHouseholdRepo creates a new instance of MockHouseholdRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockHouseholdRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockHouseholdRepo {
+	mock := &MockHouseholdRepo{}
 	mock.Mock.Test(t)
 
 	t.Cleanup(func() { mock.AssertExpectations(t) })
@@ -706,83 +1410,84 @@ func NewMockSalaryRepo(t interface {
 	return mock
 }
 
-// MockSalaryRepo is an autogenerated mock type for the SalaryRepo type
-type MockSalaryRepo struct {
+// MockHouseholdRepo is an autogenerated mock type for the HouseholdRepo type
+type MockHouseholdRepo struct {
 	mock.Mock
 }
 
-type MockSalaryRepo_Expecter struct {
+type MockHouseholdRepo_Expecter struct {
 	mock *mock.Mock
 }
 
-func (_m *MockSalaryRepo) EXPECT() *MockSalaryRepo_Expecter {
-	return &MockSalaryRepo_Expecter{mock: &_m.Mock}
+func (_m *MockHouseholdRepo) EXPECT() *MockHouseholdRepo_Expecter {
+	return &MockHouseholdRepo_Expecter{mock: &_m.Mock}
 }
 
-// Create provides a mock function for the type MockSalaryRepo
-func (_mock *MockSalaryRepo) Create(ctx context.Context, salary *domain.Salary) error {
-	ret := _mock.Called(ctx, salary)
+// AcceptInvitation provides a mock function for the type MockHouseholdRepo
+func (_mock *MockHouseholdRepo) AcceptInvitation(ctx context.Context, i *domain.HouseholdInvitation, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, i, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Create")
+		panic("no return value specified for AcceptInvitation")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Salary) error); ok {
-		r0 = returnFunc(ctx, salary)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.HouseholdInvitation, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, i, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockSalaryRepo_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
-type MockSalaryRepo_Create_Call struct {
+// MockHouseholdRepo_AcceptInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcceptInvitation'
+type MockHouseholdRepo_AcceptInvitation_Call struct {
 	*mock.Call
 }
 
-// Create is a helper method to define mock.On call
+// AcceptInvitation is a helper method to define mock.On call
 //   - ctx
-//   - salary
-func (_e *MockSalaryRepo_Expecter) Create(ctx interface{}, salary interface{}) *MockSalaryRepo_Create_Call {
-	return &MockSalaryRepo_Create_Call{Call: _e.mock.On("Create", ctx, salary)}
+//   - i
+//   - userID
+func (_e *MockHouseholdRepo_Expecter) AcceptInvitation(ctx interface{}, i interface{}, userID interface{}) *MockHouseholdRepo_AcceptInvitation_Call {
+	return &MockHouseholdRepo_AcceptInvitation_Call{Call: _e.mock.On("AcceptInvitation", ctx, i, userID)}
 }
 
-func (_c *MockSalaryRepo_Create_Call) Run(run func(ctx context.Context, salary *domain.Salary)) *MockSalaryRepo_Create_Call {
+func (_c *MockHouseholdRepo_AcceptInvitation_Call) Run(run func(ctx context.Context, i *domain.HouseholdInvitation, userID uuid.UUID)) *MockHouseholdRepo_AcceptInvitation_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*domain.Salary))
+		run(args[0].(context.Context), args[1].(*domain.HouseholdInvitation), args[2].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockSalaryRepo_Create_Call) Return(err error) *MockSalaryRepo_Create_Call {
+func (_c *MockHouseholdRepo_AcceptInvitation_Call) Return(err error) *MockHouseholdRepo_AcceptInvitation_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockSalaryRepo_Create_Call) RunAndReturn(run func(ctx context.Context, salary *domain.Salary) error) *MockSalaryRepo_Create_Call {
+func (_c *MockHouseholdRepo_AcceptInvitation_Call) RunAndReturn(run func(ctx context.Context, i *domain.HouseholdInvitation, userID uuid.UUID) error) *MockHouseholdRepo_AcceptInvitation_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Get provides a mock function for the type MockSalaryRepo
-func (_mock *MockSalaryRepo) Get(ctx context.Context, userID uuid.UUID) (*domain.Salary, error) {
+// AllMemberships provides a mock function for the type MockHouseholdRepo
+func (_mock *MockHouseholdRepo) AllMemberships(ctx context.Context, userID uuid.UUID) ([]domain.Membership, error) {
 	ret := _mock.Called(ctx, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Get")
+		panic("no return value specified for AllMemberships")
 	}
 
-	var r0 *domain.Salary
+	var r0 []domain.Membership
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Salary, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]domain.Membership, error)); ok {
 		return returnFunc(ctx, userID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Salary); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []domain.Membership); ok {
 		r0 = returnFunc(ctx, userID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.Salary)
+			r0 = ret.Get(0).([]domain.Membership)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
@@ -793,197 +1498,3658 @@ func (_mock *MockSalaryRepo) Get(ctx context.Context, userID uuid.UUID) (*domain
 	return r0, r1
 }
 
-// MockSalaryRepo_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
-type MockSalaryRepo_Get_Call struct {
+// MockHouseholdRepo_AllMemberships_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AllMemberships'
+type MockHouseholdRepo_AllMemberships_Call struct {
 	*mock.Call
 }
 
-// Get is a helper method to define mock.On call
+// AllMemberships is a helper method to define mock.On call
 //   - ctx
 //   - userID
-func (_e *MockSalaryRepo_Expecter) Get(ctx interface{}, userID interface{}) *MockSalaryRepo_Get_Call {
-	return &MockSalaryRepo_Get_Call{Call: _e.mock.On("Get", ctx, userID)}
+func (_e *MockHouseholdRepo_Expecter) AllMemberships(ctx interface{}, userID interface{}) *MockHouseholdRepo_AllMemberships_Call {
+	return &MockHouseholdRepo_AllMemberships_Call{Call: _e.mock.On("AllMemberships", ctx, userID)}
 }
 
-func (_c *MockSalaryRepo_Get_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockSalaryRepo_Get_Call {
+func (_c *MockHouseholdRepo_AllMemberships_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockHouseholdRepo_AllMemberships_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockSalaryRepo_Get_Call) Return(salary *domain.Salary, err error) *MockSalaryRepo_Get_Call {
-	_c.Call.Return(salary, err)
+func (_c *MockHouseholdRepo_AllMemberships_Call) Return(memberships []domain.Membership, err error) *MockHouseholdRepo_AllMemberships_Call {
+	_c.Call.Return(memberships, err)
 	return _c
 }
 
-func (_c *MockSalaryRepo_Get_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) (*domain.Salary, error)) *MockSalaryRepo_Get_Call {
+func (_c *MockHouseholdRepo_AllMemberships_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) ([]domain.Membership, error)) *MockHouseholdRepo_AllMemberships_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Update provides a mock function for the type MockSalaryRepo
-func (_mock *MockSalaryRepo) Update(ctx context.Context, salary *domain.Salary) error {
-	ret := _mock.Called(ctx, salary)
+// Create provides a mock function for the type MockHouseholdRepo
+func (_mock *MockHouseholdRepo) Create(ctx context.Context, h *domain.Household) error {
+	ret := _mock.Called(ctx, h)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Household) error); ok {
+		r0 = returnFunc(ctx, h)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockHouseholdRepo_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockHouseholdRepo_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx
+//   - h
+func (_e *MockHouseholdRepo_Expecter) Create(ctx interface{}, h interface{}) *MockHouseholdRepo_Create_Call {
+	return &MockHouseholdRepo_Create_Call{Call: _e.mock.On("Create", ctx, h)}
+}
+
+func (_c *MockHouseholdRepo_Create_Call) Run(run func(ctx context.Context, h *domain.Household)) *MockHouseholdRepo_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Household))
+	})
+	return _c
+}
+
+func (_c *MockHouseholdRepo_Create_Call) Return(err error) *MockHouseholdRepo_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockHouseholdRepo_Create_Call) RunAndReturn(run func(ctx context.Context, h *domain.Household) error) *MockHouseholdRepo_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateInvitation provides a mock function for the type MockHouseholdRepo
+func (_mock *MockHouseholdRepo) CreateInvitation(ctx context.Context, i *domain.HouseholdInvitation) error {
+	ret := _mock.Called(ctx, i)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateInvitation")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.HouseholdInvitation) error); ok {
+		r0 = returnFunc(ctx, i)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockHouseholdRepo_CreateInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateInvitation'
+type MockHouseholdRepo_CreateInvitation_Call struct {
+	*mock.Call
+}
+
+// CreateInvitation is a helper method to define mock.On call
+//   - ctx
+//   - i
+func (_e *MockHouseholdRepo_Expecter) CreateInvitation(ctx interface{}, i interface{}) *MockHouseholdRepo_CreateInvitation_Call {
+	return &MockHouseholdRepo_CreateInvitation_Call{Call: _e.mock.On("CreateInvitation", ctx, i)}
+}
+
+func (_c *MockHouseholdRepo_CreateInvitation_Call) Run(run func(ctx context.Context, i *domain.HouseholdInvitation)) *MockHouseholdRepo_CreateInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.HouseholdInvitation))
+	})
+	return _c
+}
+
+func (_c *MockHouseholdRepo_CreateInvitation_Call) Return(err error) *MockHouseholdRepo_CreateInvitation_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockHouseholdRepo_CreateInvitation_Call) RunAndReturn(run func(ctx context.Context, i *domain.HouseholdInvitation) error) *MockHouseholdRepo_CreateInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockHouseholdRepo
+func (_mock *MockHouseholdRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockHouseholdRepo_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockHouseholdRepo_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockHouseholdRepo_Expecter) Delete(ctx interface{}, id interface{}) *MockHouseholdRepo_Delete_Call {
+	return &MockHouseholdRepo_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *MockHouseholdRepo_Delete_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockHouseholdRepo_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockHouseholdRepo_Delete_Call) Return(err error) *MockHouseholdRepo_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockHouseholdRepo_Delete_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *MockHouseholdRepo_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByOwner provides a mock function for the type MockHouseholdRepo
+func (_mock *MockHouseholdRepo) GetByOwner(ctx context.Context, ownerID uuid.UUID) (*domain.Household, error) {
+	ret := _mock.Called(ctx, ownerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByOwner")
+	}
+
+	var r0 *domain.Household
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Household, error)); ok {
+		return returnFunc(ctx, ownerID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Household); ok {
+		r0 = returnFunc(ctx, ownerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Household)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, ownerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockHouseholdRepo_GetByOwner_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByOwner'
+type MockHouseholdRepo_GetByOwner_Call struct {
+	*mock.Call
+}
+
+// GetByOwner is a helper method to define mock.On call
+//   - ctx
+//   - ownerID
+func (_e *MockHouseholdRepo_Expecter) GetByOwner(ctx interface{}, ownerID interface{}) *MockHouseholdRepo_GetByOwner_Call {
+	return &MockHouseholdRepo_GetByOwner_Call{Call: _e.mock.On("GetByOwner", ctx, ownerID)}
+}
+
+func (_c *MockHouseholdRepo_GetByOwner_Call) Run(run func(ctx context.Context, ownerID uuid.UUID)) *MockHouseholdRepo_GetByOwner_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockHouseholdRepo_GetByOwner_Call) Return(household *domain.Household, err error) *MockHouseholdRepo_GetByOwner_Call {
+	_c.Call.Return(household, err)
+	return _c
+}
+
+func (_c *MockHouseholdRepo_GetByOwner_Call) RunAndReturn(run func(ctx context.Context, ownerID uuid.UUID) (*domain.Household, error)) *MockHouseholdRepo_GetByOwner_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetInvitationByToken provides a mock function for the type MockHouseholdRepo
+func (_mock *MockHouseholdRepo) GetInvitationByToken(ctx context.Context, token string) (*domain.HouseholdInvitation, error) {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInvitationByToken")
+	}
+
+	var r0 *domain.HouseholdInvitation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.HouseholdInvitation, error)); ok {
+		return returnFunc(ctx, token)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.HouseholdInvitation); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.HouseholdInvitation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockHouseholdRepo_GetInvitationByToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInvitationByToken'
+type MockHouseholdRepo_GetInvitationByToken_Call struct {
+	*mock.Call
+}
+
+// GetInvitationByToken is a helper method to define mock.On call
+//   - ctx
+//   - token
+func (_e *MockHouseholdRepo_Expecter) GetInvitationByToken(ctx interface{}, token interface{}) *MockHouseholdRepo_GetInvitationByToken_Call {
+	return &MockHouseholdRepo_GetInvitationByToken_Call{Call: _e.mock.On("GetInvitationByToken", ctx, token)}
+}
+
+func (_c *MockHouseholdRepo_GetInvitationByToken_Call) Run(run func(ctx context.Context, token string)) *MockHouseholdRepo_GetInvitationByToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockHouseholdRepo_GetInvitationByToken_Call) Return(householdInvitation *domain.HouseholdInvitation, err error) *MockHouseholdRepo_GetInvitationByToken_Call {
+	_c.Call.Return(householdInvitation, err)
+	return _c
+}
+
+func (_c *MockHouseholdRepo_GetInvitationByToken_Call) RunAndReturn(run func(ctx context.Context, token string) (*domain.HouseholdInvitation, error)) *MockHouseholdRepo_GetInvitationByToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMembership provides a mock function for the type MockHouseholdRepo
+func (_mock *MockHouseholdRepo) GetMembership(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.Membership, error) {
+	ret := _mock.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMembership")
+	}
+
+	var r0 *domain.Membership
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*domain.Membership, error)); ok {
+		return returnFunc(ctx, id, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *domain.Membership); ok {
+		r0 = returnFunc(ctx, id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Membership)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockHouseholdRepo_GetMembership_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMembership'
+type MockHouseholdRepo_GetMembership_Call struct {
+	*mock.Call
+}
+
+// GetMembership is a helper method to define mock.On call
+//   - ctx
+//   - id
+//   - userID
+func (_e *MockHouseholdRepo_Expecter) GetMembership(ctx interface{}, id interface{}, userID interface{}) *MockHouseholdRepo_GetMembership_Call {
+	return &MockHouseholdRepo_GetMembership_Call{Call: _e.mock.On("GetMembership", ctx, id, userID)}
+}
+
+func (_c *MockHouseholdRepo_GetMembership_Call) Run(run func(ctx context.Context, id uuid.UUID, userID uuid.UUID)) *MockHouseholdRepo_GetMembership_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockHouseholdRepo_GetMembership_Call) Return(membership *domain.Membership, err error) *MockHouseholdRepo_GetMembership_Call {
+	_c.Call.Return(membership, err)
+	return _c
+}
+
+func (_c *MockHouseholdRepo_GetMembership_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.Membership, error)) *MockHouseholdRepo_GetMembership_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Members provides a mock function for the type MockHouseholdRepo
+func (_mock *MockHouseholdRepo) Members(ctx context.Context, id uuid.UUID) ([]domain.HouseholdMember, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Members")
+	}
+
+	var r0 []domain.HouseholdMember
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]domain.HouseholdMember, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []domain.HouseholdMember); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.HouseholdMember)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockHouseholdRepo_Members_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Members'
+type MockHouseholdRepo_Members_Call struct {
+	*mock.Call
+}
+
+// Members is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockHouseholdRepo_Expecter) Members(ctx interface{}, id interface{}) *MockHouseholdRepo_Members_Call {
+	return &MockHouseholdRepo_Members_Call{Call: _e.mock.On("Members", ctx, id)}
+}
+
+func (_c *MockHouseholdRepo_Members_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockHouseholdRepo_Members_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockHouseholdRepo_Members_Call) Return(householdMembers []domain.HouseholdMember, err error) *MockHouseholdRepo_Members_Call {
+	_c.Call.Return(householdMembers, err)
+	return _c
+}
+
+func (_c *MockHouseholdRepo_Members_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) ([]domain.HouseholdMember, error)) *MockHouseholdRepo_Members_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveMember provides a mock function for the type MockHouseholdRepo
+func (_mock *MockHouseholdRepo) RemoveMember(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveMember")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockHouseholdRepo_RemoveMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveMember'
+type MockHouseholdRepo_RemoveMember_Call struct {
+	*mock.Call
+}
+
+// RemoveMember is a helper method to define mock.On call
+//   - ctx
+//   - id
+//   - userID
+func (_e *MockHouseholdRepo_Expecter) RemoveMember(ctx interface{}, id interface{}, userID interface{}) *MockHouseholdRepo_RemoveMember_Call {
+	return &MockHouseholdRepo_RemoveMember_Call{Call: _e.mock.On("RemoveMember", ctx, id, userID)}
+}
+
+func (_c *MockHouseholdRepo_RemoveMember_Call) Run(run func(ctx context.Context, id uuid.UUID, userID uuid.UUID)) *MockHouseholdRepo_RemoveMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockHouseholdRepo_RemoveMember_Call) Return(err error) *MockHouseholdRepo_RemoveMember_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockHouseholdRepo_RemoveMember_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, userID uuid.UUID) error) *MockHouseholdRepo_RemoveMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateMemberRole provides a mock function for the type MockHouseholdRepo
+func (_mock *MockHouseholdRepo) UpdateMemberRole(ctx context.Context, id uuid.UUID, userID uuid.UUID, role domain.HouseholdRole) error {
+	ret := _mock.Called(ctx, id, userID, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMemberRole")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID, domain.HouseholdRole) error); ok {
+		r0 = returnFunc(ctx, id, userID, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockHouseholdRepo_UpdateMemberRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMemberRole'
+type MockHouseholdRepo_UpdateMemberRole_Call struct {
+	*mock.Call
+}
+
+// UpdateMemberRole is a helper method to define mock.On call
+//   - ctx
+//   - id
+//   - userID
+//   - role
+func (_e *MockHouseholdRepo_Expecter) UpdateMemberRole(ctx interface{}, id interface{}, userID interface{}, role interface{}) *MockHouseholdRepo_UpdateMemberRole_Call {
+	return &MockHouseholdRepo_UpdateMemberRole_Call{Call: _e.mock.On("UpdateMemberRole", ctx, id, userID, role)}
+}
+
+func (_c *MockHouseholdRepo_UpdateMemberRole_Call) Run(run func(ctx context.Context, id uuid.UUID, userID uuid.UUID, role domain.HouseholdRole)) *MockHouseholdRepo_UpdateMemberRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID), args[3].(domain.HouseholdRole))
+	})
+	return _c
+}
+
+func (_c *MockHouseholdRepo_UpdateMemberRole_Call) Return(err error) *MockHouseholdRepo_UpdateMemberRole_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockHouseholdRepo_UpdateMemberRole_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, userID uuid.UUID, role domain.HouseholdRole) error) *MockHouseholdRepo_UpdateMemberRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockIncomeRepo creates a new instance of MockIncomeRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIncomeRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIncomeRepo {
+	mock := &MockIncomeRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockIncomeRepo is an autogenerated mock type for the IncomeRepo type
+type MockIncomeRepo struct {
+	mock.Mock
+}
+
+type MockIncomeRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIncomeRepo) EXPECT() *MockIncomeRepo_Expecter {
+	return &MockIncomeRepo_Expecter{mock: &_m.Mock}
+}
+
+// All provides a mock function for the type MockIncomeRepo
+func (_mock *MockIncomeRepo) All(ctx context.Context, userID uuid.UUID) ([]domain.Income, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for All")
+	}
+
+	var r0 []domain.Income
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]domain.Income, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []domain.Income); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Income)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIncomeRepo_All_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'All'
+type MockIncomeRepo_All_Call struct {
+	*mock.Call
+}
+
+// All is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockIncomeRepo_Expecter) All(ctx interface{}, userID interface{}) *MockIncomeRepo_All_Call {
+	return &MockIncomeRepo_All_Call{Call: _e.mock.On("All", ctx, userID)}
+}
+
+func (_c *MockIncomeRepo_All_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockIncomeRepo_All_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockIncomeRepo_All_Call) Return(incomes []domain.Income, err error) *MockIncomeRepo_All_Call {
+	_c.Call.Return(incomes, err)
+	return _c
+}
+
+func (_c *MockIncomeRepo_All_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) ([]domain.Income, error)) *MockIncomeRepo_All_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockIncomeRepo
+func (_mock *MockIncomeRepo) Create(ctx context.Context, i *domain.Income) error {
+	ret := _mock.Called(ctx, i)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Income) error); ok {
+		r0 = returnFunc(ctx, i)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIncomeRepo_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockIncomeRepo_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx
+//   - i
+func (_e *MockIncomeRepo_Expecter) Create(ctx interface{}, i interface{}) *MockIncomeRepo_Create_Call {
+	return &MockIncomeRepo_Create_Call{Call: _e.mock.On("Create", ctx, i)}
+}
+
+func (_c *MockIncomeRepo_Create_Call) Run(run func(ctx context.Context, i *domain.Income)) *MockIncomeRepo_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Income))
+	})
+	return _c
+}
+
+func (_c *MockIncomeRepo_Create_Call) Return(err error) *MockIncomeRepo_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIncomeRepo_Create_Call) RunAndReturn(run func(ctx context.Context, i *domain.Income) error) *MockIncomeRepo_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockIncomeRepo
+func (_mock *MockIncomeRepo) Delete(ctx context.Context, id uint, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIncomeRepo_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockIncomeRepo_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx
+//   - id
+//   - userID
+func (_e *MockIncomeRepo_Expecter) Delete(ctx interface{}, id interface{}, userID interface{}) *MockIncomeRepo_Delete_Call {
+	return &MockIncomeRepo_Delete_Call{Call: _e.mock.On("Delete", ctx, id, userID)}
+}
+
+func (_c *MockIncomeRepo_Delete_Call) Run(run func(ctx context.Context, id uint, userID uuid.UUID)) *MockIncomeRepo_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockIncomeRepo_Delete_Call) Return(err error) *MockIncomeRepo_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIncomeRepo_Delete_Call) RunAndReturn(run func(ctx context.Context, id uint, userID uuid.UUID) error) *MockIncomeRepo_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAmount provides a mock function for the type MockIncomeRepo
+func (_mock *MockIncomeRepo) DeleteAmount(ctx context.Context, incomeID uint, month time.Time) error {
+	ret := _mock.Called(ctx, incomeID, month)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAmount")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint, time.Time) error); ok {
+		r0 = returnFunc(ctx, incomeID, month)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIncomeRepo_DeleteAmount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAmount'
+type MockIncomeRepo_DeleteAmount_Call struct {
+	*mock.Call
+}
+
+// DeleteAmount is a helper method to define mock.On call
+//   - ctx
+//   - incomeID
+//   - month
+func (_e *MockIncomeRepo_Expecter) DeleteAmount(ctx interface{}, incomeID interface{}, month interface{}) *MockIncomeRepo_DeleteAmount_Call {
+	return &MockIncomeRepo_DeleteAmount_Call{Call: _e.mock.On("DeleteAmount", ctx, incomeID, month)}
+}
+
+func (_c *MockIncomeRepo_DeleteAmount_Call) Run(run func(ctx context.Context, incomeID uint, month time.Time)) *MockIncomeRepo_DeleteAmount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockIncomeRepo_DeleteAmount_Call) Return(err error) *MockIncomeRepo_DeleteAmount_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIncomeRepo_DeleteAmount_Call) RunAndReturn(run func(ctx context.Context, incomeID uint, month time.Time) error) *MockIncomeRepo_DeleteAmount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockIncomeRepo
+func (_mock *MockIncomeRepo) Get(ctx context.Context, id uint, userID uuid.UUID) (*domain.Income, error) {
+	ret := _mock.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domain.Income
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint, uuid.UUID) (*domain.Income, error)); ok {
+		return returnFunc(ctx, id, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint, uuid.UUID) *domain.Income); ok {
+		r0 = returnFunc(ctx, id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Income)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIncomeRepo_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockIncomeRepo_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx
+//   - id
+//   - userID
+func (_e *MockIncomeRepo_Expecter) Get(ctx interface{}, id interface{}, userID interface{}) *MockIncomeRepo_Get_Call {
+	return &MockIncomeRepo_Get_Call{Call: _e.mock.On("Get", ctx, id, userID)}
+}
+
+func (_c *MockIncomeRepo_Get_Call) Run(run func(ctx context.Context, id uint, userID uuid.UUID)) *MockIncomeRepo_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockIncomeRepo_Get_Call) Return(income *domain.Income, err error) *MockIncomeRepo_Get_Call {
+	_c.Call.Return(income, err)
+	return _c
+}
+
+func (_c *MockIncomeRepo_Get_Call) RunAndReturn(run func(ctx context.Context, id uint, userID uuid.UUID) (*domain.Income, error)) *MockIncomeRepo_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetAmount provides a mock function for the type MockIncomeRepo
+func (_mock *MockIncomeRepo) SetAmount(ctx context.Context, a *domain.IncomeAmount) error {
+	ret := _mock.Called(ctx, a)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetAmount")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.IncomeAmount) error); ok {
+		r0 = returnFunc(ctx, a)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIncomeRepo_SetAmount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetAmount'
+type MockIncomeRepo_SetAmount_Call struct {
+	*mock.Call
+}
+
+// SetAmount is a helper method to define mock.On call
+//   - ctx
+//   - a
+func (_e *MockIncomeRepo_Expecter) SetAmount(ctx interface{}, a interface{}) *MockIncomeRepo_SetAmount_Call {
+	return &MockIncomeRepo_SetAmount_Call{Call: _e.mock.On("SetAmount", ctx, a)}
+}
+
+func (_c *MockIncomeRepo_SetAmount_Call) Run(run func(ctx context.Context, a *domain.IncomeAmount)) *MockIncomeRepo_SetAmount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.IncomeAmount))
+	})
+	return _c
+}
+
+func (_c *MockIncomeRepo_SetAmount_Call) Return(err error) *MockIncomeRepo_SetAmount_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIncomeRepo_SetAmount_Call) RunAndReturn(run func(ctx context.Context, a *domain.IncomeAmount) error) *MockIncomeRepo_SetAmount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockIncomeRepo
+func (_mock *MockIncomeRepo) Update(ctx context.Context, i *domain.Income) error {
+	ret := _mock.Called(ctx, i)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Income) error); ok {
+		r0 = returnFunc(ctx, i)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockIncomeRepo_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockIncomeRepo_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx
+//   - i
+func (_e *MockIncomeRepo_Expecter) Update(ctx interface{}, i interface{}) *MockIncomeRepo_Update_Call {
+	return &MockIncomeRepo_Update_Call{Call: _e.mock.On("Update", ctx, i)}
+}
+
+func (_c *MockIncomeRepo_Update_Call) Run(run func(ctx context.Context, i *domain.Income)) *MockIncomeRepo_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Income))
+	})
+	return _c
+}
+
+func (_c *MockIncomeRepo_Update_Call) Return(err error) *MockIncomeRepo_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockIncomeRepo_Update_Call) RunAndReturn(run func(ctx context.Context, i *domain.Income) error) *MockIncomeRepo_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockNotificationRepo creates a new instance of MockNotificationRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockNotificationRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockNotificationRepo {
+	mock := &MockNotificationRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockNotificationRepo is an autogenerated mock type for the NotificationRepo type
+type MockNotificationRepo struct {
+	mock.Mock
+}
+
+type MockNotificationRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockNotificationRepo) EXPECT() *MockNotificationRepo_Expecter {
+	return &MockNotificationRepo_Expecter{mock: &_m.Mock}
+}
+
+// All provides a mock function for the type MockNotificationRepo
+func (_mock *MockNotificationRepo) All(ctx context.Context, unreadOnly bool, userID uuid.UUID) ([]domain.Notification, error) {
+	ret := _mock.Called(ctx, unreadOnly, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for All")
+	}
+
+	var r0 []domain.Notification
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, bool, uuid.UUID) ([]domain.Notification, error)); ok {
+		return returnFunc(ctx, unreadOnly, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, bool, uuid.UUID) []domain.Notification); ok {
+		r0 = returnFunc(ctx, unreadOnly, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Notification)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, bool, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, unreadOnly, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockNotificationRepo_All_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'All'
+type MockNotificationRepo_All_Call struct {
+	*mock.Call
+}
+
+// All is a helper method to define mock.On call
+//   - ctx
+//   - unreadOnly
+//   - userID
+func (_e *MockNotificationRepo_Expecter) All(ctx interface{}, unreadOnly interface{}, userID interface{}) *MockNotificationRepo_All_Call {
+	return &MockNotificationRepo_All_Call{Call: _e.mock.On("All", ctx, unreadOnly, userID)}
+}
+
+func (_c *MockNotificationRepo_All_Call) Run(run func(ctx context.Context, unreadOnly bool, userID uuid.UUID)) *MockNotificationRepo_All_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(bool), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockNotificationRepo_All_Call) Return(notifications []domain.Notification, err error) *MockNotificationRepo_All_Call {
+	_c.Call.Return(notifications, err)
+	return _c
+}
+
+func (_c *MockNotificationRepo_All_Call) RunAndReturn(run func(ctx context.Context, unreadOnly bool, userID uuid.UUID) ([]domain.Notification, error)) *MockNotificationRepo_All_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockNotificationRepo
+func (_mock *MockNotificationRepo) Create(ctx context.Context, n *domain.Notification) (bool, error) {
+	ret := _mock.Called(ctx, n)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Notification) (bool, error)); ok {
+		return returnFunc(ctx, n)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Notification) bool); ok {
+		r0 = returnFunc(ctx, n)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.Notification) error); ok {
+		r1 = returnFunc(ctx, n)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockNotificationRepo_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockNotificationRepo_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx
+//   - n
+func (_e *MockNotificationRepo_Expecter) Create(ctx interface{}, n interface{}) *MockNotificationRepo_Create_Call {
+	return &MockNotificationRepo_Create_Call{Call: _e.mock.On("Create", ctx, n)}
+}
+
+func (_c *MockNotificationRepo_Create_Call) Run(run func(ctx context.Context, n *domain.Notification)) *MockNotificationRepo_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Notification))
+	})
+	return _c
+}
+
+func (_c *MockNotificationRepo_Create_Call) Return(b bool, err error) *MockNotificationRepo_Create_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockNotificationRepo_Create_Call) RunAndReturn(run func(ctx context.Context, n *domain.Notification) (bool, error)) *MockNotificationRepo_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkAllRead provides a mock function for the type MockNotificationRepo
+func (_mock *MockNotificationRepo) MarkAllRead(ctx context.Context, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkAllRead")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockNotificationRepo_MarkAllRead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkAllRead'
+type MockNotificationRepo_MarkAllRead_Call struct {
+	*mock.Call
+}
+
+// MarkAllRead is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockNotificationRepo_Expecter) MarkAllRead(ctx interface{}, userID interface{}) *MockNotificationRepo_MarkAllRead_Call {
+	return &MockNotificationRepo_MarkAllRead_Call{Call: _e.mock.On("MarkAllRead", ctx, userID)}
+}
+
+func (_c *MockNotificationRepo_MarkAllRead_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockNotificationRepo_MarkAllRead_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockNotificationRepo_MarkAllRead_Call) Return(err error) *MockNotificationRepo_MarkAllRead_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockNotificationRepo_MarkAllRead_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) error) *MockNotificationRepo_MarkAllRead_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkRead provides a mock function for the type MockNotificationRepo
+func (_mock *MockNotificationRepo) MarkRead(ctx context.Context, id uint, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkRead")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockNotificationRepo_MarkRead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkRead'
+type MockNotificationRepo_MarkRead_Call struct {
+	*mock.Call
+}
+
+// MarkRead is a helper method to define mock.On call
+//   - ctx
+//   - id
+//   - userID
+func (_e *MockNotificationRepo_Expecter) MarkRead(ctx interface{}, id interface{}, userID interface{}) *MockNotificationRepo_MarkRead_Call {
+	return &MockNotificationRepo_MarkRead_Call{Call: _e.mock.On("MarkRead", ctx, id, userID)}
+}
+
+func (_c *MockNotificationRepo_MarkRead_Call) Run(run func(ctx context.Context, id uint, userID uuid.UUID)) *MockNotificationRepo_MarkRead_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockNotificationRepo_MarkRead_Call) Return(err error) *MockNotificationRepo_MarkRead_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockNotificationRepo_MarkRead_Call) RunAndReturn(run func(ctx context.Context, id uint, userID uuid.UUID) error) *MockNotificationRepo_MarkRead_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockOutboxRepo creates a new instance of MockOutboxRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOutboxRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOutboxRepo {
+	mock := &MockOutboxRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockOutboxRepo is an autogenerated mock type for the OutboxRepo type
+type MockOutboxRepo struct {
+	mock.Mock
+}
+
+type MockOutboxRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOutboxRepo) EXPECT() *MockOutboxRepo_Expecter {
+	return &MockOutboxRepo_Expecter{mock: &_m.Mock}
+}
+
+// ClaimDue provides a mock function for the type MockOutboxRepo
+func (_mock *MockOutboxRepo) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.OutboxEmail, error) {
+	ret := _mock.Called(ctx, now, lease, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDue")
+	}
+
+	var r0 []domain.OutboxEmail
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration, int) ([]domain.OutboxEmail, error)); ok {
+		return returnFunc(ctx, now, lease, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration, int) []domain.OutboxEmail); ok {
+		r0 = returnFunc(ctx, now, lease, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.OutboxEmail)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, time.Duration, int) error); ok {
+		r1 = returnFunc(ctx, now, lease, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOutboxRepo_ClaimDue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimDue'
+type MockOutboxRepo_ClaimDue_Call struct {
+	*mock.Call
+}
+
+// ClaimDue is a helper method to define mock.On call
+//   - ctx
+//   - now
+//   - lease
+//   - limit
+func (_e *MockOutboxRepo_Expecter) ClaimDue(ctx interface{}, now interface{}, lease interface{}, limit interface{}) *MockOutboxRepo_ClaimDue_Call {
+	return &MockOutboxRepo_ClaimDue_Call{Call: _e.mock.On("ClaimDue", ctx, now, lease, limit)}
+}
+
+func (_c *MockOutboxRepo_ClaimDue_Call) Run(run func(ctx context.Context, now time.Time, lease time.Duration, limit int)) *MockOutboxRepo_ClaimDue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Duration), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockOutboxRepo_ClaimDue_Call) Return(outboxEmails []domain.OutboxEmail, err error) *MockOutboxRepo_ClaimDue_Call {
+	_c.Call.Return(outboxEmails, err)
+	return _c
+}
+
+func (_c *MockOutboxRepo_ClaimDue_Call) RunAndReturn(run func(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.OutboxEmail, error)) *MockOutboxRepo_ClaimDue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Enqueue provides a mock function for the type MockOutboxRepo
+func (_mock *MockOutboxRepo) Enqueue(ctx context.Context, e *domain.OutboxEmail) error {
+	ret := _mock.Called(ctx, e)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Enqueue")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.OutboxEmail) error); ok {
+		r0 = returnFunc(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockOutboxRepo_Enqueue_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Enqueue'
+type MockOutboxRepo_Enqueue_Call struct {
+	*mock.Call
+}
+
+// Enqueue is a helper method to define mock.On call
+//   - ctx
+//   - e
+func (_e *MockOutboxRepo_Expecter) Enqueue(ctx interface{}, e interface{}) *MockOutboxRepo_Enqueue_Call {
+	return &MockOutboxRepo_Enqueue_Call{Call: _e.mock.On("Enqueue", ctx, e)}
+}
+
+func (_c *MockOutboxRepo_Enqueue_Call) Run(run func(ctx context.Context, e *domain.OutboxEmail)) *MockOutboxRepo_Enqueue_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.OutboxEmail))
+	})
+	return _c
+}
+
+func (_c *MockOutboxRepo_Enqueue_Call) Return(err error) *MockOutboxRepo_Enqueue_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockOutboxRepo_Enqueue_Call) RunAndReturn(run func(ctx context.Context, e *domain.OutboxEmail) error) *MockOutboxRepo_Enqueue_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkFailed provides a mock function for the type MockOutboxRepo
+func (_mock *MockOutboxRepo) MarkFailed(ctx context.Context, id uint, attempts int, nextAttemptAt *time.Time, lastErr string) error {
+	ret := _mock.Called(ctx, id, attempts, nextAttemptAt, lastErr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkFailed")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint, int, *time.Time, string) error); ok {
+		r0 = returnFunc(ctx, id, attempts, nextAttemptAt, lastErr)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockOutboxRepo_MarkFailed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkFailed'
+type MockOutboxRepo_MarkFailed_Call struct {
+	*mock.Call
+}
+
+// MarkFailed is a helper method to define mock.On call
+//   - ctx
+//   - id
+//   - attempts
+//   - nextAttemptAt
+//   - lastErr
+func (_e *MockOutboxRepo_Expecter) MarkFailed(ctx interface{}, id interface{}, attempts interface{}, nextAttemptAt interface{}, lastErr interface{}) *MockOutboxRepo_MarkFailed_Call {
+	return &MockOutboxRepo_MarkFailed_Call{Call: _e.mock.On("MarkFailed", ctx, id, attempts, nextAttemptAt, lastErr)}
+}
+
+func (_c *MockOutboxRepo_MarkFailed_Call) Run(run func(ctx context.Context, id uint, attempts int, nextAttemptAt *time.Time, lastErr string)) *MockOutboxRepo_MarkFailed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(int), args[3].(*time.Time), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *MockOutboxRepo_MarkFailed_Call) Return(err error) *MockOutboxRepo_MarkFailed_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockOutboxRepo_MarkFailed_Call) RunAndReturn(run func(ctx context.Context, id uint, attempts int, nextAttemptAt *time.Time, lastErr string) error) *MockOutboxRepo_MarkFailed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkSent provides a mock function for the type MockOutboxRepo
+func (_mock *MockOutboxRepo) MarkSent(ctx context.Context, id uint) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkSent")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockOutboxRepo_MarkSent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkSent'
+type MockOutboxRepo_MarkSent_Call struct {
+	*mock.Call
+}
+
+// MarkSent is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockOutboxRepo_Expecter) MarkSent(ctx interface{}, id interface{}) *MockOutboxRepo_MarkSent_Call {
+	return &MockOutboxRepo_MarkSent_Call{Call: _e.mock.On("MarkSent", ctx, id)}
+}
+
+func (_c *MockOutboxRepo_MarkSent_Call) Run(run func(ctx context.Context, id uint)) *MockOutboxRepo_MarkSent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint))
+	})
+	return _c
+}
+
+func (_c *MockOutboxRepo_MarkSent_Call) Return(err error) *MockOutboxRepo_MarkSent_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockOutboxRepo_MarkSent_Call) RunAndReturn(run func(ctx context.Context, id uint) error) *MockOutboxRepo_MarkSent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockRecurringExpenseRepo creates a new instance of MockRecurringExpenseRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRecurringExpenseRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRecurringExpenseRepo {
+	mock := &MockRecurringExpenseRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockRecurringExpenseRepo is an autogenerated mock type for the RecurringExpenseRepo type
+type MockRecurringExpenseRepo struct {
+	mock.Mock
+}
+
+type MockRecurringExpenseRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRecurringExpenseRepo) EXPECT() *MockRecurringExpenseRepo_Expecter {
+	return &MockRecurringExpenseRepo_Expecter{mock: &_m.Mock}
+}
+
+// All provides a mock function for the type MockRecurringExpenseRepo
+func (_mock *MockRecurringExpenseRepo) All(ctx context.Context, userID uuid.UUID) ([]domain.RecurringExpense, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for All")
+	}
+
+	var r0 []domain.RecurringExpense
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]domain.RecurringExpense, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []domain.RecurringExpense); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.RecurringExpense)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRecurringExpenseRepo_All_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'All'
+type MockRecurringExpenseRepo_All_Call struct {
+	*mock.Call
+}
+
+// All is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockRecurringExpenseRepo_Expecter) All(ctx interface{}, userID interface{}) *MockRecurringExpenseRepo_All_Call {
+	return &MockRecurringExpenseRepo_All_Call{Call: _e.mock.On("All", ctx, userID)}
+}
+
+func (_c *MockRecurringExpenseRepo_All_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockRecurringExpenseRepo_All_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockRecurringExpenseRepo_All_Call) Return(recurringExpenses []domain.RecurringExpense, err error) *MockRecurringExpenseRepo_All_Call {
+	_c.Call.Return(recurringExpenses, err)
+	return _c
+}
+
+func (_c *MockRecurringExpenseRepo_All_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) ([]domain.RecurringExpense, error)) *MockRecurringExpenseRepo_All_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AllStartedBy provides a mock function for the type MockRecurringExpenseRepo
+func (_mock *MockRecurringExpenseRepo) AllStartedBy(ctx context.Context, date time.Time) ([]domain.RecurringExpense, error) {
+	ret := _mock.Called(ctx, date)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AllStartedBy")
+	}
+
+	var r0 []domain.RecurringExpense
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) ([]domain.RecurringExpense, error)); ok {
+		return returnFunc(ctx, date)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) []domain.RecurringExpense); ok {
+		r0 = returnFunc(ctx, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.RecurringExpense)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRecurringExpenseRepo_AllStartedBy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AllStartedBy'
+type MockRecurringExpenseRepo_AllStartedBy_Call struct {
+	*mock.Call
+}
+
+// AllStartedBy is a helper method to define mock.On call
+//   - ctx
+//   - date
+func (_e *MockRecurringExpenseRepo_Expecter) AllStartedBy(ctx interface{}, date interface{}) *MockRecurringExpenseRepo_AllStartedBy_Call {
+	return &MockRecurringExpenseRepo_AllStartedBy_Call{Call: _e.mock.On("AllStartedBy", ctx, date)}
+}
+
+func (_c *MockRecurringExpenseRepo_AllStartedBy_Call) Run(run func(ctx context.Context, date time.Time)) *MockRecurringExpenseRepo_AllStartedBy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRecurringExpenseRepo_AllStartedBy_Call) Return(recurringExpenses []domain.RecurringExpense, err error) *MockRecurringExpenseRepo_AllStartedBy_Call {
+	_c.Call.Return(recurringExpenses, err)
+	return _c
+}
+
+func (_c *MockRecurringExpenseRepo_AllStartedBy_Call) RunAndReturn(run func(ctx context.Context, date time.Time) ([]domain.RecurringExpense, error)) *MockRecurringExpenseRepo_AllStartedBy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockRecurringExpenseRepo
+func (_mock *MockRecurringExpenseRepo) Create(ctx context.Context, r *domain.RecurringExpense) error {
+	ret := _mock.Called(ctx, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.RecurringExpense) error); ok {
+		r0 = returnFunc(ctx, r)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRecurringExpenseRepo_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockRecurringExpenseRepo_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx
+//   - r
+func (_e *MockRecurringExpenseRepo_Expecter) Create(ctx interface{}, r interface{}) *MockRecurringExpenseRepo_Create_Call {
+	return &MockRecurringExpenseRepo_Create_Call{Call: _e.mock.On("Create", ctx, r)}
+}
+
+func (_c *MockRecurringExpenseRepo_Create_Call) Run(run func(ctx context.Context, r *domain.RecurringExpense)) *MockRecurringExpenseRepo_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.RecurringExpense))
+	})
+	return _c
+}
+
+func (_c *MockRecurringExpenseRepo_Create_Call) Return(err error) *MockRecurringExpenseRepo_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRecurringExpenseRepo_Create_Call) RunAndReturn(run func(ctx context.Context, r *domain.RecurringExpense) error) *MockRecurringExpenseRepo_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockRecurringExpenseRepo
+func (_mock *MockRecurringExpenseRepo) Delete(ctx context.Context, id uint, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRecurringExpenseRepo_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockRecurringExpenseRepo_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx
+//   - id
+//   - userID
+func (_e *MockRecurringExpenseRepo_Expecter) Delete(ctx interface{}, id interface{}, userID interface{}) *MockRecurringExpenseRepo_Delete_Call {
+	return &MockRecurringExpenseRepo_Delete_Call{Call: _e.mock.On("Delete", ctx, id, userID)}
+}
+
+func (_c *MockRecurringExpenseRepo_Delete_Call) Run(run func(ctx context.Context, id uint, userID uuid.UUID)) *MockRecurringExpenseRepo_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockRecurringExpenseRepo_Delete_Call) Return(err error) *MockRecurringExpenseRepo_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRecurringExpenseRepo_Delete_Call) RunAndReturn(run func(ctx context.Context, id uint, userID uuid.UUID) error) *MockRecurringExpenseRepo_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockRecurringExpenseRepo
+func (_mock *MockRecurringExpenseRepo) Get(ctx context.Context, id uint, userID uuid.UUID) (*domain.RecurringExpense, error) {
+	ret := _mock.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domain.RecurringExpense
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint, uuid.UUID) (*domain.RecurringExpense, error)); ok {
+		return returnFunc(ctx, id, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint, uuid.UUID) *domain.RecurringExpense); ok {
+		r0 = returnFunc(ctx, id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.RecurringExpense)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRecurringExpenseRepo_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockRecurringExpenseRepo_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx
+//   - id
+//   - userID
+func (_e *MockRecurringExpenseRepo_Expecter) Get(ctx interface{}, id interface{}, userID interface{}) *MockRecurringExpenseRepo_Get_Call {
+	return &MockRecurringExpenseRepo_Get_Call{Call: _e.mock.On("Get", ctx, id, userID)}
+}
+
+func (_c *MockRecurringExpenseRepo_Get_Call) Run(run func(ctx context.Context, id uint, userID uuid.UUID)) *MockRecurringExpenseRepo_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockRecurringExpenseRepo_Get_Call) Return(recurringExpense *domain.RecurringExpense, err error) *MockRecurringExpenseRepo_Get_Call {
+	_c.Call.Return(recurringExpense, err)
+	return _c
+}
+
+func (_c *MockRecurringExpenseRepo_Get_Call) RunAndReturn(run func(ctx context.Context, id uint, userID uuid.UUID) (*domain.RecurringExpense, error)) *MockRecurringExpenseRepo_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Materialize provides a mock function for the type MockRecurringExpenseRepo
+func (_mock *MockRecurringExpenseRepo) Materialize(ctx context.Context, r *domain.RecurringExpense, until time.Time) (int, error) {
+	ret := _mock.Called(ctx, r, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Materialize")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.RecurringExpense, time.Time) (int, error)); ok {
+		return returnFunc(ctx, r, until)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.RecurringExpense, time.Time) int); ok {
+		r0 = returnFunc(ctx, r, until)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.RecurringExpense, time.Time) error); ok {
+		r1 = returnFunc(ctx, r, until)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRecurringExpenseRepo_Materialize_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Materialize'
+type MockRecurringExpenseRepo_Materialize_Call struct {
+	*mock.Call
+}
+
+// Materialize is a helper method to define mock.On call
+//   - ctx
+//   - r
+//   - until
+func (_e *MockRecurringExpenseRepo_Expecter) Materialize(ctx interface{}, r interface{}, until interface{}) *MockRecurringExpenseRepo_Materialize_Call {
+	return &MockRecurringExpenseRepo_Materialize_Call{Call: _e.mock.On("Materialize", ctx, r, until)}
+}
+
+func (_c *MockRecurringExpenseRepo_Materialize_Call) Run(run func(ctx context.Context, r *domain.RecurringExpense, until time.Time)) *MockRecurringExpenseRepo_Materialize_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.RecurringExpense), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRecurringExpenseRepo_Materialize_Call) Return(n int, err error) *MockRecurringExpenseRepo_Materialize_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockRecurringExpenseRepo_Materialize_Call) RunAndReturn(run func(ctx context.Context, r *domain.RecurringExpense, until time.Time) (int, error)) *MockRecurringExpenseRepo_Materialize_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MaterializeMonth provides a mock function for the type MockRecurringExpenseRepo
+func (_mock *MockRecurringExpenseRepo) MaterializeMonth(ctx context.Context, r *domain.RecurringExpense, month time.Time) (bool, error) {
+	ret := _mock.Called(ctx, r, month)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MaterializeMonth")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.RecurringExpense, time.Time) (bool, error)); ok {
+		return returnFunc(ctx, r, month)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.RecurringExpense, time.Time) bool); ok {
+		r0 = returnFunc(ctx, r, month)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.RecurringExpense, time.Time) error); ok {
+		r1 = returnFunc(ctx, r, month)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRecurringExpenseRepo_MaterializeMonth_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MaterializeMonth'
+type MockRecurringExpenseRepo_MaterializeMonth_Call struct {
+	*mock.Call
+}
+
+// MaterializeMonth is a helper method to define mock.On call
+//   - ctx
+//   - r
+//   - month
+func (_e *MockRecurringExpenseRepo_Expecter) MaterializeMonth(ctx interface{}, r interface{}, month interface{}) *MockRecurringExpenseRepo_MaterializeMonth_Call {
+	return &MockRecurringExpenseRepo_MaterializeMonth_Call{Call: _e.mock.On("MaterializeMonth", ctx, r, month)}
+}
+
+func (_c *MockRecurringExpenseRepo_MaterializeMonth_Call) Run(run func(ctx context.Context, r *domain.RecurringExpense, month time.Time)) *MockRecurringExpenseRepo_MaterializeMonth_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.RecurringExpense), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockRecurringExpenseRepo_MaterializeMonth_Call) Return(b bool, err error) *MockRecurringExpenseRepo_MaterializeMonth_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockRecurringExpenseRepo_MaterializeMonth_Call) RunAndReturn(run func(ctx context.Context, r *domain.RecurringExpense, month time.Time) (bool, error)) *MockRecurringExpenseRepo_MaterializeMonth_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockRecurringExpenseRepo
+func (_mock *MockRecurringExpenseRepo) Update(ctx context.Context, r *domain.RecurringExpense) error {
+	ret := _mock.Called(ctx, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.RecurringExpense) error); ok {
+		r0 = returnFunc(ctx, r)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRecurringExpenseRepo_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockRecurringExpenseRepo_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx
+//   - r
+func (_e *MockRecurringExpenseRepo_Expecter) Update(ctx interface{}, r interface{}) *MockRecurringExpenseRepo_Update_Call {
+	return &MockRecurringExpenseRepo_Update_Call{Call: _e.mock.On("Update", ctx, r)}
+}
+
+func (_c *MockRecurringExpenseRepo_Update_Call) Run(run func(ctx context.Context, r *domain.RecurringExpense)) *MockRecurringExpenseRepo_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.RecurringExpense))
+	})
+	return _c
+}
+
+func (_c *MockRecurringExpenseRepo_Update_Call) Return(err error) *MockRecurringExpenseRepo_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRecurringExpenseRepo_Update_Call) RunAndReturn(run func(ctx context.Context, r *domain.RecurringExpense) error) *MockRecurringExpenseRepo_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSalaryRepo creates a new instance of MockSalaryRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSalaryRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSalaryRepo {
+	mock := &MockSalaryRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSalaryRepo is an autogenerated mock type for the SalaryRepo type
+type MockSalaryRepo struct {
+	mock.Mock
+}
+
+type MockSalaryRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSalaryRepo) EXPECT() *MockSalaryRepo_Expecter {
+	return &MockSalaryRepo_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockSalaryRepo
+func (_mock *MockSalaryRepo) Create(ctx context.Context, salary *domain.Salary) error {
+	ret := _mock.Called(ctx, salary)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Salary) error); ok {
+		r0 = returnFunc(ctx, salary)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSalaryRepo_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockSalaryRepo_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx
+//   - salary
+func (_e *MockSalaryRepo_Expecter) Create(ctx interface{}, salary interface{}) *MockSalaryRepo_Create_Call {
+	return &MockSalaryRepo_Create_Call{Call: _e.mock.On("Create", ctx, salary)}
+}
+
+func (_c *MockSalaryRepo_Create_Call) Run(run func(ctx context.Context, salary *domain.Salary)) *MockSalaryRepo_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Salary))
+	})
+	return _c
+}
+
+func (_c *MockSalaryRepo_Create_Call) Return(err error) *MockSalaryRepo_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSalaryRepo_Create_Call) RunAndReturn(run func(ctx context.Context, salary *domain.Salary) error) *MockSalaryRepo_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockSalaryRepo
+func (_mock *MockSalaryRepo) Get(ctx context.Context, userID uuid.UUID) (*domain.Salary, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domain.Salary
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Salary, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Salary); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Salary)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSalaryRepo_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockSalaryRepo_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockSalaryRepo_Expecter) Get(ctx interface{}, userID interface{}) *MockSalaryRepo_Get_Call {
+	return &MockSalaryRepo_Get_Call{Call: _e.mock.On("Get", ctx, userID)}
+}
+
+func (_c *MockSalaryRepo_Get_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockSalaryRepo_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSalaryRepo_Get_Call) Return(salary *domain.Salary, err error) *MockSalaryRepo_Get_Call {
+	_c.Call.Return(salary, err)
+	return _c
+}
+
+func (_c *MockSalaryRepo_Get_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) (*domain.Salary, error)) *MockSalaryRepo_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// History provides a mock function for the type MockSalaryRepo
+func (_mock *MockSalaryRepo) History(ctx context.Context, userID uuid.UUID) ([]domain.Salary, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for History")
+	}
+
+	var r0 []domain.Salary
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]domain.Salary, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []domain.Salary); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Salary)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSalaryRepo_History_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'History'
+type MockSalaryRepo_History_Call struct {
+	*mock.Call
+}
+
+// History is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockSalaryRepo_Expecter) History(ctx interface{}, userID interface{}) *MockSalaryRepo_History_Call {
+	return &MockSalaryRepo_History_Call{Call: _e.mock.On("History", ctx, userID)}
+}
+
+func (_c *MockSalaryRepo_History_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockSalaryRepo_History_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSalaryRepo_History_Call) Return(salarys []domain.Salary, err error) *MockSalaryRepo_History_Call {
+	_c.Call.Return(salarys, err)
+	return _c
+}
+
+func (_c *MockSalaryRepo_History_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) ([]domain.Salary, error)) *MockSalaryRepo_History_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockSalaryRepo
+func (_mock *MockSalaryRepo) Update(ctx context.Context, salary *domain.Salary) error {
+	ret := _mock.Called(ctx, salary)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Salary) error); ok {
+		r0 = returnFunc(ctx, salary)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSalaryRepo_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockSalaryRepo_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx
+//   - salary
+func (_e *MockSalaryRepo_Expecter) Update(ctx interface{}, salary interface{}) *MockSalaryRepo_Update_Call {
+	return &MockSalaryRepo_Update_Call{Call: _e.mock.On("Update", ctx, salary)}
+}
+
+func (_c *MockSalaryRepo_Update_Call) Run(run func(ctx context.Context, salary *domain.Salary)) *MockSalaryRepo_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Salary))
+	})
+	return _c
+}
+
+func (_c *MockSalaryRepo_Update_Call) Return(err error) *MockSalaryRepo_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSalaryRepo_Update_Call) RunAndReturn(run func(ctx context.Context, salary *domain.Salary) error) *MockSalaryRepo_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSavingsTargetRepo creates a new instance of MockSavingsTargetRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSavingsTargetRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSavingsTargetRepo {
+	mock := &MockSavingsTargetRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSavingsTargetRepo is an autogenerated mock type for the SavingsTargetRepo type
+type MockSavingsTargetRepo struct {
+	mock.Mock
+}
+
+type MockSavingsTargetRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSavingsTargetRepo) EXPECT() *MockSavingsTargetRepo_Expecter {
+	return &MockSavingsTargetRepo_Expecter{mock: &_m.Mock}
+}
+
+// All provides a mock function for the type MockSavingsTargetRepo
+func (_mock *MockSavingsTargetRepo) All(ctx context.Context, userID uuid.UUID) ([]domain.SavingsTarget, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for All")
+	}
+
+	var r0 []domain.SavingsTarget
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]domain.SavingsTarget, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []domain.SavingsTarget); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SavingsTarget)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSavingsTargetRepo_All_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'All'
+type MockSavingsTargetRepo_All_Call struct {
+	*mock.Call
+}
+
+// All is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockSavingsTargetRepo_Expecter) All(ctx interface{}, userID interface{}) *MockSavingsTargetRepo_All_Call {
+	return &MockSavingsTargetRepo_All_Call{Call: _e.mock.On("All", ctx, userID)}
+}
+
+func (_c *MockSavingsTargetRepo_All_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockSavingsTargetRepo_All_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSavingsTargetRepo_All_Call) Return(savingsTargets []domain.SavingsTarget, err error) *MockSavingsTargetRepo_All_Call {
+	_c.Call.Return(savingsTargets, err)
+	return _c
+}
+
+func (_c *MockSavingsTargetRepo_All_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) ([]domain.SavingsTarget, error)) *MockSavingsTargetRepo_All_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockSavingsTargetRepo
+func (_mock *MockSavingsTargetRepo) Create(ctx context.Context, t *domain.SavingsTarget) error {
+	ret := _mock.Called(ctx, t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.SavingsTarget) error); ok {
+		r0 = returnFunc(ctx, t)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSavingsTargetRepo_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockSavingsTargetRepo_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx
+//   - t
+func (_e *MockSavingsTargetRepo_Expecter) Create(ctx interface{}, t interface{}) *MockSavingsTargetRepo_Create_Call {
+	return &MockSavingsTargetRepo_Create_Call{Call: _e.mock.On("Create", ctx, t)}
+}
+
+func (_c *MockSavingsTargetRepo_Create_Call) Run(run func(ctx context.Context, t *domain.SavingsTarget)) *MockSavingsTargetRepo_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.SavingsTarget))
+	})
+	return _c
+}
+
+func (_c *MockSavingsTargetRepo_Create_Call) Return(err error) *MockSavingsTargetRepo_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSavingsTargetRepo_Create_Call) RunAndReturn(run func(ctx context.Context, t *domain.SavingsTarget) error) *MockSavingsTargetRepo_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockSavingsTargetRepo
+func (_mock *MockSavingsTargetRepo) Delete(ctx context.Context, id uint, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSavingsTargetRepo_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockSavingsTargetRepo_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx
+//   - id
+//   - userID
+func (_e *MockSavingsTargetRepo_Expecter) Delete(ctx interface{}, id interface{}, userID interface{}) *MockSavingsTargetRepo_Delete_Call {
+	return &MockSavingsTargetRepo_Delete_Call{Call: _e.mock.On("Delete", ctx, id, userID)}
+}
+
+func (_c *MockSavingsTargetRepo_Delete_Call) Run(run func(ctx context.Context, id uint, userID uuid.UUID)) *MockSavingsTargetRepo_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSavingsTargetRepo_Delete_Call) Return(err error) *MockSavingsTargetRepo_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSavingsTargetRepo_Delete_Call) RunAndReturn(run func(ctx context.Context, id uint, userID uuid.UUID) error) *MockSavingsTargetRepo_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockSavingsTargetRepo
+func (_mock *MockSavingsTargetRepo) Get(ctx context.Context, id uint, userID uuid.UUID) (*domain.SavingsTarget, error) {
+	ret := _mock.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domain.SavingsTarget
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint, uuid.UUID) (*domain.SavingsTarget, error)); ok {
+		return returnFunc(ctx, id, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint, uuid.UUID) *domain.SavingsTarget); ok {
+		r0 = returnFunc(ctx, id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.SavingsTarget)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSavingsTargetRepo_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockSavingsTargetRepo_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx
+//   - id
+//   - userID
+func (_e *MockSavingsTargetRepo_Expecter) Get(ctx interface{}, id interface{}, userID interface{}) *MockSavingsTargetRepo_Get_Call {
+	return &MockSavingsTargetRepo_Get_Call{Call: _e.mock.On("Get", ctx, id, userID)}
+}
+
+func (_c *MockSavingsTargetRepo_Get_Call) Run(run func(ctx context.Context, id uint, userID uuid.UUID)) *MockSavingsTargetRepo_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSavingsTargetRepo_Get_Call) Return(savingsTarget *domain.SavingsTarget, err error) *MockSavingsTargetRepo_Get_Call {
+	_c.Call.Return(savingsTarget, err)
+	return _c
+}
+
+func (_c *MockSavingsTargetRepo_Get_Call) RunAndReturn(run func(ctx context.Context, id uint, userID uuid.UUID) (*domain.SavingsTarget, error)) *MockSavingsTargetRepo_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockSavingsTargetRepo
+func (_mock *MockSavingsTargetRepo) Update(ctx context.Context, t *domain.SavingsTarget) error {
+	ret := _mock.Called(ctx, t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.SavingsTarget) error); ok {
+		r0 = returnFunc(ctx, t)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSavingsTargetRepo_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockSavingsTargetRepo_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx
+//   - t
+func (_e *MockSavingsTargetRepo_Expecter) Update(ctx interface{}, t interface{}) *MockSavingsTargetRepo_Update_Call {
+	return &MockSavingsTargetRepo_Update_Call{Call: _e.mock.On("Update", ctx, t)}
+}
+
+func (_c *MockSavingsTargetRepo_Update_Call) Run(run func(ctx context.Context, t *domain.SavingsTarget)) *MockSavingsTargetRepo_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.SavingsTarget))
+	})
+	return _c
+}
+
+func (_c *MockSavingsTargetRepo_Update_Call) Return(err error) *MockSavingsTargetRepo_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSavingsTargetRepo_Update_Call) RunAndReturn(run func(ctx context.Context, t *domain.SavingsTarget) error) *MockSavingsTargetRepo_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockSessionRepo creates a new instance of MockSessionRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockSessionRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockSessionRepo {
+	mock := &MockSessionRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockSessionRepo is an autogenerated mock type for the SessionRepo type
+type MockSessionRepo struct {
+	mock.Mock
+}
+
+type MockSessionRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockSessionRepo) EXPECT() *MockSessionRepo_Expecter {
+	return &MockSessionRepo_Expecter{mock: &_m.Mock}
+}
+
+// Create provides a mock function for the type MockSessionRepo
+func (_mock *MockSessionRepo) Create(ctx context.Context, s *domain.Session) error {
+	ret := _mock.Called(ctx, s)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Session) error); ok {
+		r0 = returnFunc(ctx, s)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSessionRepo_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockSessionRepo_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx
+//   - s
+func (_e *MockSessionRepo_Expecter) Create(ctx interface{}, s interface{}) *MockSessionRepo_Create_Call {
+	return &MockSessionRepo_Create_Call{Call: _e.mock.On("Create", ctx, s)}
+}
+
+func (_c *MockSessionRepo_Create_Call) Run(run func(ctx context.Context, s *domain.Session)) *MockSessionRepo_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Session))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepo_Create_Call) Return(err error) *MockSessionRepo_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSessionRepo_Create_Call) RunAndReturn(run func(ctx context.Context, s *domain.Session) error) *MockSessionRepo_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockSessionRepo
+func (_mock *MockSessionRepo) Get(ctx context.Context, id uuid.UUID) (*domain.Session, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domain.Session
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Session, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Session); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Session)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSessionRepo_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockSessionRepo_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx
+//   - id
+func (_e *MockSessionRepo_Expecter) Get(ctx interface{}, id interface{}) *MockSessionRepo_Get_Call {
+	return &MockSessionRepo_Get_Call{Call: _e.mock.On("Get", ctx, id)}
+}
+
+func (_c *MockSessionRepo_Get_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockSessionRepo_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepo_Get_Call) Return(session *domain.Session, err error) *MockSessionRepo_Get_Call {
+	_c.Call.Return(session, err)
+	return _c
+}
+
+func (_c *MockSessionRepo_Get_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (*domain.Session, error)) *MockSessionRepo_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByPreviousTokenHash provides a mock function for the type MockSessionRepo
+func (_mock *MockSessionRepo) GetByPreviousTokenHash(ctx context.Context, hash string) (*domain.Session, error) {
+	ret := _mock.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByPreviousTokenHash")
+	}
+
+	var r0 *domain.Session
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.Session, error)); ok {
+		return returnFunc(ctx, hash)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.Session); ok {
+		r0 = returnFunc(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Session)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSessionRepo_GetByPreviousTokenHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByPreviousTokenHash'
+type MockSessionRepo_GetByPreviousTokenHash_Call struct {
+	*mock.Call
+}
+
+// GetByPreviousTokenHash is a helper method to define mock.On call
+//   - ctx
+//   - hash
+func (_e *MockSessionRepo_Expecter) GetByPreviousTokenHash(ctx interface{}, hash interface{}) *MockSessionRepo_GetByPreviousTokenHash_Call {
+	return &MockSessionRepo_GetByPreviousTokenHash_Call{Call: _e.mock.On("GetByPreviousTokenHash", ctx, hash)}
+}
+
+func (_c *MockSessionRepo_GetByPreviousTokenHash_Call) Run(run func(ctx context.Context, hash string)) *MockSessionRepo_GetByPreviousTokenHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepo_GetByPreviousTokenHash_Call) Return(session *domain.Session, err error) *MockSessionRepo_GetByPreviousTokenHash_Call {
+	_c.Call.Return(session, err)
+	return _c
+}
+
+func (_c *MockSessionRepo_GetByPreviousTokenHash_Call) RunAndReturn(run func(ctx context.Context, hash string) (*domain.Session, error)) *MockSessionRepo_GetByPreviousTokenHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByRefreshTokenHash provides a mock function for the type MockSessionRepo
+func (_mock *MockSessionRepo) GetByRefreshTokenHash(ctx context.Context, hash string) (*domain.Session, error) {
+	ret := _mock.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByRefreshTokenHash")
+	}
+
+	var r0 *domain.Session
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.Session, error)); ok {
+		return returnFunc(ctx, hash)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.Session); ok {
+		r0 = returnFunc(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Session)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockSessionRepo_GetByRefreshTokenHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByRefreshTokenHash'
+type MockSessionRepo_GetByRefreshTokenHash_Call struct {
+	*mock.Call
+}
+
+// GetByRefreshTokenHash is a helper method to define mock.On call
+//   - ctx
+//   - hash
+func (_e *MockSessionRepo_Expecter) GetByRefreshTokenHash(ctx interface{}, hash interface{}) *MockSessionRepo_GetByRefreshTokenHash_Call {
+	return &MockSessionRepo_GetByRefreshTokenHash_Call{Call: _e.mock.On("GetByRefreshTokenHash", ctx, hash)}
+}
+
+func (_c *MockSessionRepo_GetByRefreshTokenHash_Call) Run(run func(ctx context.Context, hash string)) *MockSessionRepo_GetByRefreshTokenHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepo_GetByRefreshTokenHash_Call) Return(session *domain.Session, err error) *MockSessionRepo_GetByRefreshTokenHash_Call {
+	_c.Call.Return(session, err)
+	return _c
+}
+
+func (_c *MockSessionRepo_GetByRefreshTokenHash_Call) RunAndReturn(run func(ctx context.Context, hash string) (*domain.Session, error)) *MockSessionRepo_GetByRefreshTokenHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Revoke provides a mock function for the type MockSessionRepo
+func (_mock *MockSessionRepo) Revoke(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Revoke")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSessionRepo_Revoke_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Revoke'
+type MockSessionRepo_Revoke_Call struct {
+	*mock.Call
+}
+
+// Revoke is a helper method to define mock.On call
+//   - ctx
+//   - id
+//   - userID
+func (_e *MockSessionRepo_Expecter) Revoke(ctx interface{}, id interface{}, userID interface{}) *MockSessionRepo_Revoke_Call {
+	return &MockSessionRepo_Revoke_Call{Call: _e.mock.On("Revoke", ctx, id, userID)}
+}
+
+func (_c *MockSessionRepo_Revoke_Call) Run(run func(ctx context.Context, id uuid.UUID, userID uuid.UUID)) *MockSessionRepo_Revoke_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepo_Revoke_Call) Return(err error) *MockSessionRepo_Revoke_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSessionRepo_Revoke_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, userID uuid.UUID) error) *MockSessionRepo_Revoke_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeAll provides a mock function for the type MockSessionRepo
+func (_mock *MockSessionRepo) RevokeAll(ctx context.Context, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeAll")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSessionRepo_RevokeAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeAll'
+type MockSessionRepo_RevokeAll_Call struct {
+	*mock.Call
+}
+
+// RevokeAll is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockSessionRepo_Expecter) RevokeAll(ctx interface{}, userID interface{}) *MockSessionRepo_RevokeAll_Call {
+	return &MockSessionRepo_RevokeAll_Call{Call: _e.mock.On("RevokeAll", ctx, userID)}
+}
+
+func (_c *MockSessionRepo_RevokeAll_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockSessionRepo_RevokeAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepo_RevokeAll_Call) Return(err error) *MockSessionRepo_RevokeAll_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSessionRepo_RevokeAll_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) error) *MockSessionRepo_RevokeAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RevokeOthers provides a mock function for the type MockSessionRepo
+func (_mock *MockSessionRepo) RevokeOthers(ctx context.Context, userID uuid.UUID, keepID uuid.UUID) error {
+	ret := _mock.Called(ctx, userID, keepID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeOthers")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID, keepID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSessionRepo_RevokeOthers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RevokeOthers'
+type MockSessionRepo_RevokeOthers_Call struct {
+	*mock.Call
+}
+
+// RevokeOthers is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - keepID
+func (_e *MockSessionRepo_Expecter) RevokeOthers(ctx interface{}, userID interface{}, keepID interface{}) *MockSessionRepo_RevokeOthers_Call {
+	return &MockSessionRepo_RevokeOthers_Call{Call: _e.mock.On("RevokeOthers", ctx, userID, keepID)}
+}
+
+func (_c *MockSessionRepo_RevokeOthers_Call) Run(run func(ctx context.Context, userID uuid.UUID, keepID uuid.UUID)) *MockSessionRepo_RevokeOthers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepo_RevokeOthers_Call) Return(err error) *MockSessionRepo_RevokeOthers_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSessionRepo_RevokeOthers_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, keepID uuid.UUID) error) *MockSessionRepo_RevokeOthers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Rotate provides a mock function for the type MockSessionRepo
+func (_mock *MockSessionRepo) Rotate(ctx context.Context, s *domain.Session, newHash string, expiresAt time.Time) error {
+	ret := _mock.Called(ctx, s, newHash, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rotate")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Session, string, time.Time) error); ok {
+		r0 = returnFunc(ctx, s, newHash, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockSessionRepo_Rotate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rotate'
+type MockSessionRepo_Rotate_Call struct {
+	*mock.Call
+}
+
+// Rotate is a helper method to define mock.On call
+//   - ctx
+//   - s
+//   - newHash
+//   - expiresAt
+func (_e *MockSessionRepo_Expecter) Rotate(ctx interface{}, s interface{}, newHash interface{}, expiresAt interface{}) *MockSessionRepo_Rotate_Call {
+	return &MockSessionRepo_Rotate_Call{Call: _e.mock.On("Rotate", ctx, s, newHash, expiresAt)}
+}
+
+func (_c *MockSessionRepo_Rotate_Call) Run(run func(ctx context.Context, s *domain.Session, newHash string, expiresAt time.Time)) *MockSessionRepo_Rotate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Session), args[2].(string), args[3].(time.Time))
+	})
+	return _c
+}
+
+func (_c *MockSessionRepo_Rotate_Call) Return(err error) *MockSessionRepo_Rotate_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSessionRepo_Rotate_Call) RunAndReturn(run func(ctx context.Context, s *domain.Session, newHash string, expiresAt time.Time) error) *MockSessionRepo_Rotate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTagRepo creates a new instance of MockTagRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTagRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTagRepo {
+	mock := &MockTagRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTagRepo is an autogenerated mock type for the TagRepo type
+type MockTagRepo struct {
+	mock.Mock
+}
+
+type MockTagRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTagRepo) EXPECT() *MockTagRepo_Expecter {
+	return &MockTagRepo_Expecter{mock: &_m.Mock}
+}
+
+// All provides a mock function for the type MockTagRepo
+func (_mock *MockTagRepo) All(ctx context.Context, userID uuid.UUID) ([]domain.Tag, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for All")
+	}
+
+	var r0 []domain.Tag
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]domain.Tag, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) []domain.Tag); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Tag)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTagRepo_All_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'All'
+type MockTagRepo_All_Call struct {
+	*mock.Call
+}
+
+// All is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockTagRepo_Expecter) All(ctx interface{}, userID interface{}) *MockTagRepo_All_Call {
+	return &MockTagRepo_All_Call{Call: _e.mock.On("All", ctx, userID)}
+}
+
+func (_c *MockTagRepo_All_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockTagRepo_All_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockTagRepo_All_Call) Return(tags []domain.Tag, err error) *MockTagRepo_All_Call {
+	_c.Call.Return(tags, err)
+	return _c
+}
+
+func (_c *MockTagRepo_All_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) ([]domain.Tag, error)) *MockTagRepo_All_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockTagRepo
+func (_mock *MockTagRepo) Create(ctx context.Context, t *domain.Tag) error {
+	ret := _mock.Called(ctx, t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Tag) error); ok {
+		r0 = returnFunc(ctx, t)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTagRepo_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockTagRepo_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx
+//   - t
+func (_e *MockTagRepo_Expecter) Create(ctx interface{}, t interface{}) *MockTagRepo_Create_Call {
+	return &MockTagRepo_Create_Call{Call: _e.mock.On("Create", ctx, t)}
+}
+
+func (_c *MockTagRepo_Create_Call) Run(run func(ctx context.Context, t *domain.Tag)) *MockTagRepo_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Tag))
+	})
+	return _c
+}
+
+func (_c *MockTagRepo_Create_Call) Return(err error) *MockTagRepo_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTagRepo_Create_Call) RunAndReturn(run func(ctx context.Context, t *domain.Tag) error) *MockTagRepo_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockTagRepo
+func (_mock *MockTagRepo) Delete(ctx context.Context, id uint, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTagRepo_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockTagRepo_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx
+//   - id
+//   - userID
+func (_e *MockTagRepo_Expecter) Delete(ctx interface{}, id interface{}, userID interface{}) *MockTagRepo_Delete_Call {
+	return &MockTagRepo_Delete_Call{Call: _e.mock.On("Delete", ctx, id, userID)}
+}
+
+func (_c *MockTagRepo_Delete_Call) Run(run func(ctx context.Context, id uint, userID uuid.UUID)) *MockTagRepo_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockTagRepo_Delete_Call) Return(err error) *MockTagRepo_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTagRepo_Delete_Call) RunAndReturn(run func(ctx context.Context, id uint, userID uuid.UUID) error) *MockTagRepo_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function for the type MockTagRepo
+func (_mock *MockTagRepo) Get(ctx context.Context, id uint, userID uuid.UUID) (*domain.Tag, error) {
+	ret := _mock.Called(ctx, id, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *domain.Tag
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint, uuid.UUID) (*domain.Tag, error)); ok {
+		return returnFunc(ctx, id, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint, uuid.UUID) *domain.Tag); ok {
+		r0 = returnFunc(ctx, id, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Tag)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTagRepo_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockTagRepo_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx
+//   - id
+//   - userID
+func (_e *MockTagRepo_Expecter) Get(ctx interface{}, id interface{}, userID interface{}) *MockTagRepo_Get_Call {
+	return &MockTagRepo_Get_Call{Call: _e.mock.On("Get", ctx, id, userID)}
+}
+
+func (_c *MockTagRepo_Get_Call) Run(run func(ctx context.Context, id uint, userID uuid.UUID)) *MockTagRepo_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uint), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockTagRepo_Get_Call) Return(tag *domain.Tag, err error) *MockTagRepo_Get_Call {
+	_c.Call.Return(tag, err)
+	return _c
+}
+
+func (_c *MockTagRepo_Get_Call) RunAndReturn(run func(ctx context.Context, id uint, userID uuid.UUID) (*domain.Tag, error)) *MockTagRepo_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMany provides a mock function for the type MockTagRepo
+func (_mock *MockTagRepo) GetMany(ctx context.Context, ids []uint, userID uuid.UUID) ([]domain.Tag, error) {
+	ret := _mock.Called(ctx, ids, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMany")
+	}
+
+	var r0 []domain.Tag
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []uint, uuid.UUID) ([]domain.Tag, error)); ok {
+		return returnFunc(ctx, ids, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []uint, uuid.UUID) []domain.Tag); ok {
+		r0 = returnFunc(ctx, ids, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Tag)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []uint, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, ids, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTagRepo_GetMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMany'
+type MockTagRepo_GetMany_Call struct {
+	*mock.Call
+}
+
+// GetMany is a helper method to define mock.On call
+//   - ctx
+//   - ids
+//   - userID
+func (_e *MockTagRepo_Expecter) GetMany(ctx interface{}, ids interface{}, userID interface{}) *MockTagRepo_GetMany_Call {
+	return &MockTagRepo_GetMany_Call{Call: _e.mock.On("GetMany", ctx, ids, userID)}
+}
+
+func (_c *MockTagRepo_GetMany_Call) Run(run func(ctx context.Context, ids []uint, userID uuid.UUID)) *MockTagRepo_GetMany_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uint), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockTagRepo_GetMany_Call) Return(tags []domain.Tag, err error) *MockTagRepo_GetMany_Call {
+	_c.Call.Return(tags, err)
+	return _c
+}
+
+func (_c *MockTagRepo_GetMany_Call) RunAndReturn(run func(ctx context.Context, ids []uint, userID uuid.UUID) ([]domain.Tag, error)) *MockTagRepo_GetMany_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Merge provides a mock function for the type MockTagRepo
+func (_mock *MockTagRepo) Merge(ctx context.Context, source *domain.Tag, target *domain.Tag) error {
+	ret := _mock.Called(ctx, source, target)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Merge")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Tag, *domain.Tag) error); ok {
+		r0 = returnFunc(ctx, source, target)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTagRepo_Merge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Merge'
+type MockTagRepo_Merge_Call struct {
+	*mock.Call
+}
+
+// Merge is a helper method to define mock.On call
+//   - ctx
+//   - source
+//   - target
+func (_e *MockTagRepo_Expecter) Merge(ctx interface{}, source interface{}, target interface{}) *MockTagRepo_Merge_Call {
+	return &MockTagRepo_Merge_Call{Call: _e.mock.On("Merge", ctx, source, target)}
+}
+
+func (_c *MockTagRepo_Merge_Call) Run(run func(ctx context.Context, source *domain.Tag, target *domain.Tag)) *MockTagRepo_Merge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Tag), args[2].(*domain.Tag))
+	})
+	return _c
+}
+
+func (_c *MockTagRepo_Merge_Call) Return(err error) *MockTagRepo_Merge_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTagRepo_Merge_Call) RunAndReturn(run func(ctx context.Context, source *domain.Tag, target *domain.Tag) error) *MockTagRepo_Merge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MonthlyTotals provides a mock function for the type MockTagRepo
+func (_mock *MockTagRepo) MonthlyTotals(ctx context.Context, from time.Time, to time.Time, userID uuid.UUID) ([]domain.TagMonthlyTotal, error) {
+	ret := _mock.Called(ctx, from, to, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MonthlyTotals")
+	}
+
+	var r0 []domain.TagMonthlyTotal
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, uuid.UUID) ([]domain.TagMonthlyTotal, error)); ok {
+		return returnFunc(ctx, from, to, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, uuid.UUID) []domain.TagMonthlyTotal); ok {
+		r0 = returnFunc(ctx, from, to, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TagMonthlyTotal)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, from, to, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTagRepo_MonthlyTotals_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MonthlyTotals'
+type MockTagRepo_MonthlyTotals_Call struct {
+	*mock.Call
+}
+
+// MonthlyTotals is a helper method to define mock.On call
+//   - ctx
+//   - from
+//   - to
+//   - userID
+func (_e *MockTagRepo_Expecter) MonthlyTotals(ctx interface{}, from interface{}, to interface{}, userID interface{}) *MockTagRepo_MonthlyTotals_Call {
+	return &MockTagRepo_MonthlyTotals_Call{Call: _e.mock.On("MonthlyTotals", ctx, from, to, userID)}
+}
+
+func (_c *MockTagRepo_MonthlyTotals_Call) Run(run func(ctx context.Context, from time.Time, to time.Time, userID uuid.UUID)) *MockTagRepo_MonthlyTotals_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time), args[3].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockTagRepo_MonthlyTotals_Call) Return(tagMonthlyTotals []domain.TagMonthlyTotal, err error) *MockTagRepo_MonthlyTotals_Call {
+	_c.Call.Return(tagMonthlyTotals, err)
+	return _c
+}
+
+func (_c *MockTagRepo_MonthlyTotals_Call) RunAndReturn(run func(ctx context.Context, from time.Time, to time.Time, userID uuid.UUID) ([]domain.TagMonthlyTotal, error)) *MockTagRepo_MonthlyTotals_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type MockTagRepo
+func (_mock *MockTagRepo) Update(ctx context.Context, t *domain.Tag) error {
+	ret := _mock.Called(ctx, t)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Tag) error); ok {
+		r0 = returnFunc(ctx, t)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTagRepo_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type MockTagRepo_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx
+//   - t
+func (_e *MockTagRepo_Expecter) Update(ctx interface{}, t interface{}) *MockTagRepo_Update_Call {
+	return &MockTagRepo_Update_Call{Call: _e.mock.On("Update", ctx, t)}
+}
+
+func (_c *MockTagRepo_Update_Call) Run(run func(ctx context.Context, t *domain.Tag)) *MockTagRepo_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Tag))
+	})
+	return _c
+}
+
+func (_c *MockTagRepo_Update_Call) Return(err error) *MockTagRepo_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTagRepo_Update_Call) RunAndReturn(run func(ctx context.Context, t *domain.Tag) error) *MockTagRepo_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTransactor creates a new instance of MockTransactor. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTransactor(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTransactor {
+	mock := &MockTransactor{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockTransactor is an autogenerated mock type for the Transactor type
+type MockTransactor struct {
+	mock.Mock
+}
+
+type MockTransactor_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTransactor) EXPECT() *MockTransactor_Expecter {
+	return &MockTransactor_Expecter{mock: &_m.Mock}
+}
+
+// ReadOnlyTransaction provides a mock function for the type MockTransactor
+func (_mock *MockTransactor) ReadOnlyTransaction(ctx context.Context, fn func(context.Context) error) error {
+	ret := _mock.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReadOnlyTransaction")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, func(context.Context) error) error); ok {
+		r0 = returnFunc(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTransactor_ReadOnlyTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadOnlyTransaction'
+type MockTransactor_ReadOnlyTransaction_Call struct {
+	*mock.Call
+}
+
+// ReadOnlyTransaction is a helper method to define mock.On call
+//   - ctx
+//   - fn
+func (_e *MockTransactor_Expecter) ReadOnlyTransaction(ctx interface{}, fn interface{}) *MockTransactor_ReadOnlyTransaction_Call {
+	return &MockTransactor_ReadOnlyTransaction_Call{Call: _e.mock.On("ReadOnlyTransaction", ctx, fn)}
+}
+
+func (_c *MockTransactor_ReadOnlyTransaction_Call) Run(run func(ctx context.Context, fn func(context.Context) error)) *MockTransactor_ReadOnlyTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(context.Context) error))
+	})
+	return _c
+}
+
+func (_c *MockTransactor_ReadOnlyTransaction_Call) Return(err error) *MockTransactor_ReadOnlyTransaction_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTransactor_ReadOnlyTransaction_Call) RunAndReturn(run func(ctx context.Context, fn func(context.Context) error) error) *MockTransactor_ReadOnlyTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Transaction provides a mock function for the type MockTransactor
+func (_mock *MockTransactor) Transaction(ctx context.Context, fn func(context.Context) error) error {
+	ret := _mock.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transaction")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, func(context.Context) error) error); ok {
+		r0 = returnFunc(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTransactor_Transaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Transaction'
+type MockTransactor_Transaction_Call struct {
+	*mock.Call
+}
+
+// Transaction is a helper method to define mock.On call
+//   - ctx
+//   - fn
+func (_e *MockTransactor_Expecter) Transaction(ctx interface{}, fn interface{}) *MockTransactor_Transaction_Call {
+	return &MockTransactor_Transaction_Call{Call: _e.mock.On("Transaction", ctx, fn)}
+}
+
+func (_c *MockTransactor_Transaction_Call) Run(run func(ctx context.Context, fn func(context.Context) error)) *MockTransactor_Transaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(func(context.Context) error))
+	})
+	return _c
+}
+
+func (_c *MockTransactor_Transaction_Call) Return(err error) *MockTransactor_Transaction_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTransactor_Transaction_Call) RunAndReturn(run func(ctx context.Context, fn func(context.Context) error) error) *MockTransactor_Transaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockUserRepo creates a new instance of MockUserRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockUserRepo(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockUserRepo {
+	mock := &MockUserRepo{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockUserRepo is an autogenerated mock type for the UserRepo type
+type MockUserRepo struct {
+	mock.Mock
+}
+
+type MockUserRepo_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockUserRepo) EXPECT() *MockUserRepo_Expecter {
+	return &MockUserRepo_Expecter{mock: &_m.Mock}
+}
+
+// All provides a mock function for the type MockUserRepo
+func (_mock *MockUserRepo) All(ctx context.Context) ([]domain.User, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for All")
+	}
+
+	var r0 []domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]domain.User, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []domain.User); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepo_All_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'All'
+type MockUserRepo_All_Call struct {
+	*mock.Call
+}
+
+// All is a helper method to define mock.On call
+//   - ctx
+func (_e *MockUserRepo_Expecter) All(ctx interface{}) *MockUserRepo_All_Call {
+	return &MockUserRepo_All_Call{Call: _e.mock.On("All", ctx)}
+}
+
+func (_c *MockUserRepo_All_Call) Run(run func(ctx context.Context)) *MockUserRepo_All_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockUserRepo_All_Call) Return(users []domain.User, err error) *MockUserRepo_All_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *MockUserRepo_All_Call) RunAndReturn(run func(ctx context.Context) ([]domain.User, error)) *MockUserRepo_All_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountRecoveryCodes provides a mock function for the type MockUserRepo
+func (_mock *MockUserRepo) CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int64, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountRecoveryCodes")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int64, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) int64); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepo_CountRecoveryCodes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountRecoveryCodes'
+type MockUserRepo_CountRecoveryCodes_Call struct {
+	*mock.Call
+}
+
+// CountRecoveryCodes is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockUserRepo_Expecter) CountRecoveryCodes(ctx interface{}, userID interface{}) *MockUserRepo_CountRecoveryCodes_Call {
+	return &MockUserRepo_CountRecoveryCodes_Call{Call: _e.mock.On("CountRecoveryCodes", ctx, userID)}
+}
+
+func (_c *MockUserRepo_CountRecoveryCodes_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockUserRepo_CountRecoveryCodes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockUserRepo_CountRecoveryCodes_Call) Return(n int64, err error) *MockUserRepo_CountRecoveryCodes_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockUserRepo_CountRecoveryCodes_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) (int64, error)) *MockUserRepo_CountRecoveryCodes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Create provides a mock function for the type MockUserRepo
+func (_mock *MockUserRepo) Create(ctx context.Context, user *domain.User, salary *domain.Salary) error {
+	ret := _mock.Called(ctx, user, salary)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.User, *domain.Salary) error); ok {
+		r0 = returnFunc(ctx, user, salary)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepo_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
+type MockUserRepo_Create_Call struct {
+	*mock.Call
+}
+
+// Create is a helper method to define mock.On call
+//   - ctx
+//   - user
+//   - salary
+func (_e *MockUserRepo_Expecter) Create(ctx interface{}, user interface{}, salary interface{}) *MockUserRepo_Create_Call {
+	return &MockUserRepo_Create_Call{Call: _e.mock.On("Create", ctx, user, salary)}
+}
+
+func (_c *MockUserRepo_Create_Call) Run(run func(ctx context.Context, user *domain.User, salary *domain.Salary)) *MockUserRepo_Create_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.User), args[2].(*domain.Salary))
+	})
+	return _c
+}
+
+func (_c *MockUserRepo_Create_Call) Return(err error) *MockUserRepo_Create_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepo_Create_Call) RunAndReturn(run func(ctx context.Context, user *domain.User, salary *domain.Salary) error) *MockUserRepo_Create_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateToken provides a mock function for the type MockUserRepo
+func (_mock *MockUserRepo) CreateToken(ctx context.Context, token *domain.UserToken) error {
+	ret := _mock.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateToken")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.UserToken) error); ok {
+		r0 = returnFunc(ctx, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepo_CreateToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateToken'
+type MockUserRepo_CreateToken_Call struct {
+	*mock.Call
+}
+
+// CreateToken is a helper method to define mock.On call
+//   - ctx
+//   - token
+func (_e *MockUserRepo_Expecter) CreateToken(ctx interface{}, token interface{}) *MockUserRepo_CreateToken_Call {
+	return &MockUserRepo_CreateToken_Call{Call: _e.mock.On("CreateToken", ctx, token)}
+}
+
+func (_c *MockUserRepo_CreateToken_Call) Run(run func(ctx context.Context, token *domain.UserToken)) *MockUserRepo_CreateToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.UserToken))
+	})
+	return _c
+}
+
+func (_c *MockUserRepo_CreateToken_Call) Return(err error) *MockUserRepo_CreateToken_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepo_CreateToken_Call) RunAndReturn(run func(ctx context.Context, token *domain.UserToken) error) *MockUserRepo_CreateToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockUserRepo
+func (_mock *MockUserRepo) Delete(ctx context.Context, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Update")
+		panic("no return value specified for Delete")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.Salary) error); ok {
-		r0 = returnFunc(ctx, salary)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockSalaryRepo_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
-type MockSalaryRepo_Update_Call struct {
+// MockUserRepo_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockUserRepo_Delete_Call struct {
 	*mock.Call
 }
 
-// Update is a helper method to define mock.On call
+// Delete is a helper method to define mock.On call
 //   - ctx
-//   - salary
-func (_e *MockSalaryRepo_Expecter) Update(ctx interface{}, salary interface{}) *MockSalaryRepo_Update_Call {
-	return &MockSalaryRepo_Update_Call{Call: _e.mock.On("Update", ctx, salary)}
+//   - userID
+func (_e *MockUserRepo_Expecter) Delete(ctx interface{}, userID interface{}) *MockUserRepo_Delete_Call {
+	return &MockUserRepo_Delete_Call{Call: _e.mock.On("Delete", ctx, userID)}
 }
 
-func (_c *MockSalaryRepo_Update_Call) Run(run func(ctx context.Context, salary *domain.Salary)) *MockSalaryRepo_Update_Call {
+func (_c *MockUserRepo_Delete_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockUserRepo_Delete_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*domain.Salary))
+		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockSalaryRepo_Update_Call) Return(err error) *MockSalaryRepo_Update_Call {
+func (_c *MockUserRepo_Delete_Call) Return(err error) *MockUserRepo_Delete_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockSalaryRepo_Update_Call) RunAndReturn(run func(ctx context.Context, salary *domain.Salary) error) *MockSalaryRepo_Update_Call {
+func (_c *MockUserRepo_Delete_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) error) *MockUserRepo_Delete_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockUserRepo creates a new instance of MockUserRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockUserRepo(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockUserRepo {
-	mock := &MockUserRepo{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockUserRepo is an autogenerated mock type for the UserRepo type
-type MockUserRepo struct {
-	mock.Mock
-}
-
-type MockUserRepo_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockUserRepo) EXPECT() *MockUserRepo_Expecter {
-	return &MockUserRepo_Expecter{mock: &_m.Mock}
-}
-
-// Create provides a mock function for the type MockUserRepo
-func (_mock *MockUserRepo) Create(ctx context.Context, user *domain.User, salary *domain.Salary) error {
-	ret := _mock.Called(ctx, user, salary)
+// DisableTOTP provides a mock function for the type MockUserRepo
+func (_mock *MockUserRepo) DisableTOTP(ctx context.Context, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for Create")
+		panic("no return value specified for DisableTOTP")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.User, *domain.Salary) error); ok {
-		r0 = returnFunc(ctx, user, salary)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockUserRepo_Create_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Create'
-type MockUserRepo_Create_Call struct {
+// MockUserRepo_DisableTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DisableTOTP'
+type MockUserRepo_DisableTOTP_Call struct {
 	*mock.Call
 }
 
-// Create is a helper method to define mock.On call
+// DisableTOTP is a helper method to define mock.On call
 //   - ctx
-//   - user
-//   - salary
-func (_e *MockUserRepo_Expecter) Create(ctx interface{}, user interface{}, salary interface{}) *MockUserRepo_Create_Call {
-	return &MockUserRepo_Create_Call{Call: _e.mock.On("Create", ctx, user, salary)}
+//   - userID
+func (_e *MockUserRepo_Expecter) DisableTOTP(ctx interface{}, userID interface{}) *MockUserRepo_DisableTOTP_Call {
+	return &MockUserRepo_DisableTOTP_Call{Call: _e.mock.On("DisableTOTP", ctx, userID)}
 }
 
-func (_c *MockUserRepo_Create_Call) Run(run func(ctx context.Context, user *domain.User, salary *domain.Salary)) *MockUserRepo_Create_Call {
+func (_c *MockUserRepo_DisableTOTP_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockUserRepo_DisableTOTP_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*domain.User), args[2].(*domain.Salary))
+		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *MockUserRepo_Create_Call) Return(err error) *MockUserRepo_Create_Call {
+func (_c *MockUserRepo_DisableTOTP_Call) Return(err error) *MockUserRepo_DisableTOTP_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockUserRepo_Create_Call) RunAndReturn(run func(ctx context.Context, user *domain.User, salary *domain.Salary) error) *MockUserRepo_Create_Call {
+func (_c *MockUserRepo_DisableTOTP_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) error) *MockUserRepo_DisableTOTP_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateToken provides a mock function for the type MockUserRepo
-func (_mock *MockUserRepo) CreateToken(ctx context.Context, token *domain.UserToken) error {
-	ret := _mock.Called(ctx, token)
+// EnableTOTP provides a mock function for the type MockUserRepo
+func (_mock *MockUserRepo) EnableTOTP(ctx context.Context, userID uuid.UUID, step int64, recoveryCodeHashes []string) error {
+	ret := _mock.Called(ctx, userID, step, recoveryCodeHashes)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateToken")
+		panic("no return value specified for EnableTOTP")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.UserToken) error); ok {
-		r0 = returnFunc(ctx, token)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64, []string) error); ok {
+		r0 = returnFunc(ctx, userID, step, recoveryCodeHashes)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockUserRepo_CreateToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateToken'
-type MockUserRepo_CreateToken_Call struct {
+// MockUserRepo_EnableTOTP_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnableTOTP'
+type MockUserRepo_EnableTOTP_Call struct {
 	*mock.Call
 }
 
-// CreateToken is a helper method to define mock.On call
+// EnableTOTP is a helper method to define mock.On call
 //   - ctx
-//   - token
-func (_e *MockUserRepo_Expecter) CreateToken(ctx interface{}, token interface{}) *MockUserRepo_CreateToken_Call {
-	return &MockUserRepo_CreateToken_Call{Call: _e.mock.On("CreateToken", ctx, token)}
+//   - userID
+//   - step
+//   - recoveryCodeHashes
+func (_e *MockUserRepo_Expecter) EnableTOTP(ctx interface{}, userID interface{}, step interface{}, recoveryCodeHashes interface{}) *MockUserRepo_EnableTOTP_Call {
+	return &MockUserRepo_EnableTOTP_Call{Call: _e.mock.On("EnableTOTP", ctx, userID, step, recoveryCodeHashes)}
 }
 
-func (_c *MockUserRepo_CreateToken_Call) Run(run func(ctx context.Context, token *domain.UserToken)) *MockUserRepo_CreateToken_Call {
+func (_c *MockUserRepo_EnableTOTP_Call) Run(run func(ctx context.Context, userID uuid.UUID, step int64, recoveryCodeHashes []string)) *MockUserRepo_EnableTOTP_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*domain.UserToken))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int64), args[3].([]string))
 	})
 	return _c
 }
 
-func (_c *MockUserRepo_CreateToken_Call) Return(err error) *MockUserRepo_CreateToken_Call {
+func (_c *MockUserRepo_EnableTOTP_Call) Return(err error) *MockUserRepo_EnableTOTP_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockUserRepo_CreateToken_Call) RunAndReturn(run func(ctx context.Context, token *domain.UserToken) error) *MockUserRepo_CreateToken_Call {
+func (_c *MockUserRepo_EnableTOTP_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, step int64, recoveryCodeHashes []string) error) *MockUserRepo_EnableTOTP_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1103,8 +5269,8 @@ func (_c *MockUserRepo_GetByEmail_Call) RunAndReturn(run func(ctx context.Contex
 }
 
 // GetUserTokenByToken provides a mock function for the type MockUserRepo
-func (_mock *MockUserRepo) GetUserTokenByToken(ctx context.Context, token string) (*domain.UserToken, error) {
-	ret := _mock.Called(ctx, token)
+func (_mock *MockUserRepo) GetUserTokenByToken(ctx context.Context, token string, purpose domain.TokenPurpose) (*domain.UserToken, error) {
+	ret := _mock.Called(ctx, token, purpose)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetUserTokenByToken")
@@ -1112,18 +5278,18 @@ func (_mock *MockUserRepo) GetUserTokenByToken(ctx context.Context, token string
 
 	var r0 *domain.UserToken
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.UserToken, error)); ok {
-		return returnFunc(ctx, token)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.TokenPurpose) (*domain.UserToken, error)); ok {
+		return returnFunc(ctx, token, purpose)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.UserToken); ok {
-		r0 = returnFunc(ctx, token)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, domain.TokenPurpose) *domain.UserToken); ok {
+		r0 = returnFunc(ctx, token, purpose)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*domain.UserToken)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, token)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, domain.TokenPurpose) error); ok {
+		r1 = returnFunc(ctx, token, purpose)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1138,13 +5304,14 @@ type MockUserRepo_GetUserTokenByToken_Call struct {
 // GetUserTokenByToken is a helper method to define mock.On call
 //   - ctx
 //   - token
-func (_e *MockUserRepo_Expecter) GetUserTokenByToken(ctx interface{}, token interface{}) *MockUserRepo_GetUserTokenByToken_Call {
-	return &MockUserRepo_GetUserTokenByToken_Call{Call: _e.mock.On("GetUserTokenByToken", ctx, token)}
+//   - purpose
+func (_e *MockUserRepo_Expecter) GetUserTokenByToken(ctx interface{}, token interface{}, purpose interface{}) *MockUserRepo_GetUserTokenByToken_Call {
+	return &MockUserRepo_GetUserTokenByToken_Call{Call: _e.mock.On("GetUserTokenByToken", ctx, token, purpose)}
 }
 
-func (_c *MockUserRepo_GetUserTokenByToken_Call) Run(run func(ctx context.Context, token string)) *MockUserRepo_GetUserTokenByToken_Call {
+func (_c *MockUserRepo_GetUserTokenByToken_Call) Run(run func(ctx context.Context, token string, purpose domain.TokenPurpose)) *MockUserRepo_GetUserTokenByToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(domain.TokenPurpose))
 	})
 	return _c
 }
@@ -1154,7 +5321,53 @@ func (_c *MockUserRepo_GetUserTokenByToken_Call) Return(userToken *domain.UserTo
 	return _c
 }
 
-func (_c *MockUserRepo_GetUserTokenByToken_Call) RunAndReturn(run func(ctx context.Context, token string) (*domain.UserToken, error)) *MockUserRepo_GetUserTokenByToken_Call {
+func (_c *MockUserRepo_GetUserTokenByToken_Call) RunAndReturn(run func(ctx context.Context, token string, purpose domain.TokenPurpose) (*domain.UserToken, error)) *MockUserRepo_GetUserTokenByToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkAsVerified provides a mock function for the type MockUserRepo
+func (_mock *MockUserRepo) MarkAsVerified(ctx context.Context, userID uuid.UUID) error {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkAsVerified")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepo_MarkAsVerified_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkAsVerified'
+type MockUserRepo_MarkAsVerified_Call struct {
+	*mock.Call
+}
+
+// MarkAsVerified is a helper method to define mock.On call
+//   - ctx
+//   - userID
+func (_e *MockUserRepo_Expecter) MarkAsVerified(ctx interface{}, userID interface{}) *MockUserRepo_MarkAsVerified_Call {
+	return &MockUserRepo_MarkAsVerified_Call{Call: _e.mock.On("MarkAsVerified", ctx, userID)}
+}
+
+func (_c *MockUserRepo_MarkAsVerified_Call) Run(run func(ctx context.Context, userID uuid.UUID)) *MockUserRepo_MarkAsVerified_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockUserRepo_MarkAsVerified_Call) Return(err error) *MockUserRepo_MarkAsVerified_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepo_MarkAsVerified_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID) error) *MockUserRepo_MarkAsVerified_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1205,6 +5418,100 @@ func (_c *MockUserRepo_MarkTokenAsUsed_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
+// SetTOTPSecret provides a mock function for the type MockUserRepo
+func (_mock *MockUserRepo) SetTOTPSecret(ctx context.Context, userID uuid.UUID, secret string) error {
+	ret := _mock.Called(ctx, userID, secret)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTOTPSecret")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = returnFunc(ctx, userID, secret)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepo_SetTOTPSecret_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTOTPSecret'
+type MockUserRepo_SetTOTPSecret_Call struct {
+	*mock.Call
+}
+
+// SetTOTPSecret is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - secret
+func (_e *MockUserRepo_Expecter) SetTOTPSecret(ctx interface{}, userID interface{}, secret interface{}) *MockUserRepo_SetTOTPSecret_Call {
+	return &MockUserRepo_SetTOTPSecret_Call{Call: _e.mock.On("SetTOTPSecret", ctx, userID, secret)}
+}
+
+func (_c *MockUserRepo_SetTOTPSecret_Call) Run(run func(ctx context.Context, userID uuid.UUID, secret string)) *MockUserRepo_SetTOTPSecret_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockUserRepo_SetTOTPSecret_Call) Return(err error) *MockUserRepo_SetTOTPSecret_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepo_SetTOTPSecret_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, secret string) error) *MockUserRepo_SetTOTPSecret_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateEmail provides a mock function for the type MockUserRepo
+func (_mock *MockUserRepo) UpdateEmail(ctx context.Context, userID uuid.UUID, email string) error {
+	ret := _mock.Called(ctx, userID, email)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEmail")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = returnFunc(ctx, userID, email)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepo_UpdateEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateEmail'
+type MockUserRepo_UpdateEmail_Call struct {
+	*mock.Call
+}
+
+// UpdateEmail is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - email
+func (_e *MockUserRepo_Expecter) UpdateEmail(ctx interface{}, userID interface{}, email interface{}) *MockUserRepo_UpdateEmail_Call {
+	return &MockUserRepo_UpdateEmail_Call{Call: _e.mock.On("UpdateEmail", ctx, userID, email)}
+}
+
+func (_c *MockUserRepo_UpdateEmail_Call) Run(run func(ctx context.Context, userID uuid.UUID, email string)) *MockUserRepo_UpdateEmail_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockUserRepo_UpdateEmail_Call) Return(err error) *MockUserRepo_UpdateEmail_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepo_UpdateEmail_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, email string) error) *MockUserRepo_UpdateEmail_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateUserPassword provides a mock function for the type MockUserRepo
 func (_mock *MockUserRepo) UpdateUserPassword(ctx context.Context, userID uuid.UUID, hashedPassword string) error {
 	ret := _mock.Called(ctx, userID, hashedPassword)
@@ -1251,3 +5558,97 @@ func (_c *MockUserRepo_UpdateUserPassword_Call) RunAndReturn(run func(ctx contex
 	_c.Call.Return(run)
 	return _c
 }
+
+// UseRecoveryCode provides a mock function for the type MockUserRepo
+func (_mock *MockUserRepo) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) error {
+	ret := _mock.Called(ctx, userID, codeHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseRecoveryCode")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) error); ok {
+		r0 = returnFunc(ctx, userID, codeHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepo_UseRecoveryCode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseRecoveryCode'
+type MockUserRepo_UseRecoveryCode_Call struct {
+	*mock.Call
+}
+
+// UseRecoveryCode is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - codeHash
+func (_e *MockUserRepo_Expecter) UseRecoveryCode(ctx interface{}, userID interface{}, codeHash interface{}) *MockUserRepo_UseRecoveryCode_Call {
+	return &MockUserRepo_UseRecoveryCode_Call{Call: _e.mock.On("UseRecoveryCode", ctx, userID, codeHash)}
+}
+
+func (_c *MockUserRepo_UseRecoveryCode_Call) Run(run func(ctx context.Context, userID uuid.UUID, codeHash string)) *MockUserRepo_UseRecoveryCode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockUserRepo_UseRecoveryCode_Call) Return(err error) *MockUserRepo_UseRecoveryCode_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepo_UseRecoveryCode_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, codeHash string) error) *MockUserRepo_UseRecoveryCode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UseTOTPStep provides a mock function for the type MockUserRepo
+func (_mock *MockUserRepo) UseTOTPStep(ctx context.Context, userID uuid.UUID, step int64) error {
+	ret := _mock.Called(ctx, userID, step)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UseTOTPStep")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64) error); ok {
+		r0 = returnFunc(ctx, userID, step)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepo_UseTOTPStep_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseTOTPStep'
+type MockUserRepo_UseTOTPStep_Call struct {
+	*mock.Call
+}
+
+// UseTOTPStep is a helper method to define mock.On call
+//   - ctx
+//   - userID
+//   - step
+func (_e *MockUserRepo_Expecter) UseTOTPStep(ctx interface{}, userID interface{}, step interface{}) *MockUserRepo_UseTOTPStep_Call {
+	return &MockUserRepo_UseTOTPStep_Call{Call: _e.mock.On("UseTOTPStep", ctx, userID, step)}
+}
+
+func (_c *MockUserRepo_UseTOTPStep_Call) Run(run func(ctx context.Context, userID uuid.UUID, step int64)) *MockUserRepo_UseTOTPStep_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockUserRepo_UseTOTPStep_Call) Return(err error) *MockUserRepo_UseTOTPStep_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepo_UseTOTPStep_Call) RunAndReturn(run func(ctx context.Context, userID uuid.UUID, step int64) error) *MockUserRepo_UseTOTPStep_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/brianvoe/gofakeit/v7"
 	"github.com/google/uuid"
+	"github.com/joaopsramos/fincon/internal/auth"
 	"github.com/joaopsramos/fincon/internal/domain"
 	"gorm.io/gorm"
 )
@@ -16,7 +17,17 @@ type Factory struct {
 }
 
 func NewFactory(tx *gorm.DB) *Factory {
-	return &Factory{tx: tx, faker: gofakeit.New(0)}
+	return NewSeededFactory(tx, 0)
+}
+
+// NewSeededFactory builds a factory whose fake data is always the same for a given seed,
+// 0 picks a random one
+func NewSeededFactory(tx *gorm.DB, seed uint64) *Factory {
+	return &Factory{tx: tx, faker: gofakeit.New(seed)}
+}
+
+func (f *Factory) Faker() *gofakeit.Faker {
+	return f.faker
 }
 
 func (f *Factory) InsertUser(u ...*domain.User) domain.User {
@@ -35,8 +46,53 @@ func (f *Factory) InsertExpense(e ...*domain.Expense) domain.Expense {
 	return insert(f, e, domain.Expense{Name: f.faker.ProductName(), Value: f.faker.Int64(), Date: f.faker.Date()})
 }
 
+func (f *Factory) InsertRecurringExpense(r ...*domain.RecurringExpense) domain.RecurringExpense {
+	return insert(f, r, domain.RecurringExpense{
+		Name:       f.faker.ProductName(),
+		Value:      f.faker.Int64(),
+		DayOfMonth: f.faker.IntRange(1, 28),
+		Cadence:    domain.Monthly,
+		StartDate:  f.faker.Date(),
+	})
+}
+
+func (f *Factory) InsertIncome(i ...*domain.Income) domain.Income {
+	return insert(f, i, domain.Income{Name: f.faker.JobTitle(), Amount: f.faker.Int64(), StartDate: f.faker.Date()})
+}
+
+func (f *Factory) InsertTag(t ...*domain.Tag) domain.Tag {
+	return insert(f, t, domain.Tag{Name: f.faker.Noun()})
+}
+
+func (f *Factory) InsertSavingsTarget(t ...*domain.SavingsTarget) domain.SavingsTarget {
+	startDate := f.faker.Date()
+
+	return insert(f, t, domain.SavingsTarget{
+		Name:         f.faker.Noun(),
+		TargetAmount: f.faker.Int64(),
+		StartDate:    startDate,
+		Deadline:     startDate.AddDate(1, 0, 0),
+	})
+}
+
+func (f *Factory) InsertSession(s ...*domain.Session) domain.Session {
+	return insert(f, s, domain.Session{
+		RefreshTokenHash: auth.HashToken(auth.GenerateRefreshToken()),
+		ExpiresAt:        time.Now().UTC().Add(auth.RefreshTokenExpiresIn),
+		LastUsedAt:       time.Now().UTC(),
+	})
+}
+
 func (f *Factory) InsertUserToken(t ...*domain.UserToken) domain.UserToken {
-	return insert(f, t, domain.UserToken{Token: uuid.New(), ExpiresAt: time.Now().UTC().Add(24 * time.Hour)})
+	return insert(f, t, domain.UserToken{
+		Token:     uuid.New(),
+		Purpose:   domain.PasswordResetToken,
+		ExpiresAt: time.Now().UTC().Add(24 * time.Hour),
+	})
+}
+
+func (f *Factory) InsertDigestPreference(p ...*domain.DigestPreference) domain.DigestPreference {
+	return insert(f, p, domain.DigestPreference{Frequency: domain.DigestMonthly})
 }
 
 func insert[T any](f *Factory, given []*T, fake T) T {
@@ -5,6 +5,8 @@
 package testhelper
 
 import (
+	"context"
+
 	"github.com/joaopsramos/fincon/internal/mail"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -37,16 +39,16 @@ func (_m *MockMailer) EXPECT() *MockMailer_Expecter {
 }
 
 // Send provides a mock function for the type MockMailer
-func (_mock *MockMailer) Send(email mail.Email) error {
-	ret := _mock.Called(email)
+func (_mock *MockMailer) Send(ctx context.Context, email mail.Email) error {
+	ret := _mock.Called(ctx, email)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Send")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(mail.Email) error); ok {
-		r0 = returnFunc(email)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, mail.Email) error); ok {
+		r0 = returnFunc(ctx, email)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -59,14 +61,15 @@ type MockMailer_Send_Call struct {
 }
 
 // Send is a helper method to define mock.On call
+//   - ctx
 //   - email
-func (_e *MockMailer_Expecter) Send(email interface{}) *MockMailer_Send_Call {
-	return &MockMailer_Send_Call{Call: _e.mock.On("Send", email)}
+func (_e *MockMailer_Expecter) Send(ctx interface{}, email interface{}) *MockMailer_Send_Call {
+	return &MockMailer_Send_Call{Call: _e.mock.On("Send", ctx, email)}
 }
 
-func (_c *MockMailer_Send_Call) Run(run func(email mail.Email)) *MockMailer_Send_Call {
+func (_c *MockMailer_Send_Call) Run(run func(ctx context.Context, email mail.Email)) *MockMailer_Send_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(mail.Email))
+		run(args[0].(context.Context), args[1].(mail.Email))
 	})
 	return _c
 }
@@ -76,7 +79,7 @@ func (_c *MockMailer_Send_Call) Return(err error) *MockMailer_Send_Call {
 	return _c
 }
 
-func (_c *MockMailer_Send_Call) RunAndReturn(run func(email mail.Email) error) *MockMailer_Send_Call {
+func (_c *MockMailer_Send_Call) RunAndReturn(run func(ctx context.Context, email mail.Email) error) *MockMailer_Send_Call {
 	_c.Call.Return(run)
 	return _c
 }
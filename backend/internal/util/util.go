@@ -1,6 +1,7 @@
 package util
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -13,13 +14,28 @@ import (
 
 type M = map[string]any
 
-const ApiDateLayout = "2006-01-02"
+const (
+	ApiDateLayout  = "2006-01-02"
+	ApiMonthLayout = "2006-01"
+)
 
 func ParseZodSchema(schema z.ComplexZogSchema, body io.Reader, dest any) map[string]any {
 	err := schema.Parse(zjson.Decode(body), dest)
 	return ParseZogErrors(err)
 }
 
+// IsNullField reports whether the JSON object in body sets key explicitly to null, which
+// the schemas can't tell apart from a missing key
+func IsNullField(body []byte, key string) bool {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return false
+	}
+
+	raw, ok := fields[key]
+	return ok && string(bytes.TrimSpace(raw)) == "null"
+}
+
 func ParseZogErrors(errs z.ZogErrMap) map[string]any {
 	if errs != nil {
 		sanitized := z.Errors.SanitizeMap(errs)
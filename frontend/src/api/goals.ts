@@ -18,6 +18,6 @@ export async function updateGoals(goals: Goal[], params: FormData) {
     return { id: goal.id, percentage: Number.parseInt(newPercentage) }
   })
 
-  const resp = await api.post("/goals", reqBody)
+  const resp = await api.put("/goals", reqBody)
   return resp.data as Goal[]
 }